@@ -0,0 +1,88 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/nacos-group/nacos-sdk-go/v2/clients"
+	"github.com/nacos-group/nacos-sdk-go/v2/common/constant"
+	"github.com/nacos-group/nacos-sdk-go/v2/vo"
+	"github.com/spf13/viper"
+)
+
+//注意，viper识别参数有问题，如果有'_','.'会出现解析不到的情况，使用`mapstructure:"yaml.name"`绑定
+
+// Config 与其他服务共用 Nacos 中的 shortlink 配置，只读取需要的部分
+type Config struct {
+	MySQL  MySQLConfig
+	Kafka  KafkaConfig
+	Logger LoggerConfig
+}
+
+type MySQLConfig struct {
+	Host            string
+	Port            int
+	User            string
+	Password        string
+	DBName          string
+	MaxIdleConns    int
+	MaxOpenConns    int
+	ConnMaxLifetime int
+}
+
+type KafkaConfig struct {
+	Brokers []string
+	// 点击事件的 Topic，默认 shortlink-click
+	ClickTopic string `mapstructure:"click_topic"`
+}
+
+type LoggerConfig struct {
+	Level string
+}
+
+var GlobalConfig Config
+
+// GetDSN 返回MySQL连接字符串
+func (c *MySQLConfig) GetDSN() string {
+	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+		c.User, c.Password, c.Host, c.Port, c.DBName)
+}
+
+// InitConfigFromNacos 从 Nacos 读取配置
+func InitConfigFromNacos() error {
+	serverConfigs := []constant.ServerConfig{
+		*constant.NewServerConfig("127.0.0.1", 8848), // Nacos 地址
+	}
+	clientConfig := *constant.NewClientConfig(
+		constant.WithNamespaceId(""), // 空为 public
+		constant.WithTimeoutMs(5000),
+		constant.WithNotLoadCacheAtStart(true),
+		constant.WithUpdateCacheWhenEmpty(false),
+		constant.WithLogLevel("info"),
+	)
+
+	client, err := clients.NewConfigClient(vo.NacosClientParam{
+		ClientConfig:  &clientConfig,
+		ServerConfigs: serverConfigs,
+	})
+	if err != nil {
+		return fmt.Errorf("❌ 创建 Nacos 客户端失败: %v", err)
+	}
+
+	content, err := client.GetConfig(vo.ConfigParam{
+		DataId: "shortlink",
+		Group:  "DEFAULT_GROUP",
+	})
+	if err != nil {
+		return fmt.Errorf("❌ 获取配置失败: %v", err)
+	}
+
+	viper.SetConfigType("yaml")
+	if err := viper.ReadConfig(bytes.NewBufferString(content)); err != nil {
+		return fmt.Errorf("❌ viper 解析失败: %v", err)
+	}
+	if err := viper.Unmarshal(&GlobalConfig); err != nil {
+		return fmt.Errorf("❌ 配置绑定结构体失败: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"shortLink/analyticsservice/logger"
+	"shortLink/analyticsservice/model"
+	"shortLink/common/event"
+
+	"github.com/IBM/sarama"
+	"go.uber.org/zap"
+)
+
+const (
+	flushSize     = 1000            // 累加到这么多点击后写入数据库
+	flushInterval = 2 * time.Second // 最长的写入间隔
+	maxRetryDelay = time.Minute     // 写入失败后重试间隔的上限
+)
+
+// ClickConsumer 消费点击事件，按小时、天和维度累加后批量写入统计表
+// 写入成功后才提交 offset，服务异常退出时未提交的事件会被重新消费（至少一次）
+// 统计表记录了每个分区已写入的 offset（见 model.ConsumerOffset），重新消费时跳过已写入的事件，不会重复累加
+type ClickConsumer struct{}
+
+func (ClickConsumer) Setup(sarama.ConsumerGroupSession) error   { return nil }
+func (ClickConsumer) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+
+func (ClickConsumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	applied, err := model.GetAppliedOffset(claim.Topic(), claim.Partition())
+	if err != nil {
+		return fmt.Errorf("查询已写入的 offset 失败: %w", err)
+	}
+
+	rollup := NewRollup()
+	var first, last *sarama.ConsumerMessage
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	// 写入失败时暂停消费，按指数退避重试，避免内存中的数据无限增长
+	messages := claim.Messages()
+	retryDelay := flushInterval
+	var retryAt time.Time
+
+	flush := func() {
+		if last == nil {
+			return
+		}
+		if rollup.Len() > 0 {
+			hourly, daily, dimensions := rollup.Rows()
+			saved, err := model.SaveRollup(claim.Topic(), claim.Partition(), first.Offset, last.Offset, hourly, daily, dimensions)
+			if err != nil {
+				// 保留已累加的数据，退避后重试
+				logger.Log.Error("写入点击统计失败，暂停消费",
+					zap.Int32("partition", claim.Partition()),
+					zap.Int("clicks", rollup.Len()),
+					zap.Duration("retry", retryDelay),
+					zap.Error(err))
+				messages = nil
+				retryAt = time.Now().Add(retryDelay)
+				retryDelay = min(retryDelay*2, maxRetryDelay)
+				return
+			}
+			if !saved {
+				logger.Log.Warn("点击事件已被写入，跳过",
+					zap.Int32("partition", claim.Partition()),
+					zap.Int64("first", first.Offset),
+					zap.Int64("last", last.Offset))
+			}
+		}
+		session.MarkMessage(last, "")
+		rollup = NewRollup()
+		first, last = nil, nil
+		messages = claim.Messages()
+		retryDelay = flushInterval
+	}
+
+	for {
+		select {
+		case msg, ok := <-messages:
+			if !ok {
+				flush()
+				return nil
+			}
+			if msg.Offset <= applied {
+				// 上次写入统计表后 offset 没有提交成功，这些事件已经统计过
+				session.MarkMessage(msg, "")
+				continue
+			}
+			var e event.Click
+			if err := json.Unmarshal(msg.Value, &e); err != nil {
+				// 格式错误的事件直接跳过，避免阻塞分区
+				logger.Log.Warn("点击事件格式错误",
+					zap.Int32("partition", msg.Partition),
+					zap.Int64("offset", msg.Offset),
+					zap.Error(err))
+			} else {
+				rollup.Add(&e)
+			}
+			if first == nil {
+				first = msg
+			}
+			last = msg
+			if rollup.Len() >= flushSize {
+				flush()
+			}
+		case <-ticker.C:
+			if time.Now().After(retryAt) {
+				flush()
+			}
+		case <-session.Context().Done():
+			flush()
+			return nil
+		}
+	}
+}
+
+// StartClickConsumer 启动点击事件消费者，ctx 取消后返回
+func StartClickConsumer(ctx context.Context, brokers []string, groupID string, topics []string) error {
+	config := sarama.NewConfig()
+	//仅在该 消费者组是首次消费某个 topic 的时候，从最早的消息（offset = 0）开始消费。
+	config.Consumer.Offsets.Initial = sarama.OffsetOldest
+	config.Version = sarama.V2_1_0_0
+	config.Consumer.Return.Errors = true
+
+	consumer, err := sarama.NewConsumerGroup(brokers, groupID, config)
+	if err != nil {
+		return fmt.Errorf("无法创建消费者组: %w", err)
+	}
+	defer consumer.Close()
+
+	go func() {
+		for err := range consumer.Errors() {
+			logger.Log.Error("消费点击事件出错", zap.Error(err))
+		}
+	}()
+
+	logger.Log.Info("启动点击事件消费者", zap.Strings("topics", topics), zap.String("group", groupID))
+	for ctx.Err() == nil {
+		if err := consumer.Consume(ctx, topics, ClickConsumer{}); err != nil {
+			logger.Log.Error("消费循环错误", zap.Error(err))
+			time.Sleep(time.Second)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package consumer
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"shortLink/analyticsservice/model"
+	"shortLink/common/event"
+)
+
+// 维度值的最大长度，超过后截断
+const maxDimensionValueLen = 191
+
+type hourKey struct {
+	link string
+	hour time.Time
+}
+
+type dayKey struct {
+	link string
+	day  time.Time
+}
+
+// clickCount 一小时或一天的点击量，机器人点击单独计数
+type clickCount struct {
+	clicks int64
+	bot    int64
+}
+
+type dimensionKey struct {
+	dayKey
+	dimension string
+	value     string
+}
+
+// Rollup 在内存中累加一批点击事件，写入数据库时每个 key 只写一行
+type Rollup struct {
+	hourly     map[hourKey]*clickCount
+	daily      map[dayKey]*clickCount
+	dimensions map[dimensionKey]int64
+	count      int
+}
+
+// NewRollup 创建空的累加器
+func NewRollup() *Rollup {
+	return &Rollup{
+		hourly:     make(map[hourKey]*clickCount),
+		daily:      make(map[dayKey]*clickCount),
+		dimensions: make(map[dimensionKey]int64),
+	}
+}
+
+// Add 累加一次点击，按本地时区划分小时和天
+// 机器人点击只计入当小时和当天的机器人点击量，不计入点击量和各维度
+func (r *Rollup) Add(e *event.Click) {
+	if e.Code == "" {
+		return
+	}
+	at := time.UnixMilli(e.Timestamp).In(time.Local)
+	hour := hourKey{link: e.Code, hour: at.Truncate(time.Hour)}
+	day := dayKey{link: e.Code, day: time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.Local)}
+
+	hourCount := r.hourly[hour]
+	if hourCount == nil {
+		hourCount = &clickCount{}
+		r.hourly[hour] = hourCount
+	}
+	dayCount := r.daily[day]
+	if dayCount == nil {
+		dayCount = &clickCount{}
+		r.daily[day] = dayCount
+	}
+	r.count++
+	if e.Bot != "" {
+		hourCount.bot++
+		dayCount.bot++
+		return
+	}
+	hourCount.clicks++
+	dayCount.clicks++
+	r.dimensions[dimensionKey{dayKey: day, dimension: model.DimensionCountry, value: orUnknown(e.Country)}]++
+	r.dimensions[dimensionKey{dayKey: day, dimension: model.DimensionDevice, value: orUnknown(e.Device)}]++
+	r.dimensions[dimensionKey{dayKey: day, dimension: model.DimensionReferer, value: RefererDomain(e.Referer)}]++
+	r.dimensions[dimensionKey{dayKey: day, dimension: model.DimensionBrowser, value: orUnknown(e.Browser)}]++
+	r.dimensions[dimensionKey{dayKey: day, dimension: model.DimensionOS, value: orUnknown(e.OS)}]++
+}
+
+// Len 已累加的点击数
+func (r *Rollup) Len() int {
+	return r.count
+}
+
+// Rows 转换为待写入的统计行
+func (r *Rollup) Rows() ([]model.LinkClickHourly, []model.LinkClickDaily, []model.LinkClickDimension) {
+	hourly := make([]model.LinkClickHourly, 0, len(r.hourly))
+	for k, n := range r.hourly {
+		hourly = append(hourly, model.LinkClickHourly{LinkKey: k.link, Hour: k.hour, Clicks: n.clicks, BotClicks: n.bot})
+	}
+	daily := make([]model.LinkClickDaily, 0, len(r.daily))
+	for k, n := range r.daily {
+		daily = append(daily, model.LinkClickDaily{LinkKey: k.link, Day: k.day, Clicks: n.clicks, BotClicks: n.bot})
+	}
+	return hourly, daily, r.dimensionRows()
+}
+
+// dimensionRows 每个短链接每天每个维度保留点击量最高的 model.MaxDimensionValues 个取值，其余合并为 model.DimensionOther
+// 写入时还会结合已保存的取值再次限制，见 model.SaveRollup
+func (r *Rollup) dimensionRows() []model.LinkClickDimension {
+	groups := make(map[dimensionKey][]model.LinkClickDimension)
+	for k, n := range r.dimensions {
+		g := dimensionKey{dayKey: k.dayKey, dimension: k.dimension}
+		groups[g] = append(groups[g], model.LinkClickDimension{
+			LinkKey:   k.link,
+			Day:       k.day,
+			Dimension: k.dimension,
+			Value:     k.value,
+			Clicks:    n,
+		})
+	}
+	rows := make([]model.LinkClickDimension, 0, len(r.dimensions))
+	for _, values := range groups {
+		if len(values) <= model.MaxDimensionValues {
+			rows = append(rows, values...)
+			continue
+		}
+		sort.Slice(values, func(i, j int) bool {
+			if values[i].Clicks != values[j].Clicks {
+				return values[i].Clicks > values[j].Clicks
+			}
+			return values[i].Value < values[j].Value
+		})
+		other := values[model.MaxDimensionValues]
+		other.Value = model.DimensionOther
+		for _, v := range values[model.MaxDimensionValues+1:] {
+			other.Clicks += v.Clicks
+		}
+		rows = append(rows, values[:model.MaxDimensionValues]...)
+		rows = append(rows, other)
+	}
+	return rows
+}
+
+// RefererDomain 提取来源的域名，没有来源或无法解析时返回 direct
+func RefererDomain(referer string) string {
+	if referer == "" {
+		return "direct"
+	}
+	u, err := url.Parse(referer)
+	if err != nil || u.Hostname() == "" {
+		return "direct"
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	return truncate(host)
+}
+
+func orUnknown(v string) string {
+	if v == "" {
+		return "unknown"
+	}
+	return truncate(v)
+}
+
+func truncate(v string) string {
+	if len(v) > maxDimensionValueLen {
+		return v[:maxDimensionValueLen]
+	}
+	return v
+}
@@ -0,0 +1,116 @@
+package consumer
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"shortLink/analyticsservice/model"
+	"shortLink/common/event"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollup(t *testing.T) {
+	base := time.Date(2025, 3, 1, 10, 15, 0, 0, time.Local)
+
+	t.Run("按小时和天累加", func(t *testing.T) {
+		r := NewRollup()
+		r.Add(&event.Click{Code: "abc", Timestamp: base.UnixMilli(), Country: "CN", Device: "mobile", Browser: "Safari", OS: "iOS"})
+		r.Add(&event.Click{Code: "abc", Timestamp: base.Add(30 * time.Minute).UnixMilli(), Country: "CN", Device: "desktop"})
+		r.Add(&event.Click{Code: "abc", Timestamp: base.Add(time.Hour).UnixMilli(), Referer: "https://www.Google.com/search?q=1"})
+		r.Add(&event.Click{Code: "s.example.com/xyz", Timestamp: base.UnixMilli()})
+		assert.Equal(t, 4, r.Len())
+
+		hourly, daily, dimensions := r.Rows()
+		hours := map[string]int64{}
+		for _, h := range hourly {
+			hours[h.LinkKey+"@"+h.Hour.Format("15:04")] = h.Clicks
+		}
+		assert.Equal(t, map[string]int64{"abc@10:00": 2, "abc@11:00": 1, "s.example.com/xyz@10:00": 1}, hours)
+
+		days := map[string]int64{}
+		for _, d := range daily {
+			days[d.LinkKey] = d.Clicks
+			assert.Equal(t, "2025-03-01", d.Day.Format("2006-01-02"))
+		}
+		assert.Equal(t, map[string]int64{"abc": 3, "s.example.com/xyz": 1}, days)
+
+		dims := map[string]int64{}
+		for _, d := range dimensions {
+			if d.LinkKey == "abc" {
+				dims[d.Dimension+":"+d.Value] = d.Clicks
+			}
+		}
+		assert.Equal(t, int64(2), dims[model.DimensionCountry+":CN"])
+		assert.Equal(t, int64(1), dims[model.DimensionCountry+":unknown"])
+		assert.Equal(t, int64(1), dims[model.DimensionDevice+":mobile"])
+		assert.Equal(t, int64(2), dims[model.DimensionReferer+":direct"])
+		assert.Equal(t, int64(1), dims[model.DimensionReferer+":google.com"])
+		assert.Equal(t, int64(1), dims[model.DimensionBrowser+":Safari"])
+		assert.Equal(t, int64(2), dims[model.DimensionOS+":unknown"])
+	})
+
+	t.Run("机器人点击单独计数", func(t *testing.T) {
+		r := NewRollup()
+		r.Add(&event.Click{Code: "abc", Timestamp: base.UnixMilli(), Country: "CN"})
+		r.Add(&event.Click{Code: "abc", Timestamp: base.UnixMilli(), Bot: "user_agent"})
+		r.Add(&event.Click{Code: "xyz", Timestamp: base.UnixMilli(), Bot: "repeated"})
+		assert.Equal(t, 3, r.Len())
+
+		hourly, daily, dimensions := r.Rows()
+		hours := map[string][2]int64{}
+		for _, h := range hourly {
+			hours[h.LinkKey] = [2]int64{h.Clicks, h.BotClicks}
+		}
+		assert.Equal(t, map[string][2]int64{"abc": {1, 1}, "xyz": {0, 1}}, hours)
+		days := map[string][2]int64{}
+		for _, d := range daily {
+			days[d.LinkKey] = [2]int64{d.Clicks, d.BotClicks}
+		}
+		assert.Equal(t, map[string][2]int64{"abc": {1, 1}, "xyz": {0, 1}}, days)
+		for _, d := range dimensions {
+			assert.Equal(t, "abc", d.LinkKey)
+			assert.Equal(t, int64(1), d.Clicks)
+		}
+	})
+
+	t.Run("每个维度的取值数量有上限", func(t *testing.T) {
+		r := NewRollup()
+		// 一个主要来源，加上远超上限的长尾来源
+		for i := 0; i < 5; i++ {
+			r.Add(&event.Click{Code: "abc", Timestamp: base.UnixMilli(), Referer: "https://news.example.com/"})
+		}
+		extra := model.MaxDimensionValues + 20
+		for i := 0; i < extra; i++ {
+			r.Add(&event.Click{Code: "abc", Timestamp: base.UnixMilli(), Referer: fmt.Sprintf("https://spam%d.example.net/", i)})
+		}
+
+		_, _, dimensions := r.Rows()
+		referers := map[string]int64{}
+		var total int64
+		for _, d := range dimensions {
+			if d.Dimension == model.DimensionReferer {
+				referers[d.Value] = d.Clicks
+				total += d.Clicks
+			}
+		}
+		assert.Len(t, referers, model.MaxDimensionValues+1)
+		assert.Equal(t, int64(5), referers["news.example.com"])
+		assert.Equal(t, int64(21), referers[model.DimensionOther])
+		assert.Equal(t, int64(5+extra), total)
+	})
+
+	t.Run("忽略没有短链接的事件", func(t *testing.T) {
+		r := NewRollup()
+		r.Add(&event.Click{Timestamp: base.UnixMilli()})
+		assert.Equal(t, 0, r.Len())
+	})
+}
+
+func TestRefererDomain(t *testing.T) {
+	assert.Equal(t, "direct", RefererDomain(""))
+	assert.Equal(t, "direct", RefererDomain("not a url"))
+	assert.Equal(t, "t.co", RefererDomain("https://t.co/abc"))
+	assert.Equal(t, "example.com", RefererDomain("http://WWW.example.com:8080/page"))
+}
@@ -0,0 +1,17 @@
+package logger
+
+import (
+	"os"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var Log *zap.Logger
+
+// InitLogger 初始化日志，输出到标准输出
+func InitLogger(level zapcore.Level) {
+	encoder := zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
+	core := zapcore.NewCore(encoder, zapcore.AddSync(os.Stdout), level)
+	Log = zap.New(core, zap.AddCaller())
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"shortLink/analyticsservice/config"
+	"shortLink/analyticsservice/consumer"
+	"shortLink/analyticsservice/logger"
+	"shortLink/analyticsservice/model"
+	"shortLink/common/event"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const groupID = "analytics-service-group"
+
+func main() {
+	// 初始化配置
+	if err := config.InitConfigFromNacos(); err != nil {
+		log.Fatalf("❌ 初始化配置失败: %v", err)
+	}
+
+	// 初始化日志
+	logger.InitLogger(zapcore.InfoLevel)
+
+	// 初始化数据库
+	if err := model.InitDB(config.GlobalConfig.MySQL.GetDSN()); err != nil {
+		log.Fatalf("❌ 初始化数据库失败: %v", err)
+	}
+
+	topic := config.GlobalConfig.Kafka.ClickTopic
+	if topic == "" {
+		topic = event.ClickTopic
+	}
+
+	// 优雅退出：取消后消费者写入已累加的统计再返回
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+		<-sigCh
+		log.Println("正在关闭服务...")
+		cancel()
+	}()
+
+	log.Println("✅ analytics-service 启动，开始消费点击事件")
+	if err := consumer.StartClickConsumer(ctx, config.GlobalConfig.Kafka.Brokers, groupID, []string{topic}); err != nil {
+		logger.Log.Fatal("点击事件消费者启动失败", zap.Error(err))
+	}
+	log.Println("🛑 analytics-service 退出")
+}
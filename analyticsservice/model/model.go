@@ -0,0 +1,229 @@
+package model
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var db *gorm.DB
+
+func GetDB() *gorm.DB {
+	return db
+}
+
+func InitDB(dataSource string) error {
+	var err error
+	db, err = gorm.Open(mysql.Open(dataSource), &gorm.Config{})
+	if err != nil {
+		return err
+	}
+	// 自动建表
+	return db.AutoMigrate(&LinkClickHourly{}, &LinkClickDaily{}, &LinkClickDimension{}, &ConsumerOffset{})
+}
+
+// 维度统计的类型
+const (
+	DimensionCountry = "country"
+	DimensionDevice  = "device"
+	DimensionReferer = "referer" // 来源域名，没有来源时为 direct
+	DimensionBrowser = "browser"
+	DimensionOS      = "os"
+)
+
+// DimensionOther 超出取值上限后合并的取值，真实的取值（域名、浏览器名等）不会带括号
+const DimensionOther = "(other)"
+
+// MaxDimensionValues 每个短链接每天每个维度最多保存的取值数量，之后出现的新取值合并为 DimensionOther，
+// 避免大量垃圾来源等长尾取值让统计表无限增长
+const MaxDimensionValues = 50
+
+// LinkClickHourly 短链接每小时的点击量
+type LinkClickHourly struct {
+	LinkKey   string    `gorm:"primaryKey;size:191"` // 与 shortlinkcore 的 model.LinkKey 一致
+	Hour      time.Time `gorm:"primaryKey"`          // 小时的开始时间
+	Clicks    int64
+	BotClicks int64 `gorm:"not null;default:0"` // 机器人点击量，不计入 Clicks
+}
+
+func (LinkClickHourly) TableName() string {
+	return "link_click_hourly"
+}
+
+// LinkClickDaily 短链接每天的点击量
+type LinkClickDaily struct {
+	LinkKey   string    `gorm:"primaryKey;size:191"`
+	Day       time.Time `gorm:"primaryKey;type:date"`
+	Clicks    int64
+	BotClicks int64 `gorm:"not null;default:0"` // 机器人点击量，不计入 Clicks
+}
+
+func (LinkClickDaily) TableName() string {
+	return "link_click_daily"
+}
+
+// LinkClickDimension 短链接每天按维度（国家、设备、来源、浏览器、操作系统）的点击量
+type LinkClickDimension struct {
+	LinkKey   string    `gorm:"primaryKey;size:191"`
+	Day       time.Time `gorm:"primaryKey;type:date"`
+	Dimension string    `gorm:"primaryKey;size:16"`
+	Value     string    `gorm:"primaryKey;size:191"`
+	Clicks    int64
+}
+
+func (LinkClickDimension) TableName() string {
+	return "link_click_dimension"
+}
+
+// addClicks 已存在的行累加点击量
+var addClicks = clause.OnConflict{
+	DoUpdates: clause.Assignments(map[string]any{"clicks": gorm.Expr("clicks + VALUES(clicks)")}),
+}
+
+// addClickCounts 已存在的行累加点击量和机器人点击量
+var addClickCounts = clause.OnConflict{
+	DoUpdates: clause.Assignments(map[string]any{
+		"clicks":     gorm.Expr("clicks + VALUES(clicks)"),
+		"bot_clicks": gorm.Expr("bot_clicks + VALUES(bot_clicks)"),
+	}),
+}
+
+// ConsumerOffset 每个分区已经写入统计表的最后一条事件的 offset
+// 与统计数据在同一个事务中更新，重新消费已写入的事件时据此跳过，避免重复累加
+type ConsumerOffset struct {
+	Topic      string `gorm:"primaryKey;size:191"`
+	Partition  int32  `gorm:"primaryKey"`
+	Offset     int64
+	UpdateTime time.Time `gorm:"autoUpdateTime"`
+}
+
+func (ConsumerOffset) TableName() string {
+	return "consumer_offset"
+}
+
+// GetAppliedOffset 查询分区已写入的最后一个 offset，没有写入过时返回 -1
+func GetAppliedOffset(topic string, partition int32) (int64, error) {
+	var o ConsumerOffset
+	err := db.First(&o, "topic = ? AND `partition` = ?", topic, partition).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return -1, nil
+	}
+	if err != nil {
+		return -1, err
+	}
+	return o.Offset, nil
+}
+
+// SaveRollup 在一个事务中累加分区 [first, last] 范围内事件的点击量，并记录 last 为已写入的 offset
+// 失败时整批回滚，可以原样重试；范围已被写入过时（如分区重新分配后旧消费者仍在写入）不做任何修改，返回 false
+func SaveRollup(topic string, partition int32, first, last int64,
+	hourly []LinkClickHourly, daily []LinkClickDaily, dimensions []LinkClickDimension) (bool, error) {
+	saved := false
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// 先插入再加锁，保证并发写入同一分区时串行执行
+		offset := ConsumerOffset{Topic: topic, Partition: partition, Offset: -1}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&offset).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&offset, "topic = ? AND `partition` = ?", topic, partition).Error; err != nil {
+			return err
+		}
+		if offset.Offset >= first {
+			return nil
+		}
+		if len(hourly) > 0 {
+			if err := tx.Clauses(addClickCounts).CreateInBatches(hourly, 500).Error; err != nil {
+				return err
+			}
+		}
+		if len(daily) > 0 {
+			if err := tx.Clauses(addClickCounts).CreateInBatches(daily, 500).Error; err != nil {
+				return err
+			}
+		}
+		if len(dimensions) > 0 {
+			capped, err := capDimensions(tx, dimensions)
+			if err != nil {
+				return err
+			}
+			if err := tx.Clauses(addClicks).CreateInBatches(capped, 500).Error; err != nil {
+				return err
+			}
+		}
+		saved = true
+		return tx.Model(&ConsumerOffset{}).
+			Where("topic = ? AND `partition` = ?", topic, partition).
+			Update("offset", last).Error
+	})
+	return saved, err
+}
+
+// dimensionGroup 同一短链接、同一天、同一维度的取值
+type dimensionGroup struct {
+	linkKey   string
+	day       string
+	dimension string
+}
+
+func groupOf(r LinkClickDimension) dimensionGroup {
+	return dimensionGroup{linkKey: r.LinkKey, day: r.Day.Format("2006-01-02"), dimension: r.Dimension}
+}
+
+// capDimensions 已保存的取值照常累加，新取值在数量达到 MaxDimensionValues 后合并为 DimensionOther
+// 同一短链接的事件在同一分区，由分区的 offset 行锁保证不会并发写入，上限是准确的
+func capDimensions(tx *gorm.DB, rows []LinkClickDimension) ([]LinkClickDimension, error) {
+	saved := make(map[dimensionGroup]map[string]bool)
+	tuples := make([][]any, 0)
+	for _, r := range rows {
+		if g := groupOf(r); saved[g] == nil {
+			saved[g] = make(map[string]bool)
+			tuples = append(tuples, []any{r.LinkKey, r.Day, r.Dimension})
+		}
+	}
+	var existing []LinkClickDimension
+	err := tx.Select("link_key", "day", "dimension", "value").
+		Where("(link_key, day, dimension) IN ? AND value <> ?", tuples, DimensionOther).
+		Find(&existing).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range existing {
+		if values := saved[groupOf(e)]; values != nil {
+			values[e.Value] = true
+		}
+	}
+
+	// 点击量多的新取值优先保存
+	sorted := append([]LinkClickDimension(nil), rows...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Clicks > sorted[j].Clicks })
+	result := make([]LinkClickDimension, 0, len(sorted))
+	other := make(map[dimensionGroup]*LinkClickDimension)
+	for _, r := range sorted {
+		g := groupOf(r)
+		values := saved[g]
+		if r.Value != DimensionOther && !values[r.Value] && len(values) < MaxDimensionValues {
+			values[r.Value] = true
+		}
+		if values[r.Value] {
+			result = append(result, r)
+			continue
+		}
+		// 超出上限的新取值以及本批已经合并的 DimensionOther
+		if o := other[g]; o != nil {
+			o.Clicks += r.Clicks
+			continue
+		}
+		o := r
+		o.Value = DimensionOther
+		other[g] = &o
+	}
+	for _, o := range other {
+		result = append(result, *o)
+	}
+	return result, nil
+}
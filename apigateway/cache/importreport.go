@@ -0,0 +1,23 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// CSV 导入的错误报告，保留一天供下载
+const importReportTTL = time.Hour * 24
+
+// ImportReportKey 返回导入错误报告的key，按用户隔离
+func ImportReportKey(userID, reportID string) string {
+	return fmt.Sprintf("import:report:%s:%s", userID, reportID)
+}
+
+// SetImportReport 保存导入错误报告
+func SetImportReport(key string, data []byte) error {
+	if rdb == nil {
+		return errors.New("Redis未初始化")
+	}
+	return rdb.Set(ctx, key, data, importReportTTL).Err()
+}
@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"fmt"
+	"time"
+)
+
+// 二维码图片缓存，key 包含短链接和参数摘要
+// 参数组合很多，缓存只用于吸收短时间内的重复请求，过期时间不宜过长
+// 浏览器和 CDN 的缓存时间与之相同，短链接删除或封禁后最多一小时就不再返回二维码
+const QRTTL = time.Hour
+
+// QRKey 返回二维码缓存的key
+func QRKey(code, optionHash string) string {
+	return fmt.Sprintf("qr:%s:%s", code, optionHash)
+}
+
+// GetBytes 读取二进制缓存，未命中返回nil
+func GetBytes(key string) []byte {
+	if rdb == nil {
+		return nil
+	}
+	val, err := rdb.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil
+	}
+	return val
+}
+
+// SetQR 缓存二维码图片
+func SetQR(key string, data []byte) {
+	if rdb == nil {
+		fmt.Println("Redis未初始化")
+		return
+	}
+	if err := rdb.Set(ctx, key, data, QRTTL).Err(); err != nil {
+		fmt.Printf("设置二维码缓存失败: %v\n", err)
+	}
+}
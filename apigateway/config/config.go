@@ -60,6 +60,10 @@ type AppConfig struct {
 	JWTExpire    int
 	Base62Length int
 	MaxRetries   int `mapstructure:"max_retries"`
+	// 可信代理（nginx）地址，只有来自这些地址的 X-Forwarded-For 才会被采信
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+	// 对外的短链接域名（如 https://s.example.com），二维码等场景用它拼接完整短链接
+	ShortDomain string `mapstructure:"short_domain"`
 }
 
 type NacosConfig struct {
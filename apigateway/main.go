@@ -1,28 +1,142 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
+	"regexp"
 	"shortLink/apigateway/cache"
 	"shortLink/apigateway/config"
 	"shortLink/apigateway/middleware"
+	"shortLink/apigateway/pkg/csvimport"
+	"shortLink/apigateway/pkg/deeplink"
 	"shortLink/apigateway/pkg/discovery"
+	"shortLink/apigateway/pkg/export"
+	"shortLink/apigateway/pkg/qrcode"
 	pbShortlink "shortLink/proto/shortlinkpb"
 	pb "shortLink/proto/userpb"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
-// 获取user-service实例
-func getUserServiceClient() (pb.UserServiceClient, error) {
+// 跳转时转发给短链接服务的请求头
+var forwardHeaders = []string{"User-Agent", "Referer", "Accept-Language"}
+
+// 默认可信代理：本机和 docker 网段内的 nginx
+var defaultTrustedProxies = []string{"127.0.0.1", "::1", "172.16.0.0/12"}
+
+// 访问者Cookie，用于 A/B 分流保持粘性
+const visitorCookie = "sl_vid"
+
+// visitorID 读取访问者Cookie，不存在时在响应中下发新的Cookie并返回空
+// 本次请求没有带Cookie时返回空，短链接服务按IP保持粘性；不保存Cookie的客户端每次都按IP分流，结果稳定
+func visitorID(c *gin.Context) string {
+	if id, err := c.Cookie(visitorCookie); err == nil && id != "" {
+		return id
+	}
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(visitorCookie, uuid.NewString(), 365*24*3600, "/", "", false, true)
+	return ""
+}
+
+// shortLinkURL 拼接完整的短链接地址
+// host 为用户的自定义域名，为空时使用对外域名；未配置对外域名时，使用当前请求的协议和域名
+func shortLinkURL(c *gin.Context, host, code string) string {
+	domain := strings.TrimRight(config.GlobalConfig.App.ShortDomain, "/")
+	if domain == "" || host != "" {
+		scheme := "http"
+		if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" || strings.HasPrefix(domain, "https://") {
+			scheme = "https"
+		}
+		if host == "" {
+			host = c.Request.Host
+		}
+		domain = scheme + "://" + host
+	}
+	return domain + "/" + code
+}
+
+// linkDomain 短链接所在的域名，管理接口通过 ?domain= 指定，为空表示默认域名
+func linkDomain(c *gin.Context, fromBody string) string {
+	if d := c.Query("domain"); d != "" {
+		return d
+	}
+	return fromBody
+}
+
+// workspaceError 将工作区服务返回的错误转换为 HTTP 响应
+func workspaceError(c *gin.Context, err error, fallback string) {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": status.Convert(err).Message(), "data": nil})
+	case codes.PermissionDenied:
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": status.Convert(err).Message(), "data": nil})
+	case codes.NotFound:
+		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": status.Convert(err).Message(), "data": nil})
+	case codes.AlreadyExists, codes.FailedPrecondition:
+		c.JSON(http.StatusConflict, gin.H{"code": 409, "message": status.Convert(err).Message(), "data": nil})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": fallback, "data": nil})
+	}
+}
+
+// topLinks 按 ?window=&mode=&limit= 查询热门短链接排行，req 中已填好排行范围
+func topLinks(c *gin.Context, shortlinkClient pbShortlink.ShortlinkServiceClient, req *pbShortlink.TopRequest) {
+	req.Count, _ = strconv.ParseInt(c.Query("limit"), 10, 64)
+	req.Window = c.Query("window")
+	req.Mode = c.Query("mode")
+
+	// 超时2秒就返回
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	resp, err := shortlinkClient.GetTopLinks(ctx, req)
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": status.Convert(err).Message(), "data": nil})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取排行榜失败", "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"code": 200, "message": "获取成功", "data": gin.H{
+		"top":    resp.Top,
+		"window": resp.Window,
+		"mode":   resp.Mode,
+	}})
+}
+
+// 域名只包含字母、数字、连字符和点
+var hostPattern = regexp.MustCompile(`^[0-9A-Za-z.-]{1,253}$`)
+
+// 短链接只包含 Base62 字符，自定义短链接还可以包含下划线和连字符
+var shortCodePattern = regexp.MustCompile(`^[0-9A-Za-z_-]{1,32}$`)
+
+// CSV 导入的文件大小和行数上限
+const (
+	maxImportSize = 5 << 20
+	maxImportRows = 5000
+)
+
+// 获取user-service连接，用户、RBAC 和工作区服务共用
+func getUserServiceConn() (*grpc.ClientConn, error) {
 	// 重试3次
 	for i := 0; i < 3; i++ {
 		instances, err := discovery.GetServiceInstances("user-service1")
@@ -51,7 +165,7 @@ func getUserServiceClient() (pb.UserServiceClient, error) {
 			continue
 		}
 
-		return pb.NewUserServiceClient(conn), nil
+		return conn, nil
 	}
 
 	return nil, fmt.Errorf("无法连接到user-service，已重试3次")
@@ -112,10 +226,14 @@ func main() {
 	cache.InitRedis(config.GlobalConfig.Redis.Host, config.GlobalConfig.Redis.Password, config.GlobalConfig.Redis.Port, config.GlobalConfig.Redis.DB)
 
 	// 获取user-service客户端
-	userClient, err := getUserServiceClient()
+	userConn, err := getUserServiceConn()
 	if err != nil {
 		log.Fatalf("获取user-service客户端失败: %v", err)
 	}
+	userClient := pb.NewUserServiceClient(userConn)
+	rbacClient := pb.NewRBACServiceClient(userConn)
+	workspaceClient := pb.NewWorkspaceServiceClient(userConn)
+	apiKeyClient := pb.NewAPIKeyServiceClient(userConn)
 
 	// 获取shortlink-service实例
 	shortlinkClient, err := getShortlinkServiceClient()
@@ -124,25 +242,34 @@ func main() {
 	}
 
 	r := gin.Default()
+	// 只信任前置 nginx 传来的 X-Forwarded-For，避免客户端伪造IP
+	trustedProxies := config.GlobalConfig.App.TrustedProxies
+	if len(trustedProxies) == 0 {
+		trustedProxies = defaultTrustedProxies
+	}
+	if err := r.SetTrustedProxies(trustedProxies); err != nil {
+		log.Fatalf("设置可信代理失败: %v", err)
+	}
 	// 启用跨域支持（允许前端访问）
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST", "OPTIONS"},
-		AllowHeaders:     []string{"Authorization", "Content-Type"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Authorization", "Content-Type", middleware.WorkspaceHeader},
 		AllowCredentials: true,
 	}))
 
 	auth := r.Group("/")
-	auth.Use(middleware.AuthMiddleware()) // JWT 鉴权中间件
+	auth.Use(middleware.AuthMiddleware(apiKeyClient)) // JWT / API Key 鉴权中间件
 	{
 		// 创建短链接
-		auth.POST("/api/v1/links", func(c *gin.Context) {
+		auth.POST("/api/v1/links", middleware.RequireScope(middleware.ScopeLinksWrite), middleware.WorkspaceMiddleware(rbacClient, "link", "create"), func(c *gin.Context) {
 			var req pbShortlink.ShortenRequest
 			if err := c.ShouldBindJSON(&req); err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误", "data": nil})
 				return
 			}
 			req.UserId = strconv.Itoa(int(c.GetUint("UserID")))
+			req.WorkspaceId = c.GetString("WorkspaceID")
 			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 			defer cancel()
 			res, err := shortlinkClient.ShortenURL(ctx, &req)
@@ -150,11 +277,15 @@ func main() {
 				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "创建短链接失败", "data": nil})
 				return
 			}
-			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "创建成功", "data": gin.H{"shortlink": res.ShortUrl}})
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "创建成功", "data": gin.H{
+				"shortlink": res.ShortUrl,
+				"full_url":  res.FullUrl,
+				"domain":    res.Domain,
+			}})
 		})
 
 		// 批量生成短链接 - 添加特殊的批量限流中间件
-		auth.POST("/api/v1/links/batch", middleware.BatchRateLimitMiddleware(), func(c *gin.Context) {
+		auth.POST("/api/v1/links/batch", middleware.RequireScope(middleware.ScopeLinksWrite), middleware.BatchRateLimitMiddleware(), middleware.WorkspaceMiddleware(rbacClient, "link", "create"), func(c *gin.Context) {
 			var req pbShortlink.BatchShortenRequest
 			if err := c.ShouldBindJSON(&req); err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误", "data": nil})
@@ -162,28 +293,94 @@ func main() {
 			}
 
 			// 检查URL列表是否为空
-			if len(req.OriginalUrls) == 0 {
+			if len(req.OriginalUrls) == 0 && len(req.Items) == 0 {
 				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "URL列表不能为空", "data": nil})
 				return
 			}
 
+			// ?qr=true 时为每个结果附带二维码（data URI），参数与二维码接口一致
+			withQR, _ := strconv.ParseBool(c.Query("qr"))
+			var qrOpts qrcode.Options
+			if withQR {
+				opts, err := qrcode.ParseOptions(c.Request.URL.Query())
+				if err != nil {
+					c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": err.Error(), "data": nil})
+					return
+				}
+				qrOpts = opts
+			}
+			// 显式列出字段，避免 protobuf 的 omitempty 丢掉 index 为0的项
+			batchItem := func(r *pbShortlink.BatchShortenResult) gin.H {
+				item := gin.H{"index": r.Index, "original_url": r.OriginalUrl, "short_url": r.ShortUrl,
+					"full_url": r.FullUrl, "error": r.Error, "duplicate": r.Duplicate}
+				if withQR && r.ShortUrl != "" {
+					content := r.FullUrl
+					if content == "" {
+						content = shortLinkURL(c, "", r.ShortUrl)
+					}
+					if uri, err := qrcode.DataURI(content, qrOpts); err == nil {
+						item["qr_code"] = uri
+					}
+				}
+				return item
+			}
+
+			req.UserId = strconv.Itoa(int(c.GetUint("UserID")))
+			req.WorkspaceId = c.GetString("WorkspaceID")
+			req.Concurrency = 10
+
+			// ?stream=true 或 Accept: application/x-ndjson 时每完成一项输出一行 NDJSON（按完成顺序，用 index 对应请求）
+			// 大批量时不受 50 秒超时限制，客户端断开时随请求上下文一起取消
+			streaming, _ := strconv.ParseBool(c.Query("stream"))
+			if streaming || strings.Contains(c.GetHeader("Accept"), "application/x-ndjson") {
+				ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Minute)
+				defer cancel()
+				stream, err := shortlinkClient.BatchShortenURLsStream(ctx, &req)
+				if err != nil {
+					c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "批量生成短链接失败", "data": nil})
+					return
+				}
+				c.Header("Content-Type", "application/x-ndjson")
+				c.Status(http.StatusOK)
+				enc := json.NewEncoder(c.Writer)
+				for {
+					r, err := stream.Recv()
+					if err == io.EOF {
+						return
+					}
+					if err != nil {
+						// 响应头已经发出，只能中断输出，未返回的项需要客户端按 index 重试
+						log.Printf("流式批量生成短链接中断: %v", err)
+						return
+					}
+					if err := enc.Encode(batchItem(r)); err != nil {
+						return
+					}
+					c.Writer.Flush()
+				}
+			}
+
 			// 设置超时时间，批量处理可能需要更长时间
 			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Second)
 			defer cancel()
 
-			req.UserId = strconv.Itoa(int(c.GetUint("UserID")))
-			req.Concurrency = 10
 			res, err := shortlinkClient.BatchShortenURLs(ctx, &req)
 			if err != nil {
 				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "批量生成短链接失败", "data": nil})
 				return
 			}
 
+			// 结果与请求顺序一致
+			results := make([]gin.H, 0, len(res.Results))
+			for _, r := range res.Results {
+				results = append(results, batchItem(r))
+			}
+
 			c.JSON(http.StatusOK, gin.H{
 				"code":    200,
 				"message": "批量创建成功",
 				"data": gin.H{
-					"results":       res.Results,
+					"results":       results,
 					"total_count":   res.TotalCount,
 					"success_count": res.SuccessCount,
 					"elapsed_time":  res.ElapsedTime,
@@ -191,24 +388,308 @@ func main() {
 			})
 		})
 
-		auth.GET("/api/v1/links/top", func(c *gin.Context) {
-			req := &pbShortlink.TopRequest{Count: 10}
+		// 提交异步批量任务，适合数万条的批量，请求体与批量创建一致
+		auth.POST("/api/v1/links/batch/jobs", middleware.RequireScope(middleware.ScopeLinksWrite), middleware.BatchRateLimitMiddleware(), middleware.WorkspaceMiddleware(rbacClient, "link", "create"), func(c *gin.Context) {
+			var req pbShortlink.BatchShortenRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误", "data": nil})
+				return
+			}
+			req.UserId = strconv.Itoa(int(c.GetUint("UserID")))
+			req.WorkspaceId = c.GetString("WorkspaceID")
+			// 所有项写入数据库后才返回，数量多时需要更长时间
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.SubmitBatchJob(ctx, &req)
+			if err != nil {
+				if status.Code(err) == codes.InvalidArgument {
+					c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": status.Convert(err).Message(), "data": nil})
+					return
+				}
+				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "提交批量任务失败", "data": nil})
+				return
+			}
+			c.JSON(http.StatusAccepted, gin.H{"code": 202, "message": "任务已提交", "data": res.Job})
+		})
+
+		// 查询异步批量任务的进度和已处理的结果
+		auth.GET("/api/v1/links/batch/jobs/:job_id", middleware.RequireScope(middleware.ScopeLinksRead), func(c *gin.Context) {
+			offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+			limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+			req := &pbShortlink.GetBatchJobRequest{
+				UserId: strconv.Itoa(int(c.GetUint("UserID"))),
+				JobId:  c.Param("job_id"),
+				Status: c.Query("status"),
+				Offset: int32(offset),
+				Limit:  int32(limit),
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.GetBatchJob(ctx, req)
+			if err != nil {
+				switch status.Code(err) {
+				case codes.NotFound:
+					c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "任务不存在", "data": nil})
+				case codes.InvalidArgument:
+					c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": status.Convert(err).Message(), "data": nil})
+				default:
+					c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "查询批量任务失败", "data": nil})
+				}
+				return
+			}
+			results := make([]gin.H, 0, len(res.Results))
+			for _, r := range res.Results {
+				results = append(results, gin.H{"index": r.Index, "original_url": r.OriginalUrl, "short_url": r.ShortUrl,
+					"full_url": r.FullUrl, "error": r.Error, "duplicate": r.Duplicate})
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "获取成功", "data": gin.H{
+				"job":          res.Job,
+				"results":      results,
+				"result_total": res.ResultTotal,
+			}})
+		})
+
+		// 取消异步批量任务，已处理的结果保留
+		auth.POST("/api/v1/links/batch/jobs/:job_id/cancel", middleware.RequireScope(middleware.ScopeLinksWrite), func(c *gin.Context) {
+			req := &pbShortlink.CancelBatchJobRequest{
+				UserId: strconv.Itoa(int(c.GetUint("UserID"))),
+				JobId:  c.Param("job_id"),
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.CancelBatchJob(ctx, req)
+			if err != nil {
+				switch status.Code(err) {
+				case codes.NotFound:
+					c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "任务不存在", "data": nil})
+				case codes.FailedPrecondition:
+					c.JSON(http.StatusConflict, gin.H{"code": 409, "message": status.Convert(err).Message(), "data": nil})
+				default:
+					c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "取消批量任务失败", "data": nil})
+				}
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "任务已取消", "data": res.Job})
+		})
+
+		// 获取个人或工作区的热门短链接，支持按时间窗口和热度排行
+		auth.GET("/api/v1/links/top", middleware.RequireScope(middleware.ScopeAnalyticsRead), middleware.WorkspaceMiddleware(rbacClient, "analytics", "read"), func(c *gin.Context) {
+			topLinks(c, shortlinkClient, &pbShortlink.TopRequest{
+				UserId:      strconv.Itoa(int(c.GetUint("UserID"))),
+				WorkspaceId: c.GetString("WorkspaceID"),
+			})
+		})
+
+		// 查询机器人 User-Agent 特征，只有管理员可以访问
+		auth.GET("/api/v1/admin/bot-signatures", middleware.RequireJWT(), middleware.RequireAdmin(rbacClient), func(c *gin.Context) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.ListBotSignatures(ctx, &pbShortlink.ListBotSignaturesRequest{})
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取机器人特征失败", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "获取成功", "data": gin.H{"signatures": res.Signatures}})
+		})
+
+		// 增加、删除或停用机器人 User-Agent 特征，只有管理员可以访问
+		auth.PATCH("/api/v1/admin/bot-signatures", middleware.RequireJWT(), middleware.RequireAdmin(rbacClient), func(c *gin.Context) {
+			var req pbShortlink.UpdateBotSignaturesRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误", "data": nil})
+				return
+			}
+			req.UserId = strconv.Itoa(int(c.GetUint("UserID")))
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.UpdateBotSignatures(ctx, &req)
+			if err != nil {
+				switch status.Code(err) {
+				case codes.InvalidArgument:
+					c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": status.Convert(err).Message(), "data": nil})
+				case codes.FailedPrecondition:
+					c.JSON(http.StatusConflict, gin.H{"code": 409, "message": status.Convert(err).Message(), "data": nil})
+				default:
+					c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "更新机器人特征失败", "data": nil})
+				}
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "更新成功", "data": gin.H{"signatures": res.Signatures}})
+		})
+
+		// 获取全站的热门短链接，只有管理员可以访问
+		auth.GET("/api/v1/admin/links/top", middleware.RequireJWT(), middleware.RequireAdmin(rbacClient), func(c *gin.Context) {
+			topLinks(c, shortlinkClient, &pbShortlink.TopRequest{Global: true})
+		})
+
+		// 修改短链接的目标地址
+		auth.PUT("/api/v1/links/:short_url", middleware.RequireScope(middleware.ScopeLinksWrite), middleware.WorkspaceMiddleware(rbacClient, "link", "update"), func(c *gin.Context) {
+			var req pbShortlink.UpdateLinkRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误", "data": nil})
+				return
+			}
+			req.ShortUrl = c.Param("short_url")
+			req.Domain = linkDomain(c, req.Domain)
+			req.UserId = strconv.Itoa(int(c.GetUint("UserID")))
+			req.WorkspaceId = c.GetString("WorkspaceID")
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.UpdateLink(ctx, &req)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "修改短链接失败", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "修改成功", "data": gin.H{
+				"short_url":    res.ShortUrl,
+				"full_url":     res.FullUrl,
+				"original_url": res.OriginalUrl,
+			}})
+		})
+
+		// 更新短链接的跳转规则
+		auth.PUT("/api/v1/links/:short_url/rules", middleware.RequireScope(middleware.ScopeLinksWrite), middleware.WorkspaceMiddleware(rbacClient, "link", "update"), func(c *gin.Context) {
+			var req pbShortlink.UpdateLinkRulesRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误", "data": nil})
+				return
+			}
+			req.ShortUrl = c.Param("short_url")
+			req.Domain = linkDomain(c, req.Domain)
+			req.UserId = strconv.Itoa(int(c.GetUint("UserID")))
+			req.WorkspaceId = c.GetString("WorkspaceID")
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.UpdateLinkRules(ctx, &req)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "更新跳转规则失败", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "更新成功", "data": gin.H{"rule_count": res.RuleCount}})
+		})
+
+		// 更新短链接的 A/B 分流权重
+		auth.PUT("/api/v1/links/:short_url/variants", middleware.RequireScope(middleware.ScopeLinksWrite), middleware.WorkspaceMiddleware(rbacClient, "link", "update"), func(c *gin.Context) {
+			var req pbShortlink.UpdateLinkVariantsRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误", "data": nil})
+				return
+			}
+			req.ShortUrl = c.Param("short_url")
+			req.Domain = linkDomain(c, req.Domain)
+			req.UserId = strconv.Itoa(int(c.GetUint("UserID")))
+			req.WorkspaceId = c.GetString("WorkspaceID")
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.UpdateLinkVariants(ctx, &req)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "更新分流配置失败", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "更新成功", "data": gin.H{"variant_count": res.VariantCount}})
+		})
 
-			// 超时2秒就返回
+		// 查询各 A/B 分组的点击量
+		auth.GET("/api/v1/links/:short_url/variants", middleware.RequireScope(middleware.ScopeAnalyticsRead), middleware.WorkspaceMiddleware(rbacClient, "analytics", "read"), func(c *gin.Context) {
+			req := &pbShortlink.GetVariantStatsRequest{
+				ShortUrl:    c.Param("short_url"),
+				UserId:      strconv.Itoa(int(c.GetUint("UserID"))),
+				Domain:      c.Query("domain"),
+				WorkspaceId: c.GetString("WorkspaceID"),
+			}
 			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 			defer cancel()
+			res, err := shortlinkClient.GetVariantStats(ctx, req)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取分组点击量失败", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "获取成功", "data": gin.H{
+				"variants":     res.Variants,
+				"total_clicks": res.TotalClicks,
+			}})
+		})
 
-			resp, err := shortlinkClient.GetTopLinks(ctx, req)
+		// 按分钟、小时或天查询短链接的点击趋势
+		auth.GET("/api/v1/links/:short_url/stats", middleware.RequireScope(middleware.ScopeAnalyticsRead), middleware.WorkspaceMiddleware(rbacClient, "analytics", "read"), func(c *gin.Context) {
+			from, _ := strconv.ParseInt(c.Query("from"), 10, 64)
+			to, _ := strconv.ParseInt(c.Query("to"), 10, 64)
+			req := &pbShortlink.GetLinkStatsRequest{
+				ShortUrl:    c.Param("short_url"),
+				UserId:      strconv.Itoa(int(c.GetUint("UserID"))),
+				Domain:      c.Query("domain"),
+				WorkspaceId: c.GetString("WorkspaceID"),
+				From:        from,
+				To:          to,
+				Granularity: c.Query("granularity"),
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.GetLinkStats(ctx, req)
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取排行榜失败", "data": nil})
+				switch status.Code(err) {
+				case codes.InvalidArgument:
+					c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": status.Convert(err).Message(), "data": nil})
+				case codes.NotFound:
+					c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "短链接不存在", "data": nil})
+				default:
+					c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取点击趋势失败", "data": nil})
+				}
 				return
 			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "获取成功", "data": gin.H{
+				"granularity":     res.Granularity,
+				"from":            res.From,
+				"to":              res.To,
+				"buckets":         res.Buckets,
+				"total_clicks":    res.TotalClicks,
+				"unique_visitors": res.UniqueVisitors,
+				"bot_clicks":      res.BotClicks,
+			}})
+		})
 
-			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "获取成功", "data": gin.H{"top": resp.Top}})
+		// 按来源域名、浏览器、操作系统、设备和国家查询短链接的点击分布
+		auth.GET("/api/v1/links/:short_url/breakdown", middleware.RequireScope(middleware.ScopeAnalyticsRead), middleware.WorkspaceMiddleware(rbacClient, "analytics", "read"), func(c *gin.Context) {
+			from, _ := strconv.ParseInt(c.Query("from"), 10, 64)
+			to, _ := strconv.ParseInt(c.Query("to"), 10, 64)
+			limit, _ := strconv.Atoi(c.Query("limit"))
+			var dimensions []string
+			if d := c.Query("dimension"); d != "" {
+				dimensions = strings.Split(d, ",")
+			}
+			req := &pbShortlink.GetLinkBreakdownRequest{
+				ShortUrl:    c.Param("short_url"),
+				UserId:      strconv.Itoa(int(c.GetUint("UserID"))),
+				Domain:      c.Query("domain"),
+				WorkspaceId: c.GetString("WorkspaceID"),
+				From:        from,
+				To:          to,
+				Dimensions:  dimensions,
+				Limit:       int32(limit),
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.GetLinkBreakdown(ctx, req)
+			if err != nil {
+				switch status.Code(err) {
+				case codes.InvalidArgument:
+					c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": status.Convert(err).Message(), "data": nil})
+				case codes.NotFound:
+					c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "短链接不存在", "data": nil})
+				default:
+					c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取点击分布失败", "data": nil})
+				}
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "获取成功", "data": gin.H{
+				"from":       res.From,
+				"to":         res.To,
+				"breakdowns": res.Breakdowns,
+			}})
 		})
 
 		// 删除用户的所有短链接
-		auth.DELETE("/api/v1/links", func(c *gin.Context) {
+		auth.DELETE("/api/v1/links", middleware.RequireJWT(), func(c *gin.Context) {
 			userID := strconv.Itoa(int(c.GetUint("UserID")))
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 			defer cancel()
@@ -229,25 +710,847 @@ func main() {
 
 			c.JSON(http.StatusOK, gin.H{
 				"code":    200,
-				"message": "删除成功",
+				"message": "已移入回收站",
 				"data": gin.H{
 					"deleted_count": res.DeletedCount,
 				},
 			})
 		})
+
+		// 查询回收站中的短链接
+		auth.GET("/api/v1/links/trash", middleware.RequireScope(middleware.ScopeLinksRead), func(c *gin.Context) {
+			page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+			pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+			req := &pbShortlink.ListTrashRequest{
+				UserId:   strconv.Itoa(int(c.GetUint("UserID"))),
+				Page:     int32(page),
+				PageSize: int32(pageSize),
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.ListTrash(ctx, req)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "查询回收站失败", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "获取成功", "data": gin.H{
+				"links": res.Links,
+				"total": res.Total,
+			}})
+		})
+
+		// 将短链接移出回收站，links 为空时恢复整个回收站
+		auth.POST("/api/v1/links/trash/restore", middleware.RequireScope(middleware.ScopeLinksWrite), func(c *gin.Context) {
+			var req pbShortlink.RestoreLinksRequest
+			if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误", "data": nil})
+				return
+			}
+			req.UserId = strconv.Itoa(int(c.GetUint("UserID")))
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.RestoreLinks(ctx, &req)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "恢复短链接失败", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "恢复成功", "data": gin.H{
+				"restored": res.Restored,
+				"skipped":  res.Skipped,
+			}})
+		})
+
+		// 登记自定义短链接域名，返回验证说明
+		auth.POST("/api/v1/domains", middleware.RequireJWT(), func(c *gin.Context) {
+			var req pbShortlink.AddDomainRequest
+			if err := c.ShouldBindJSON(&req); err != nil || req.Host == "" {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误", "data": nil})
+				return
+			}
+			req.UserId = strconv.Itoa(int(c.GetUint("UserID")))
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.AddDomain(ctx, &req)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "登记域名失败", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "登记成功", "data": res.Domain})
+		})
+
+		// 查询用户的域名
+		auth.GET("/api/v1/domains", middleware.RequireScope(middleware.ScopeLinksRead), func(c *gin.Context) {
+			req := &pbShortlink.ListDomainsRequest{UserId: strconv.Itoa(int(c.GetUint("UserID")))}
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.ListDomains(ctx, req)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取域名失败", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "获取成功", "data": gin.H{
+				"domains":       res.Domains,
+				"system_domain": res.SystemDomain,
+			}})
+		})
+
+		// 验证域名所有权，DNS 验证需要等待记录生效，可重复调用
+		auth.POST("/api/v1/domains/:host/verify", middleware.RequireJWT(), func(c *gin.Context) {
+			req := &pbShortlink.VerifyDomainRequest{
+				UserId: strconv.Itoa(int(c.GetUint("UserID"))),
+				Host:   c.Param("host"),
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.VerifyDomain(ctx, req)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "域名验证未通过", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "验证成功", "data": res.Domain})
+		})
+
+		// 设置创建短链接时的默认域名，host 为空表示恢复系统默认域名
+		auth.PUT("/api/v1/domains/default", middleware.RequireJWT(), func(c *gin.Context) {
+			var req pbShortlink.SetDefaultDomainRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误", "data": nil})
+				return
+			}
+			req.UserId = strconv.Itoa(int(c.GetUint("UserID")))
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			if _, err := shortlinkClient.SetDefaultDomain(ctx, &req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "设置默认域名失败", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "设置成功", "data": nil})
+		})
+
+		// 删除域名，域名下还有短链接时不允许删除
+		auth.DELETE("/api/v1/domains/:host", middleware.RequireJWT(), func(c *gin.Context) {
+			req := &pbShortlink.DeleteDomainRequest{
+				UserId: strconv.Itoa(int(c.GetUint("UserID"))),
+				Host:   c.Param("host"),
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			if _, err := shortlinkClient.DeleteDomain(ctx, req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "删除域名失败", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "删除成功", "data": nil})
+		})
+
+		// CSV 批量导入，每行一个短链接，支持常见短链接服务的导出格式
+		auth.POST("/api/v1/links/import", middleware.RequireScope(middleware.ScopeLinksWrite), middleware.BatchRateLimitMiddleware(), middleware.WorkspaceMiddleware(rbacClient, "link", "create"), func(c *gin.Context) {
+			userID := strconv.Itoa(int(c.GetUint("UserID")))
+			file, err := c.FormFile("file")
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "请上传 CSV 文件", "data": nil})
+				return
+			}
+			if file.Size > maxImportSize {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "文件不能超过5MB", "data": nil})
+				return
+			}
+			f, err := file.Open()
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "读取文件失败", "data": nil})
+				return
+			}
+			defer f.Close()
+
+			// 1. 解析文件，格式错误的行直接进入错误报告
+			rows, rejected, err := csvimport.Parse(f, csvimport.Options{MaxRows: maxImportRows})
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": err.Error(), "data": nil})
+				return
+			}
+
+			// 2. 合法的行走批量生成流程
+			results := make([]gin.H, 0, len(rows)+len(rejected))
+			if len(rows) > 0 {
+				req := &pbShortlink.BatchShortenRequest{
+					UserId:      userID,
+					WorkspaceId: c.GetString("WorkspaceID"),
+					Concurrency: 10,
+					Domain:      c.PostForm("domain"),
+					Items:       make([]*pbShortlink.BatchItem, 0, len(rows)),
+				}
+				for _, row := range rows {
+					req.Items = append(req.Items, &pbShortlink.BatchItem{
+						OriginalUrl:  row.Destination,
+						Alias:        row.Alias,
+						Tags:         row.Tags,
+						ExpireAt:     row.ExpireAt,
+						RedirectType: row.RedirectType,
+					})
+				}
+				ctx, cancel := context.WithTimeout(context.Background(), 50*time.Second)
+				defer cancel()
+				res, err := shortlinkClient.BatchShortenURLs(ctx, req)
+				if err != nil {
+					c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "导入失败", "data": nil})
+					return
+				}
+				for _, r := range res.Results {
+					if int(r.Index) >= len(rows) {
+						continue
+					}
+					row := rows[r.Index]
+					if r.Error != "" {
+						rejected = append(rejected, csvimport.RowError{Line: row.Line, Destination: row.Destination, Reason: r.Error})
+						continue
+					}
+					results = append(results, gin.H{
+						"line":        row.Line,
+						"destination": row.Destination,
+						"status":      "success",
+						"short_url":   r.ShortUrl,
+						"full_url":    r.FullUrl,
+					})
+				}
+			}
+			for _, e := range rejected {
+				results = append(results, gin.H{
+					"line":        e.Line,
+					"destination": e.Destination,
+					"status":      "failed",
+					"error":       e.Reason,
+				})
+			}
+			sort.Slice(results, func(i, j int) bool {
+				return results[i]["line"].(int) < results[j]["line"].(int)
+			})
+
+			// 3. 被拒绝的行生成可下载的错误报告
+			data := gin.H{
+				"total_count":   len(results),
+				"success_count": len(results) - len(rejected),
+				"failed_count":  len(rejected),
+				"results":       results,
+			}
+			if len(rejected) > 0 {
+				sort.Slice(rejected, func(i, j int) bool { return rejected[i].Line < rejected[j].Line })
+				var buf bytes.Buffer
+				reportID := uuid.NewString()
+				if err := csvimport.WriteReport(&buf, rejected); err == nil &&
+					cache.SetImportReport(cache.ImportReportKey(userID, reportID), buf.Bytes()) == nil {
+					data["report_id"] = reportID
+					data["report_url"] = "/api/v1/links/import/" + reportID + "/errors"
+				}
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "导入完成", "data": data})
+		})
+
+		// 流式导出用户的所有短链接及点击量，format 为 csv（默认）/ json / ndjson，指定工作区时导出工作区的短链接
+		auth.GET("/api/v1/links/export", middleware.RequireScope(middleware.ScopeLinksRead), middleware.WorkspaceMiddleware(rbacClient, "link", "read"), func(c *gin.Context) {
+			format := c.DefaultQuery("format", export.FormatCSV)
+			if format != export.FormatCSV && format != export.FormatJSON && format != export.FormatNDJSON {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "不支持的导出格式", "data": nil})
+				return
+			}
+			// 导出可能持续较长时间，客户端断开时随请求上下文一起取消
+			ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Minute)
+			defer cancel()
+			stream, err := shortlinkClient.ExportLinks(ctx, &pbShortlink.ExportLinksRequest{
+				UserId:      strconv.Itoa(int(c.GetUint("UserID"))),
+				WorkspaceId: c.GetString("WorkspaceID"),
+			})
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "导出失败", "data": nil})
+				return
+			}
+
+			// 服务端的错误在第一次 Recv 时才返回，先读取第一条再发送响应头，查询失败时可以返回 500
+			link, err := stream.Recv()
+			if err != nil && err != io.EOF {
+				log.Printf("导出短链接失败: %v", err)
+				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "导出失败", "data": nil})
+				return
+			}
+			w, werr := export.NewWriter(format, c.Writer)
+			if werr != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "导出失败", "data": nil})
+				return
+			}
+
+			c.Header("Content-Type", export.ContentType(format))
+			c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="links-%s.%s"`, time.Now().Format("20060102"), format))
+			c.Status(http.StatusOK)
+			for err != io.EOF {
+				if err := w.Write(link); err != nil {
+					return
+				}
+				c.Writer.Flush()
+				if link, err = stream.Recv(); err != nil && err != io.EOF {
+					// 响应头已经发出，只能中断输出，客户端会收到不完整的文件
+					log.Printf("导出短链接中断: %v", err)
+					return
+				}
+			}
+			_ = w.Close()
+		})
+
+		// 下载 CSV 导入的错误报告
+		auth.GET("/api/v1/links/import/:report_id/errors", middleware.RequireScope(middleware.ScopeLinksRead), func(c *gin.Context) {
+			userID := strconv.Itoa(int(c.GetUint("UserID")))
+			data := cache.GetBytes(cache.ImportReportKey(userID, c.Param("report_id")))
+			if data == nil {
+				c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "报告不存在或已过期", "data": nil})
+				return
+			}
+			c.Header("Content-Disposition", `attachment; filename="import-errors.csv"`)
+			c.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+		})
+
+		// 分页查询个人短链接，指定工作区时查询工作区的所有短链接
+		auth.GET("/api/v1/links", middleware.RequireScope(middleware.ScopeLinksRead), middleware.WorkspaceMiddleware(rbacClient, "link", "read"), func(c *gin.Context) {
+			page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+			pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+			req := &pbShortlink.ListLinksRequest{
+				UserId:      strconv.Itoa(int(c.GetUint("UserID"))),
+				WorkspaceId: c.GetString("WorkspaceID"),
+				Page:        int32(page),
+				PageSize:    int32(pageSize),
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.ListLinks(ctx, req)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取短链接列表失败", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "获取成功", "data": gin.H{
+				"links": res.Links,
+				"total": res.Total,
+			}})
+		})
+
+		// 创建工作区，创建者成为所有者
+		auth.POST("/api/v1/workspaces", middleware.RequireJWT(), func(c *gin.Context) {
+			var req pb.CreateWorkspaceRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误", "data": nil})
+				return
+			}
+			req.UserId = uint32(c.GetUint("UserID"))
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := workspaceClient.CreateWorkspace(ctx, &req)
+			if err != nil {
+				workspaceError(c, err, "创建工作区失败")
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "创建成功", "data": res.Workspace})
+		})
+
+		// 获取当前用户加入的工作区及角色
+		auth.GET("/api/v1/workspaces", middleware.RequireScope(middleware.ScopeLinksRead), func(c *gin.Context) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := workspaceClient.ListWorkspaces(ctx, &pb.ListWorkspacesRequest{UserId: uint32(c.GetUint("UserID"))})
+			if err != nil {
+				workspaceError(c, err, "获取工作区列表失败")
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "获取成功", "data": gin.H{"workspaces": res.Workspaces}})
+		})
+
+		// 获取工作区成员
+		auth.GET("/api/v1/workspaces/:id/members", middleware.RequireJWT(), func(c *gin.Context) {
+			workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "工作区ID非法", "data": nil})
+				return
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := workspaceClient.ListWorkspaceMembers(ctx, &pb.ListWorkspaceMembersRequest{
+				UserId:      uint32(c.GetUint("UserID")),
+				WorkspaceId: uint32(workspaceID),
+			})
+			if err != nil {
+				workspaceError(c, err, "获取工作区成员失败")
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "获取成功", "data": gin.H{"members": res.Members}})
+		})
+
+		// 按用户名添加工作区成员，只有所有者可以操作
+		auth.POST("/api/v1/workspaces/:id/members", middleware.RequireJWT(), func(c *gin.Context) {
+			workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "工作区ID非法", "data": nil})
+				return
+			}
+			var req pb.AddWorkspaceMemberRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误", "data": nil})
+				return
+			}
+			req.UserId = uint32(c.GetUint("UserID"))
+			req.WorkspaceId = uint32(workspaceID)
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := workspaceClient.AddWorkspaceMember(ctx, &req)
+			if err != nil {
+				workspaceError(c, err, "添加工作区成员失败")
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "添加成功", "data": res.Member})
+		})
+
+		// 修改工作区成员的角色，只有所有者可以操作
+		auth.PUT("/api/v1/workspaces/:id/members/:user_id", middleware.RequireJWT(), func(c *gin.Context) {
+			workspaceID, err1 := strconv.ParseUint(c.Param("id"), 10, 32)
+			memberID, err2 := strconv.ParseUint(c.Param("user_id"), 10, 32)
+			if err1 != nil || err2 != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误", "data": nil})
+				return
+			}
+			var req pb.UpdateWorkspaceMemberRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误", "data": nil})
+				return
+			}
+			req.UserId = uint32(c.GetUint("UserID"))
+			req.WorkspaceId = uint32(workspaceID)
+			req.MemberId = uint32(memberID)
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := workspaceClient.UpdateWorkspaceMember(ctx, &req)
+			if err != nil {
+				workspaceError(c, err, "修改成员角色失败")
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": res.Message, "data": nil})
+		})
+
+		// 移除工作区成员，成员也可以移除自己（退出工作区）
+		auth.DELETE("/api/v1/workspaces/:id/members/:user_id", middleware.RequireJWT(), func(c *gin.Context) {
+			workspaceID, err1 := strconv.ParseUint(c.Param("id"), 10, 32)
+			memberID, err2 := strconv.ParseUint(c.Param("user_id"), 10, 32)
+			if err1 != nil || err2 != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误", "data": nil})
+				return
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := workspaceClient.RemoveWorkspaceMember(ctx, &pb.RemoveWorkspaceMemberRequest{
+				UserId:      uint32(c.GetUint("UserID")),
+				WorkspaceId: uint32(workspaceID),
+				MemberId:    uint32(memberID),
+			})
+			if err != nil {
+				workspaceError(c, err, "移除工作区成员失败")
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": res.Message, "data": nil})
+		})
+
+		// 创建 API Key，完整的 Key 只在创建时返回一次
+		auth.POST("/api/v1/apikeys", middleware.RequireJWT(), func(c *gin.Context) {
+			var req pb.CreateAPIKeyRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误", "data": nil})
+				return
+			}
+			req.UserId = uint32(c.GetUint("UserID"))
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := apiKeyClient.CreateAPIKey(ctx, &req)
+			if err != nil {
+				switch status.Code(err) {
+				case codes.InvalidArgument:
+					c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": status.Convert(err).Message(), "data": nil})
+				case codes.FailedPrecondition:
+					c.JSON(http.StatusConflict, gin.H{"code": 409, "message": status.Convert(err).Message(), "data": nil})
+				default:
+					c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "创建 API Key 失败", "data": nil})
+				}
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "创建成功，请妥善保存 Key，之后无法再次查看", "data": gin.H{
+				"key":  res.Key,
+				"info": res.Info,
+			}})
+		})
+
+		// 查询用户的 API Key，不包含 Key 本身
+		auth.GET("/api/v1/apikeys", middleware.RequireJWT(), func(c *gin.Context) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := apiKeyClient.ListAPIKeys(ctx, &pb.ListAPIKeysRequest{UserId: uint32(c.GetUint("UserID"))})
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取 API Key 列表失败", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "获取成功", "data": gin.H{"keys": res.Keys}})
+		})
+
+		// 吊销 API Key，立即失效
+		auth.DELETE("/api/v1/apikeys/:id", middleware.RequireJWT(), func(c *gin.Context) {
+			keyID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误", "data": nil})
+				return
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := apiKeyClient.RevokeAPIKey(ctx, &pb.RevokeAPIKeyRequest{
+				UserId: uint32(c.GetUint("UserID")),
+				KeyId:  uint32(keyID),
+			})
+			if err != nil {
+				if status.Code(err) == codes.NotFound {
+					c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "API Key 不存在", "data": nil})
+					return
+				}
+				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "吊销 API Key 失败", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": res.Message, "data": nil})
+		})
+
+		// 登记 Webhook，签名密钥只在创建时返回一次
+		auth.POST("/api/v1/webhooks", middleware.RequireJWT(), func(c *gin.Context) {
+			var req pbShortlink.CreateWebhookRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误", "data": nil})
+				return
+			}
+			req.UserId = strconv.Itoa(int(c.GetUint("UserID")))
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.CreateWebhook(ctx, &req)
+			if err != nil {
+				switch status.Code(err) {
+				case codes.InvalidArgument:
+					c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": status.Convert(err).Message(), "data": nil})
+				case codes.FailedPrecondition:
+					c.JSON(http.StatusConflict, gin.H{"code": 409, "message": status.Convert(err).Message(), "data": nil})
+				default:
+					c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "创建 Webhook 失败", "data": nil})
+				}
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "创建成功，请妥善保存签名密钥，之后无法再次查看", "data": gin.H{
+				"webhook": res.Webhook,
+				"secret":  res.Secret,
+			}})
+		})
+
+		// 查询用户的 Webhook，不包含签名密钥
+		auth.GET("/api/v1/webhooks", middleware.RequireJWT(), func(c *gin.Context) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.ListWebhooks(ctx, &pbShortlink.ListWebhooksRequest{UserId: strconv.Itoa(int(c.GetUint("UserID")))})
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取 Webhook 列表失败", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "获取成功", "data": gin.H{"webhooks": res.Webhooks}})
+		})
+
+		// 删除 Webhook，尚未投递的事件不再投递，投递日志保留
+		auth.DELETE("/api/v1/webhooks/:id", middleware.RequireJWT(), func(c *gin.Context) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.DeleteWebhook(ctx, &pbShortlink.DeleteWebhookRequest{
+				UserId:    strconv.Itoa(int(c.GetUint("UserID"))),
+				WebhookId: c.Param("id"),
+			})
+			if err != nil {
+				if status.Code(err) == codes.NotFound {
+					c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "Webhook 不存在", "data": nil})
+					return
+				}
+				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "删除 Webhook 失败", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": res.Message, "data": nil})
+		})
+
+		// 分页查询 Webhook 的投递日志，可按状态过滤
+		auth.GET("/api/v1/webhooks/:id/deliveries", middleware.RequireJWT(), func(c *gin.Context) {
+			page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+			pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.ListWebhookDeliveries(ctx, &pbShortlink.ListWebhookDeliveriesRequest{
+				UserId:    strconv.Itoa(int(c.GetUint("UserID"))),
+				WebhookId: c.Param("id"),
+				Status:    c.Query("status"),
+				Page:      int32(page),
+				PageSize:  int32(pageSize),
+			})
+			if err != nil {
+				switch status.Code(err) {
+				case codes.NotFound:
+					c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "Webhook 不存在", "data": nil})
+				case codes.InvalidArgument:
+					c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": status.Convert(err).Message(), "data": nil})
+				default:
+					c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取投递日志失败", "data": nil})
+				}
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "获取成功", "data": gin.H{
+				"deliveries": res.Deliveries,
+				"total":      res.Total,
+			}})
+		})
+
+		// 重新投递一次事件，事件ID和请求体不变
+		auth.POST("/api/v1/webhooks/:id/deliveries/:delivery_id/replay", middleware.RequireJWT(), func(c *gin.Context) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.ReplayWebhookDelivery(ctx, &pbShortlink.ReplayWebhookDeliveryRequest{
+				UserId:     strconv.Itoa(int(c.GetUint("UserID"))),
+				WebhookId:  c.Param("id"),
+				DeliveryId: c.Param("delivery_id"),
+			})
+			if err != nil {
+				if status.Code(err) == codes.NotFound {
+					c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": status.Convert(err).Message(), "data": nil})
+					return
+				}
+				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "重放失败", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "已加入投递队列", "data": gin.H{"delivery": res.Delivery}})
+		})
+
+		// 批量为短链接打标签或加入活动（kind=campaign）
+		auth.POST("/api/v1/tags/links", middleware.RequireScope(middleware.ScopeLinksWrite), middleware.WorkspaceMiddleware(rbacClient, "link", "update"), func(c *gin.Context) {
+			var req pbShortlink.TagLinksRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误", "data": nil})
+				return
+			}
+			req.UserId = strconv.Itoa(int(c.GetUint("UserID")))
+			// 工作区只能来自已校验权限的请求头或查询参数，忽略请求体中的值
+			req.WorkspaceId = c.GetString("WorkspaceID")
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.TagLinks(ctx, &req)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "打标签失败", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "操作成功", "data": gin.H{
+				"affected": res.Affected,
+				"skipped":  res.Skipped,
+			}})
+		})
+
+		// 批量移除短链接的标签或活动
+		auth.DELETE("/api/v1/tags/links", middleware.RequireScope(middleware.ScopeLinksWrite), middleware.WorkspaceMiddleware(rbacClient, "link", "update"), func(c *gin.Context) {
+			var req pbShortlink.TagLinksRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "参数错误", "data": nil})
+				return
+			}
+			req.UserId = strconv.Itoa(int(c.GetUint("UserID")))
+			req.WorkspaceId = c.GetString("WorkspaceID")
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.UntagLinks(ctx, &req)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "移除标签失败", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "操作成功", "data": gin.H{
+				"affected": res.Affected,
+				"skipped":  res.Skipped,
+			}})
+		})
+
+		// 查询用户的标签和活动
+		auth.GET("/api/v1/tags", middleware.RequireScope(middleware.ScopeLinksRead), func(c *gin.Context) {
+			req := &pbShortlink.ListTagsRequest{
+				UserId: strconv.Itoa(int(c.GetUint("UserID"))),
+				Kind:   c.Query("kind"),
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.ListTags(ctx, req)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取标签失败", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "获取成功", "data": gin.H{"tags": res.Tags}})
+		})
+
+		// 查询标签或活动下的短链接
+		auth.GET("/api/v1/tags/:tag/links", middleware.RequireScope(middleware.ScopeLinksRead), middleware.WorkspaceMiddleware(rbacClient, "link", "read"), func(c *gin.Context) {
+			page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+			pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+			req := &pbShortlink.ListLinksByTagRequest{
+				UserId:      strconv.Itoa(int(c.GetUint("UserID"))),
+				WorkspaceId: c.GetString("WorkspaceID"),
+				Kind:        c.Query("kind"),
+				Tag:         c.Param("tag"),
+				Page:        int32(page),
+				PageSize:    int32(pageSize),
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.ListLinksByTag(ctx, req)
+			if err != nil {
+				c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "标签不存在", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "获取成功", "data": gin.H{
+				"links": res.Links,
+				"total": res.Total,
+			}})
+		})
+
+		// 查询标签或活动的汇总点击量
+		auth.GET("/api/v1/tags/:tag/clicks", middleware.RequireScope(middleware.ScopeAnalyticsRead), middleware.WorkspaceMiddleware(rbacClient, "analytics", "read"), func(c *gin.Context) {
+			req := &pbShortlink.GetTagClicksRequest{
+				UserId:      strconv.Itoa(int(c.GetUint("UserID"))),
+				WorkspaceId: c.GetString("WorkspaceID"),
+				Kind:        c.Query("kind"),
+				Tag:         c.Param("tag"),
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			res, err := shortlinkClient.GetTagClicks(ctx, req)
+			if err != nil {
+				c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "标签不存在", "data": nil})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "获取成功", "data": gin.H{
+				"total_clicks": res.TotalClicks,
+				"link_count":   res.LinkCount,
+				"links":        res.Links,
+			}})
+		})
 	}
 	// 跳转接口也添加限流
-	r.GET("/api/v1/links/:short_url", middleware.RateLimitMiddleware(), func(c *gin.Context) {
+	redirect := func(c *gin.Context) {
 		var req pbShortlink.ResolveRequest
 		req.ShortUrl = c.Param("short_url")
+		// 按访问的域名区分自定义域名下的短链接
+		req.Host = c.Request.Host
+		req.ClientIp = c.ClientIP()
+		req.VisitorId = visitorID(c)
+		// 转发客户端请求头，供短链接服务匹配跳转规则
+		req.Headers = make(map[string]string, len(forwardHeaders))
+		for _, h := range forwardHeaders {
+			if v := c.GetHeader(h); v != "" {
+				req.Headers[h] = v
+			}
+		}
 		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 		defer cancel()
 		res, err := shortlinkClient.Redierect(ctx, &req)
 		if err != nil {
+			if status.Code(err) == codes.FailedPrecondition {
+				c.JSON(http.StatusGone, gin.H{"code": 410, "message": "短链接已过期", "data": nil})
+				return
+			}
 			c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "短链接无效", "data": nil})
 			return
 		}
-		c.Redirect(http.StatusFound, res.OriginalUrl)
+		// App 深链接无法直接 302，返回唤起页面并在失败时跳转网页
+		if res.FallbackUrl != "" {
+			page, err := deeplink.RenderPage(res.OriginalUrl, res.FallbackUrl)
+			if errors.Is(err, deeplink.ErrUnsafeURL) {
+				log.Printf("深链接地址不安全，拒绝跳转: %s -> %s", req.ShortUrl, res.OriginalUrl)
+				c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "短链接无效", "data": nil})
+				return
+			}
+			if err != nil {
+				c.Redirect(http.StatusFound, res.FallbackUrl)
+				return
+			}
+			c.Data(http.StatusOK, "text/html; charset=utf-8", page)
+			return
+		}
+		code := http.StatusFound
+		switch res.RedirectType {
+		case http.StatusMovedPermanently, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+			code = int(res.RedirectType)
+		}
+		c.Redirect(code, res.OriginalUrl)
+	}
+	r.GET("/api/v1/links/:short_url", middleware.RateLimitMiddleware(), redirect)
+	// 对外短链接 https://<short_domain>/<code>
+	r.GET("/:short_url", middleware.RateLimitMiddleware(), redirect)
+
+	// 短链接二维码，内容为完整的短链接地址
+	r.GET("/api/v1/links/:short_url/qr", middleware.RateLimitMiddleware(), func(c *gin.Context) {
+		code := c.Param("short_url")
+		if !shortCodePattern.MatchString(code) {
+			c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "短链接格式错误", "data": nil})
+			return
+		}
+		opts, err := qrcode.ParseOptions(c.Request.URL.Query())
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": err.Error(), "data": nil})
+			return
+		}
+
+		host := c.Query("domain")
+		if host != "" && !hostPattern.MatchString(host) {
+			c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "域名格式错误", "data": nil})
+			return
+		}
+		// 只为存在的短链接生成二维码，内容使用短链接所在的域名
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		link, err := shortlinkClient.GetLink(ctx, &pbShortlink.GetLinkRequest{ShortUrl: code, Domain: strings.ToLower(host)})
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "短链接不存在", "data": nil})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "查询短链接失败", "data": nil})
+			return
+		}
+		content := link.FullUrl
+		if content == "" {
+			content = shortLinkURL(c, link.Domain, code)
+		}
+		// 摘要包含完整短链接，域名配置变化后自动使用新缓存
+		key := cache.QRKey(code, opts.Hash(content))
+		data := cache.GetBytes(key)
+		if data == nil {
+			if data, err = qrcode.Render(content, opts); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "生成二维码失败", "data": nil})
+				return
+			}
+			cache.SetQR(key, data)
+		}
+		c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(cache.QRTTL.Seconds())))
+		c.Data(http.StatusOK, opts.ContentType(), data)
+	})
+
+	// 链接预览，供前端渲染链接卡片
+	r.GET("/api/v1/links/:short_url/preview", middleware.RateLimitMiddleware(), func(c *gin.Context) {
+		req := &pbShortlink.GetLinkPreviewRequest{ShortUrl: c.Param("short_url"), Domain: c.Query("domain")}
+		// 预览缺失时需要同步抓取目标页面，超时时间放宽
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		res, err := shortlinkClient.GetLinkPreview(ctx, req)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "暂无链接预览", "data": nil})
+			return
+		}
+		c.Header("Cache-Control", "public, max-age=3600")
+		c.JSON(http.StatusOK, gin.H{"code": 200, "message": "获取成功", "data": gin.H{
+			"title":         res.Preview.Title,
+			"description":   res.Preview.Description,
+			"image":         res.Preview.Image,
+			"canonical_url": res.Preview.CanonicalUrl,
+			"fetched_at":    res.Preview.FetchedAt,
+			"summary":       res.Preview.Summary,
+		}})
 	})
 
 	// 用户注册
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVisitorID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	visit := func(cookie string) (string, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/abc", nil)
+		c.Request.RemoteAddr = "203.0.113.7:1234"
+		if cookie != "" {
+			c.Request.AddCookie(&http.Cookie{Name: visitorCookie, Value: cookie})
+		}
+		return visitorID(c), w
+	}
+
+	t.Run("没有Cookie时返回空并下发Cookie", func(t *testing.T) {
+		first, w1 := visit("")
+		second, w2 := visit("")
+		// 两次都为空，短链接服务按相同的IP分流，结果一致
+		assert.Empty(t, first)
+		assert.Empty(t, second)
+		assert.Contains(t, w1.Header().Get("Set-Cookie"), visitorCookie+"=")
+		assert.Contains(t, w2.Header().Get("Set-Cookie"), visitorCookie+"=")
+	})
+
+	t.Run("带Cookie时使用Cookie且不重新下发", func(t *testing.T) {
+		id, w := visit("visitor-1")
+		assert.Equal(t, "visitor-1", id)
+		assert.Empty(t, w.Header().Get("Set-Cookie"))
+	})
+}
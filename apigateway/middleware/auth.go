@@ -1,19 +1,43 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strings"
+	"time"
 
 	"shortLink/apigateway/cache"
 	"shortLink/apigateway/pkg/jwt"
+	pb "shortLink/proto/userpb"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-func AuthMiddleware() gin.HandlerFunc {
+// 鉴权方式，注入上下文的 AuthType
+const (
+	AuthTypeJWT    = "jwt"
+	AuthTypeAPIKey = "apikey"
+)
+
+// API Key 的权限范围
+const (
+	ScopeLinksWrite    = "links:write"
+	ScopeLinksRead     = "links:read"
+	ScopeAnalyticsRead = "analytics:read"
+)
+
+// AuthMiddleware 鉴权中间件，支持 Authorization: Bearer <JWT> 和 Authorization: ApiKey <key>
+// API Key 由 user-service 校验，权限范围注入上下文（Scopes），由 RequireScope 检查
+func AuthMiddleware(apiKeyClient pb.APIKeyServiceClient) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 读取 Authorization Header
 		authHeader := c.GetHeader("Authorization")
+		if strings.HasPrefix(authHeader, "ApiKey ") {
+			authenticateAPIKey(c, apiKeyClient, strings.TrimSpace(strings.TrimPrefix(authHeader, "ApiKey ")))
+			return
+		}
 		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "请先登录"})
 			c.Abort()
@@ -40,7 +64,85 @@ func AuthMiddleware() gin.HandlerFunc {
 		// 注入上下文（便于控制器获取 userID/role）
 		c.Set("UserID", claims.UserID)
 		c.Set("Role", claims.Role)
+		c.Set("AuthType", AuthTypeJWT)
 
 		c.Next() // 放行
 	}
 }
+
+// authenticateAPIKey 通过 user-service 校验 API Key
+func authenticateAPIKey(c *gin.Context, apiKeyClient pb.APIKeyServiceClient, key string) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
+	defer cancel()
+	res, err := apiKeyClient.VerifyAPIKey(ctx, &pb.VerifyAPIKeyRequest{Key: key})
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": status.Convert(err).Message()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "校验 API Key 失败"})
+		}
+		c.Abort()
+		return
+	}
+
+	c.Set("UserID", uint(res.UserId))
+	c.Set("Role", res.Role)
+	c.Set("AuthType", AuthTypeAPIKey)
+	c.Set("Scopes", res.Scopes)
+
+	c.Next()
+}
+
+// RequireScope 使用 API Key 访问时要求拥有指定的权限范围，JWT 登录的用户不受限制
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("AuthType") != AuthTypeAPIKey {
+			c.Next()
+			return
+		}
+		for _, s := range c.GetStringSlice("Scopes") {
+			if s == scope {
+				c.Next()
+				return
+			}
+		}
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "API Key 缺少权限范围: " + scope, "data": nil})
+		c.Abort()
+	}
+}
+
+// RequireJWT 只允许登录用户访问，用于管理 API Key、域名和工作区成员等账号级操作
+func RequireJWT() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("AuthType") == AuthTypeAPIKey {
+			c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "该操作不支持使用 API Key", "data": nil})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireAdmin 只允许管理员访问，与后台管理服务相同，通过 RBAC 服务检查 admin:access 权限
+func RequireAdmin(rbacClient pb.RBACServiceClient) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
+		defer cancel()
+		res, err := rbacClient.CheckPermission(ctx, &pb.CheckPermissionRequest{
+			UserId:   uint32(c.GetUint("UserID")),
+			Resource: "admin",
+			Action:   "access",
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "检查权限失败", "data": nil})
+			c.Abort()
+			return
+		}
+		if !res.HasPermission {
+			c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "需要管理员权限", "data": nil})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
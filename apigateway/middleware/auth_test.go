@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	pb "shortLink/proto/userpb"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeAPIKeyClient 只实现 VerifyAPIKey，其他方法不会被鉴权中间件调用
+type fakeAPIKeyClient struct {
+	pb.APIKeyServiceClient
+	keys map[string]*pb.VerifyAPIKeyResponse
+}
+
+func (f *fakeAPIKeyClient) VerifyAPIKey(ctx context.Context, in *pb.VerifyAPIKeyRequest, opts ...grpc.CallOption) (*pb.VerifyAPIKeyResponse, error) {
+	if res, ok := f.keys[in.Key]; ok {
+		return res, nil
+	}
+	return nil, status.Error(codes.Unauthenticated, "无效的 API Key")
+}
+
+func newTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	client := &fakeAPIKeyClient{keys: map[string]*pb.VerifyAPIKeyResponse{
+		"reader": {UserId: 7, Role: "user", Scopes: []string{ScopeLinksRead}},
+	}}
+	r := gin.New()
+	auth := r.Group("/", AuthMiddleware(client))
+	handler := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"user_id": c.GetUint("UserID")})
+	}
+	auth.GET("/links", RequireScope(ScopeLinksRead), handler)
+	auth.POST("/links", RequireScope(ScopeLinksWrite), handler)
+	auth.POST("/apikeys", RequireJWT(), handler)
+	return r
+}
+
+func request(r *gin.Engine, method, path, authorization string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	r := newTestRouter()
+
+	t.Run("权限范围内的请求放行", func(t *testing.T) {
+		w := request(r, http.MethodGet, "/links", "ApiKey reader")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"user_id": 7}`, w.Body.String())
+	})
+
+	t.Run("缺少权限范围", func(t *testing.T) {
+		w := request(r, http.MethodPost, "/links", "ApiKey reader")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("API Key 不能管理 API Key", func(t *testing.T) {
+		w := request(r, http.MethodPost, "/apikeys", "ApiKey reader")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("无效的 API Key", func(t *testing.T) {
+		w := request(r, http.MethodGet, "/links", "ApiKey unknown")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("没有 Authorization", func(t *testing.T) {
+		w := request(r, http.MethodGet, "/links", "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+// fakeRBACClient 只实现 CheckPermission，admins 中的用户拥有 admin:access 权限
+type fakeRBACClient struct {
+	pb.RBACServiceClient
+	admins map[uint32]bool
+}
+
+func (f *fakeRBACClient) CheckPermission(ctx context.Context, in *pb.CheckPermissionRequest, opts ...grpc.CallOption) (*pb.CheckPermissionResponse, error) {
+	return &pb.CheckPermissionResponse{HasPermission: in.Resource == "admin" && in.Action == "access" && f.admins[in.UserId]}, nil
+}
+
+func TestRequireAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/admin", func(c *gin.Context) {
+		id, _ := strconv.ParseUint(c.Query("uid"), 10, 32)
+		c.Set("UserID", uint(id))
+	}, RequireAdmin(&fakeRBACClient{admins: map[uint32]bool{1: true}}), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	t.Run("管理员放行", func(t *testing.T) {
+		w := request(r, http.MethodGet, "/admin?uid=1", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("普通用户拒绝", func(t *testing.T) {
+		w := request(r, http.MethodGet, "/admin?uid=7", "")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
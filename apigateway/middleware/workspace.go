@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	pb "shortLink/proto/userpb"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WorkspaceHeader 指定请求操作的工作区，也可以通过查询参数 workspace_id 指定
+const WorkspaceHeader = "X-Workspace-ID"
+
+// WorkspaceMiddleware 请求指定了工作区时，通过 RBAC 服务检查用户在工作区中是否拥有 resource:action 权限
+// 通过后注入 WorkspaceID 和 WorkspaceRole，未指定工作区时按个人短链接处理，直接放行
+// 需要放在 AuthMiddleware 之后
+func WorkspaceMiddleware(rbacClient pb.RBACServiceClient, resource, action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		raw := c.GetHeader(WorkspaceHeader)
+		if raw == "" {
+			raw = c.Query("workspace_id")
+		}
+		if raw == "" {
+			c.Next()
+			return
+		}
+		workspaceID, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil || workspaceID == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "工作区ID非法", "data": nil})
+			c.Abort()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
+		defer cancel()
+		res, err := rbacClient.CheckWorkspacePermission(ctx, &pb.CheckWorkspacePermissionRequest{
+			UserId:      uint32(c.GetUint("UserID")),
+			WorkspaceId: uint32(workspaceID),
+			Resource:    resource,
+			Action:      action,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "检查工作区权限失败", "data": nil})
+			c.Abort()
+			return
+		}
+		if !res.HasPermission {
+			c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": res.Message, "data": nil})
+			c.Abort()
+			return
+		}
+
+		c.Set("WorkspaceID", strconv.FormatUint(workspaceID, 10))
+		c.Set("WorkspaceRole", res.Role)
+		c.Next()
+	}
+}
@@ -0,0 +1,344 @@
+// CSV 导入模块：解析批量导入的短链接文件，兼容常见短链接服务的导出格式
+//
+// 标准格式的列为 destination、alias、tags、expiry、redirect_type，除 destination 外都可以省略。
+// 表头不区分大小写，空格、下划线和连字符会被忽略，因此 Bitly、Rebrandly、Short.io、
+// YOURLS、TinyURL 等导出文件中的 long_url、slashtag、path、keyword 等列名都能识别。
+// 没有表头时按标准格式的列顺序解析。
+package csvimport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 标准列
+const (
+	ColDestination  = "destination"
+	ColAlias        = "alias"
+	ColTags         = "tags"
+	ColExpiry       = "expiry"
+	ColRedirectType = "redirect_type"
+)
+
+// 列名别名，key 为规范化后的表头（小写，去掉空格、下划线和连字符）
+var headerAliases = map[string]string{
+	// 目标地址
+	"destination":    ColDestination,
+	"destinationurl": ColDestination,
+	"url":            ColDestination,
+	"longurl":        ColDestination, // Bitly、TinyURL
+	"originalurl":    ColDestination, // Short.io
+	"target":         ColDestination,
+	"targeturl":      ColDestination,
+	// 自定义短链接
+	"alias":       ColAlias, // TinyURL
+	"slug":        ColAlias,
+	"slashtag":    ColAlias, // Rebrandly
+	"backhalf":    ColAlias,
+	"path":        ColAlias, // Short.io
+	"keyword":     ColAlias, // YOURLS
+	"code":        ColAlias,
+	"shortcode":   ColAlias,
+	"customalias": ColAlias,
+	// 标签
+	"tags":   ColTags,
+	"tag":    ColTags,
+	"labels": ColTags,
+	// 过期时间
+	"expiry":     ColExpiry,
+	"expires":    ColExpiry,
+	"expiresat":  ColExpiry, // Short.io
+	"expireat":   ColExpiry,
+	"expiration": ColExpiry,
+	// 跳转状态码
+	"redirecttype": ColRedirectType,
+	"redirect":     ColRedirectType,
+	"statuscode":   ColRedirectType,
+}
+
+// 完整短链接列，只在没有别名列时从中提取短链接路径
+var shortURLHeaders = map[string]bool{
+	"link":      true, // Bitly
+	"bitlink":   true,
+	"shorturl":  true, // Rebrandly、Short.io、YOURLS
+	"shortlink": true,
+}
+
+// 没有表头时的列顺序
+var defaultColumns = []string{ColDestination, ColAlias, ColTags, ColExpiry, ColRedirectType}
+
+// 支持的过期时间格式
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02",
+}
+
+// Row 解析成功的一行
+type Row struct {
+	Line         int // 文件中的行号，从1开始
+	Destination  string
+	Alias        string
+	Tags         []string
+	ExpireAt     int64 // Unix 秒，0 表示永不过期
+	RedirectType int32 // 0 表示默认
+}
+
+// RowError 被拒绝的一行
+type RowError struct {
+	Line        int
+	Destination string
+	Reason      string
+}
+
+// Options 解析参数
+type Options struct {
+	MaxRows int            // 最多解析的数据行数，0 表示不限制
+	Now     time.Time      // 用于判断过期时间是否已过，默认当前时间
+	Loc     *time.Location // 不带时区的时间按该时区解析，默认 UTC
+}
+
+var ErrTooManyRows = errors.New("数据行数超过上限")
+
+// Parse 解析 CSV 文件，返回解析成功的行和被拒绝的行
+func Parse(r io.Reader, opts Options) ([]Row, []RowError, error) {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	if opts.Loc == nil {
+		opts.Loc = time.UTC
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	// 去掉 Excel 导出的 UTF-8 BOM
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var (
+		rows     []Row
+		errs     []RowError
+		columns  map[string]int
+		shortCol = -1
+	)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("CSV 格式错误: %w", err)
+		}
+		// 带换行的引号字段会占用多行，以记录开始的行号为准
+		line, _ := reader.FieldPos(0)
+		if isBlank(record) {
+			continue
+		}
+
+		// 第一行非空记录决定列的含义
+		if columns == nil {
+			var header bool
+			columns, shortCol, header = detectColumns(record)
+			if columns == nil {
+				return nil, nil, errors.New("无法识别目标地址列")
+			}
+			if header {
+				continue
+			}
+		}
+
+		if opts.MaxRows > 0 && len(rows)+len(errs) >= opts.MaxRows {
+			return nil, nil, ErrTooManyRows
+		}
+		row, err := parseRow(record, columns, shortCol, opts)
+		row.Line = line
+		if err != nil {
+			errs = append(errs, RowError{Line: line, Destination: row.Destination, Reason: err.Error()})
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return rows, errs, nil
+}
+
+// detectColumns 根据第一行确定列位置，第一行不是表头时按默认列顺序
+func detectColumns(record []string) (map[string]int, int, bool) {
+	columns := make(map[string]int)
+	shortCol := -1
+	for i, h := range record {
+		key := normalizeHeader(h)
+		if col, ok := headerAliases[key]; ok {
+			if _, dup := columns[col]; !dup {
+				columns[col] = i
+			}
+		} else if shortURLHeaders[key] && shortCol < 0 {
+			shortCol = i
+		}
+	}
+	if _, ok := columns[ColDestination]; ok {
+		return columns, shortCol, true
+	}
+	// 第一列是网址，说明没有表头
+	if isURL(record[0]) {
+		columns = make(map[string]int, len(defaultColumns))
+		for i, col := range defaultColumns {
+			columns[col] = i
+		}
+		return columns, -1, false
+	}
+	return nil, -1, false
+}
+
+// parseRow 解析一行数据
+func parseRow(record []string, columns map[string]int, shortCol int, opts Options) (Row, error) {
+	get := func(col string) string {
+		if i, ok := columns[col]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	row := Row{Destination: get(ColDestination)}
+	if row.Destination == "" {
+		return row, errors.New("目标地址为空")
+	}
+	if !isURL(row.Destination) {
+		return row, errors.New("目标地址不是有效的网址")
+	}
+
+	row.Alias = get(ColAlias)
+	if row.Alias == "" && shortCol >= 0 && shortCol < len(record) {
+		row.Alias = aliasFromShortURL(record[shortCol])
+	}
+	row.Tags = splitTags(get(ColTags))
+
+	if v := get(ColExpiry); v != "" {
+		t, err := parseTime(v, opts.Loc)
+		if err != nil {
+			return row, err
+		}
+		if !t.After(opts.Now) {
+			return row, errors.New("过期时间早于当前时间")
+		}
+		row.ExpireAt = t.Unix()
+	}
+
+	if v := get(ColRedirectType); v != "" {
+		code, err := parseRedirectType(v)
+		if err != nil {
+			return row, err
+		}
+		row.RedirectType = code
+	}
+	return row, nil
+}
+
+// aliasFromShortURL 从完整短链接中取出路径，如 https://bit.ly/abc → abc
+func aliasFromShortURL(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ""
+	}
+	if !strings.Contains(s, "://") {
+		s = "https://" + s
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return ""
+	}
+	return strings.Trim(u.Path, "/")
+}
+
+// splitTags 标签之间可以用逗号、分号或竖线分隔
+func splitTags(s string) []string {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ';' || r == '|'
+	})
+	tags := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f = strings.TrimSpace(f); f != "" {
+			tags = append(tags, f)
+		}
+	}
+	return tags
+}
+
+// parseTime 支持 Unix 秒、毫秒和常见日期格式
+func parseTime(s string, loc *time.Location) (time.Time, error) {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if n > 1e12 {
+			return time.UnixMilli(n), nil
+		}
+		return time.Unix(n, 0), nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("无法识别的过期时间: %s", s)
+}
+
+// parseRedirectType 支持状态码和 permanent / temporary 等写法
+func parseRedirectType(s string) (int32, error) {
+	switch strings.ToLower(s) {
+	case "301", "permanent", "moved":
+		return 301, nil
+	case "302", "temporary", "found":
+		return 302, nil
+	case "307":
+		return 307, nil
+	case "308":
+		return 308, nil
+	}
+	return 0, fmt.Errorf("不支持的跳转类型: %s", s)
+}
+
+// WriteReport 生成被拒绝行的 CSV 报告
+func WriteReport(w io.Writer, errs []RowError) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"line", "destination", "reason"}); err != nil {
+		return err
+	}
+	for _, e := range errs {
+		if err := cw.Write([]string{strconv.Itoa(e.Line), e.Destination, e.Reason}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func normalizeHeader(h string) string {
+	h = strings.ToLower(strings.TrimSpace(h))
+	return strings.NewReplacer(" ", "", "_", "", "-", "").Replace(h)
+}
+
+func isBlank(record []string) bool {
+	for _, f := range record {
+		if strings.TrimSpace(f) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func isURL(s string) bool {
+	u, err := url.Parse(strings.TrimSpace(s))
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,104 @@
+package csvimport
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestParse(t *testing.T) {
+	t.Run("标准格式", func(t *testing.T) {
+		data := "\xef\xbb\xbfDestination,Alias,Tags,Expiry,Redirect Type\n" +
+			"https://example.com/a,promo,\"spring, email\",2025-06-01,301\n" +
+			"https://example.com/b,,,,\n" +
+			"\n" +
+			"not-a-url,x,,,\n" +
+			"https://example.com/c,,,2024-01-01,\n" +
+			"https://example.com/d,,,,999\n"
+		rows, errs, err := Parse(strings.NewReader(data), Options{Now: now})
+		require.NoError(t, err)
+
+		require.Len(t, rows, 2)
+		assert.Equal(t, Row{
+			Line:         2,
+			Destination:  "https://example.com/a",
+			Alias:        "promo",
+			Tags:         []string{"spring", "email"},
+			ExpireAt:     time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC).Unix(),
+			RedirectType: 301,
+		}, rows[0])
+		assert.Equal(t, 3, rows[1].Line)
+		assert.Empty(t, rows[1].Alias)
+
+		require.Len(t, errs, 3)
+		assert.Equal(t, 5, errs[0].Line)
+		assert.Equal(t, "not-a-url", errs[0].Destination)
+		assert.Equal(t, 6, errs[1].Line)
+		assert.Contains(t, errs[1].Reason, "过期时间")
+		assert.Equal(t, 7, errs[2].Line)
+	})
+
+	t.Run("没有表头", func(t *testing.T) {
+		data := "https://example.com/a,abc,news|tech\nhttps://example.com/b\n"
+		rows, errs, err := Parse(strings.NewReader(data), Options{Now: now})
+		require.NoError(t, err)
+		assert.Empty(t, errs)
+		require.Len(t, rows, 2)
+		assert.Equal(t, "abc", rows[0].Alias)
+		assert.Equal(t, []string{"news", "tech"}, rows[0].Tags)
+		assert.Equal(t, 2, rows[1].Line)
+	})
+
+	t.Run("其他短链接服务的导出格式", func(t *testing.T) {
+		cases := map[string]struct {
+			data  string
+			alias string
+		}{
+			"Bitly":     {"title,long_url,link,created\nHome,https://example.com,https://bit.ly/3abcd,2024-01-01\n", "3abcd"},
+			"Rebrandly": {"id,slashtag,destination,shortUrl\n1,spring,https://example.com,rebrand.ly/spring\n", "spring"},
+			"Short.io":  {"originalURL,path,shortURL,tags\nhttps://example.com,go,https://short.io/go,a\n", "go"},
+			"YOURLS":    {"keyword,url,title,timestamp,ip,clicks\nyo,https://example.com,t,2024-01-01,1.1.1.1,3\n", "yo"},
+		}
+		for name, c := range cases {
+			rows, errs, err := Parse(strings.NewReader(c.data), Options{Now: now})
+			require.NoError(t, err, name)
+			assert.Empty(t, errs, name)
+			require.Len(t, rows, 1, name)
+			assert.Equal(t, "https://example.com", rows[0].Destination, name)
+			assert.Equal(t, c.alias, rows[0].Alias, name)
+		}
+	})
+
+	t.Run("无法识别的文件", func(t *testing.T) {
+		_, _, err := Parse(strings.NewReader("name,value\na,b\n"), Options{})
+		assert.Error(t, err)
+	})
+
+	t.Run("超过行数上限", func(t *testing.T) {
+		data := "https://example.com/a\nhttps://example.com/b\nhttps://example.com/c\n"
+		_, _, err := Parse(strings.NewReader(data), Options{MaxRows: 2})
+		assert.ErrorIs(t, err, ErrTooManyRows)
+	})
+}
+
+func TestParseTime(t *testing.T) {
+	for _, s := range []string{"1735689600", "1735689600000", "2025-01-01T00:00:00Z", "2025-01-01 00:00:00", "2025-01-01", "2025/01/01"} {
+		got, err := parseTime(s, time.UTC)
+		require.NoError(t, err, s)
+		assert.True(t, got.Equal(now), s)
+	}
+	_, err := parseTime("next week", time.UTC)
+	assert.Error(t, err)
+}
+
+func TestWriteReport(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteReport(&buf, []RowError{{Line: 3, Destination: "x,y", Reason: "目标地址不是有效的网址"}}))
+	assert.Equal(t, "line,destination,reason\n3,\"x,y\",目标地址不是有效的网址\n", buf.String())
+}
@@ -0,0 +1,73 @@
+// App 深链接跳转页面
+package deeplink
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"net/url"
+	"strings"
+)
+
+// ErrUnsafeURL 深链接或兜底地址不允许在跳转页面中使用
+var ErrUnsafeURL = errors.New("跳转地址不安全")
+
+// unsafeSchemes 会在网关页面中执行脚本或读取本地内容的 scheme
+// 保存规则时已经校验过，这里再检查一次，防止旧数据或校验遗漏导致脚本注入
+var unsafeSchemes = map[string]bool{
+	"javascript": true,
+	"data":       true,
+	"vbscript":   true,
+	"file":       true,
+	"blob":       true,
+}
+
+// 浏览器无法通过 302 判断 App 是否安装，这里先尝试唤起 App，超时后跳转网页兜底地址
+var pageTmpl = template.Must(template.New("deeplink").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width,initial-scale=1">
+<title>正在打开...</title>
+</head>
+<body>
+<p>正在打开应用，如未自动跳转请<a href="{{.Fallback}}">点击这里</a></p>
+<script>
+var target = {{.Target}};
+var fallback = {{.Fallback}};
+var timer = setTimeout(function () { window.location.replace(fallback); }, 1500);
+document.addEventListener("visibilitychange", function () {
+  if (document.hidden) { clearTimeout(timer); }
+});
+window.location.href = target;
+</script>
+</body>
+</html>`))
+
+// RenderPage 生成深链接跳转页面
+// 参数：
+//   - target: App 深链接地址
+//   - fallback: 唤起失败时跳转的网页地址
+func RenderPage(target, fallback string) ([]byte, error) {
+	t, err := url.Parse(target)
+	if err != nil || t.Scheme == "" || unsafeSchemes[strings.ToLower(t.Scheme)] {
+		return nil, ErrUnsafeURL
+	}
+	f, err := url.Parse(fallback)
+	if err != nil || f.Host == "" || (f.Scheme != "http" && f.Scheme != "https") {
+		return nil, ErrUnsafeURL
+	}
+	var buf bytes.Buffer
+	// 模板在 <script> 中会对变量做 JS 字符串转义，防止注入
+	err = pageTmpl.Execute(&buf, struct {
+		Target   string
+		Fallback string
+	}{
+		Target:   target,
+		Fallback: fallback,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
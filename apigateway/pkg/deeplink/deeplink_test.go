@@ -0,0 +1,26 @@
+package deeplink
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderPage(t *testing.T) {
+	t.Run("生成唤起页面", func(t *testing.T) {
+		page, err := RenderPage("myapp://item/1", "https://example.com/item/1")
+		require.NoError(t, err)
+		assert.Contains(t, string(page), "myapp://item/1")
+	})
+	t.Run("拒绝不安全的深链接", func(t *testing.T) {
+		for _, target := range []string{"javascript:alert(1)", "JAVASCRIPT:alert(1)", "data:text/html,x", "vbscript:x", "file:///etc/passwd", "blob:x"} {
+			_, err := RenderPage(target, "https://example.com")
+			assert.ErrorIs(t, err, ErrUnsafeURL, target)
+		}
+	})
+	t.Run("兜底地址必须是网页地址", func(t *testing.T) {
+		_, err := RenderPage("myapp://home", "javascript:alert(1)")
+		assert.ErrorIs(t, err, ErrUnsafeURL)
+	})
+}
@@ -0,0 +1,159 @@
+// 导出模块：将短链接逐条写成 CSV / JSON / NDJSON，不在内存中累积数据
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	pbShortlink "shortLink/proto/shortlinkpb"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+)
+
+// CSV 列，前几列与导入格式一致，导出的文件可以直接重新导入
+var csvHeader = []string{
+	"destination", "alias", "tags", "expiry", "redirect_type",
+	"domain", "full_url", "status", "clicks", "created_at",
+}
+
+// Link 导出的单个短链接，JSON 和 NDJSON 使用
+type Link struct {
+	ShortURL     string   `json:"short_url"`
+	Domain       string   `json:"domain"`
+	FullURL      string   `json:"full_url"`
+	OriginalURL  string   `json:"original_url"`
+	Status       string   `json:"status"`
+	Tags         []string `json:"tags"`
+	ExpireAt     string   `json:"expire_at,omitempty"`
+	RedirectType int32    `json:"redirect_type,omitempty"`
+	Clicks       int64    `json:"clicks"`
+	CreatedAt    string   `json:"created_at"`
+}
+
+// Writer 逐条写出短链接
+type Writer interface {
+	Write(link *pbShortlink.ExportedLink) error
+	// Close 写出结尾并刷新缓冲，不关闭底层的 io.Writer
+	Close() error
+}
+
+// NewWriter 按格式创建 Writer
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		return &csvWriter{w: cw}, cw.Write(csvHeader)
+	case FormatJSON:
+		return &jsonWriter{w: w, enc: json.NewEncoder(w)}, nil
+	case FormatNDJSON:
+		return &ndjsonWriter{enc: json.NewEncoder(w)}, nil
+	}
+	return nil, fmt.Errorf("不支持的导出格式: %s", format)
+}
+
+// ContentType 返回格式对应的 MIME 类型
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	}
+	return "application/json; charset=utf-8"
+}
+
+// FromPB 转换为导出结构，时间统一为 RFC3339（UTC）
+func FromPB(l *pbShortlink.ExportedLink) Link {
+	link := Link{
+		ShortURL:     l.ShortUrl,
+		Domain:       l.Domain,
+		FullURL:      l.FullUrl,
+		OriginalURL:  l.OriginalUrl,
+		Status:       l.Status,
+		Tags:         l.Tags,
+		RedirectType: l.RedirectType,
+		Clicks:       l.Clicks,
+		CreatedAt:    formatTime(l.CreateTime),
+	}
+	if link.Tags == nil {
+		link.Tags = []string{}
+	}
+	if l.ExpireAt > 0 {
+		link.ExpireAt = formatTime(l.ExpireAt)
+	}
+	return link
+}
+
+func formatTime(unix int64) string {
+	return time.Unix(unix, 0).UTC().Format(time.RFC3339)
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvWriter) Write(l *pbShortlink.ExportedLink) error {
+	link := FromPB(l)
+	redirect := ""
+	if link.RedirectType != 0 {
+		redirect = strconv.Itoa(int(link.RedirectType))
+	}
+	return c.w.Write([]string{
+		link.OriginalURL, link.ShortURL, strings.Join(link.Tags, ","), link.ExpireAt, redirect,
+		link.Domain, link.FullURL, link.Status, strconv.FormatInt(link.Clicks, 10), link.CreatedAt,
+	})
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// jsonWriter 输出一个数组，逐个元素写出
+type jsonWriter struct {
+	w     io.Writer
+	enc   *json.Encoder
+	count int
+}
+
+func (j *jsonWriter) Write(l *pbShortlink.ExportedLink) error {
+	sep := ","
+	if j.count == 0 {
+		sep = "["
+	}
+	if _, err := io.WriteString(j.w, sep); err != nil {
+		return err
+	}
+	j.count++
+	return j.enc.Encode(FromPB(l))
+}
+
+func (j *jsonWriter) Close() error {
+	end := "]\n"
+	if j.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(j.w, end)
+	return err
+}
+
+type ndjsonWriter struct {
+	enc *json.Encoder
+}
+
+func (n *ndjsonWriter) Write(l *pbShortlink.ExportedLink) error {
+	return n.enc.Encode(FromPB(l))
+}
+
+func (n *ndjsonWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,85 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"shortLink/apigateway/pkg/csvimport"
+	pbShortlink "shortLink/proto/shortlinkpb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var links = []*pbShortlink.ExportedLink{
+	{
+		ShortUrl:     "promo",
+		FullUrl:      "https://s.example.com/promo",
+		OriginalUrl:  "https://example.com/a",
+		Status:       "active",
+		Tags:         []string{"email", "spring"},
+		ExpireAt:     1893456000, // 2030-01-01
+		RedirectType: 301,
+		Clicks:       42,
+		CreateTime:   1735689600,
+	},
+	{
+		ShortUrl:    "abc123",
+		Domain:      "go.example.com",
+		OriginalUrl: "https://example.com/b",
+		Status:      "active",
+		CreateTime:  1735689600,
+	},
+}
+
+func write(t *testing.T, format string, items []*pbShortlink.ExportedLink) string {
+	var buf bytes.Buffer
+	w, err := NewWriter(format, &buf)
+	require.NoError(t, err)
+	for _, l := range items {
+		require.NoError(t, w.Write(l))
+	}
+	require.NoError(t, w.Close())
+	return buf.String()
+}
+
+func TestWriter(t *testing.T) {
+	t.Run("CSV 可以重新导入", func(t *testing.T) {
+		out := write(t, FormatCSV, links)
+		rows, errs, err := csvimport.Parse(strings.NewReader(out), csvimport.Options{})
+		require.NoError(t, err)
+		assert.Empty(t, errs)
+		require.Len(t, rows, 2)
+		assert.Equal(t, "https://example.com/a", rows[0].Destination)
+		assert.Equal(t, "promo", rows[0].Alias)
+		assert.Equal(t, []string{"email", "spring"}, rows[0].Tags)
+		assert.Equal(t, int64(1893456000), rows[0].ExpireAt)
+		assert.Equal(t, int32(301), rows[0].RedirectType)
+		assert.Contains(t, out, ",42,2025-01-01T00:00:00Z")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var got []Link
+		require.NoError(t, json.Unmarshal([]byte(write(t, FormatJSON, links)), &got))
+		require.Len(t, got, 2)
+		assert.Equal(t, "2030-01-01T00:00:00Z", got[0].ExpireAt)
+		assert.Equal(t, []string{}, got[1].Tags)
+
+		assert.Equal(t, "[]\n", write(t, FormatJSON, nil))
+	})
+
+	t.Run("NDJSON", func(t *testing.T) {
+		lines := strings.Split(strings.TrimSpace(write(t, FormatNDJSON, links)), "\n")
+		require.Len(t, lines, 2)
+		var got Link
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &got))
+		assert.Equal(t, "go.example.com", got.Domain)
+	})
+
+	t.Run("不支持的格式", func(t *testing.T) {
+		_, err := NewWriter("xml", &bytes.Buffer{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,249 @@
+// 二维码模块：按参数生成 PNG / SVG 格式的短链接二维码
+package qrcode
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"net/url"
+	"strconv"
+	"strings"
+
+	qr "github.com/skip2/go-qrcode"
+)
+
+const (
+	FormatPNG = "png"
+	FormatSVG = "svg"
+
+	MinSize   = 64
+	MaxSize   = 2048
+	MaxMargin = 16
+)
+
+// Options 二维码参数
+type Options struct {
+	Format     string // png / svg
+	Size       int    // 图片边长（像素）
+	Level      string // 纠错等级：L / M / Q / H
+	Margin     int    // 四周留白（模块数）
+	Foreground string // 前景色，6位十六进制，如 000000
+	Background string // 背景色，6位十六进制，如 ffffff
+}
+
+// DefaultOptions 默认参数
+func DefaultOptions() Options {
+	return Options{
+		Format:     FormatPNG,
+		Size:       256,
+		Level:      "M",
+		Margin:     4,
+		Foreground: "000000",
+		Background: "ffffff",
+	}
+}
+
+// ParseOptions 从查询参数解析二维码参数，未指定的参数使用默认值
+// 支持的参数：format、size、level、margin、fg、bg
+func ParseOptions(q url.Values) (Options, error) {
+	o := DefaultOptions()
+	if v := q.Get("format"); v != "" {
+		o.Format = strings.ToLower(v)
+	}
+	if v := q.Get("size"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil {
+			return o, errors.New("size 必须是整数")
+		}
+		o.Size = size
+	}
+	if v := q.Get("level"); v != "" {
+		o.Level = strings.ToUpper(v)
+	}
+	if v := q.Get("margin"); v != "" {
+		margin, err := strconv.Atoi(v)
+		if err != nil {
+			return o, errors.New("margin 必须是整数")
+		}
+		o.Margin = margin
+	}
+	if v := q.Get("fg"); v != "" {
+		o.Foreground = v
+	}
+	if v := q.Get("bg"); v != "" {
+		o.Background = v
+	}
+	return o, o.normalize()
+}
+
+// normalize 校验参数并统一颜色格式
+func (o *Options) normalize() error {
+	if o.Format != FormatPNG && o.Format != FormatSVG {
+		return fmt.Errorf("不支持的格式: %s", o.Format)
+	}
+	if o.Size < MinSize || o.Size > MaxSize {
+		return fmt.Errorf("size 必须在 %d 到 %d 之间", MinSize, MaxSize)
+	}
+	if _, err := recoveryLevel(o.Level); err != nil {
+		return err
+	}
+	if o.Margin < 0 || o.Margin > MaxMargin {
+		return fmt.Errorf("margin 必须在 0 到 %d 之间", MaxMargin)
+	}
+	var err error
+	if o.Foreground, err = normalizeColor(o.Foreground); err != nil {
+		return err
+	}
+	if o.Background, err = normalizeColor(o.Background); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Hash 内容和参数的摘要，用于缓存key
+func (o Options) Hash(content string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%d|%s|%d|%s|%s",
+		content, o.Format, o.Size, o.Level, o.Margin, o.Foreground, o.Background)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// ContentType 返回格式对应的 MIME 类型
+func (o Options) ContentType() string {
+	if o.Format == FormatSVG {
+		return "image/svg+xml"
+	}
+	return "image/png"
+}
+
+// Render 生成二维码图片
+func Render(content string, o Options) ([]byte, error) {
+	if err := o.normalize(); err != nil {
+		return nil, err
+	}
+	level, _ := recoveryLevel(o.Level)
+	code, err := qr.New(content, level)
+	if err != nil {
+		return nil, err
+	}
+	// 留白由 margin 控制，不使用库自带的固定边框
+	code.DisableBorder = true
+	bitmap := code.Bitmap()
+
+	fg, _ := parseColor(o.Foreground)
+	bg, _ := parseColor(o.Background)
+	if o.Format == FormatSVG {
+		return renderSVG(bitmap, o), nil
+	}
+	return renderPNG(bitmap, o, fg, bg)
+}
+
+// DataURI 生成 data URI 形式的二维码，便于直接嵌入页面
+func DataURI(content string, o Options) (string, error) {
+	data, err := Render(content, o)
+	if err != nil {
+		return "", err
+	}
+	return "data:" + o.ContentType() + ";base64," + base64.StdEncoding.EncodeToString(data), nil
+}
+
+// renderPNG 按整数倍放大模块并在画布中居中，保证模块边缘清晰
+func renderPNG(bitmap [][]bool, o Options, fg, bg color.Color) ([]byte, error) {
+	modules := len(bitmap) + 2*o.Margin
+	scale := o.Size / modules
+	if scale < 1 {
+		scale = 1
+	}
+	size := o.Size
+	if modules*scale > size {
+		size = modules * scale
+	}
+	offset := (size-modules*scale)/2 + o.Margin*scale
+
+	img := image.NewPaletted(image.Rect(0, 0, size, size), color.Palette{bg, fg})
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if !dark {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetColorIndex(offset+x*scale+dx, offset+y*scale+dy, 1)
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// renderSVG 每行相邻的深色模块合并为一个矩形，减小文件体积
+func renderSVG(bitmap [][]bool, o Options) []byte {
+	modules := len(bitmap) + 2*o.Margin
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		o.Size, o.Size, modules, modules)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="#%s"/>`, o.Background)
+	fmt.Fprintf(&b, `<path fill="#%s" d="`, o.Foreground)
+	for y, row := range bitmap {
+		for x := 0; x < len(row); {
+			if !row[x] {
+				x++
+				continue
+			}
+			start := x
+			for x < len(row) && row[x] {
+				x++
+			}
+			fmt.Fprintf(&b, "M%d %dh%dv1h-%dz", start+o.Margin, y+o.Margin, x-start, x-start)
+		}
+	}
+	b.WriteString(`"/></svg>`)
+	return []byte(b.String())
+}
+
+func recoveryLevel(level string) (qr.RecoveryLevel, error) {
+	switch level {
+	case "L":
+		return qr.Low, nil
+	case "M":
+		return qr.Medium, nil
+	case "Q":
+		return qr.High, nil
+	case "H":
+		return qr.Highest, nil
+	}
+	return 0, fmt.Errorf("不支持的纠错等级: %s", level)
+}
+
+// normalizeColor 统一为小写6位十六进制，支持 #fff 和 fff 的简写
+func normalizeColor(s string) (string, error) {
+	s = strings.ToLower(strings.TrimPrefix(s, "#"))
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if _, err := parseColor(s); err != nil {
+		return "", err
+	}
+	return s, nil
+}
+
+func parseColor(s string) (color.Color, error) {
+	if len(s) != 6 {
+		return nil, fmt.Errorf("颜色格式错误: %s", s)
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("颜色格式错误: %s", s)
+	}
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}, nil
+}
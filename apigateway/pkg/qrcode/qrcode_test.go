@@ -0,0 +1,71 @@
+package qrcode
+
+import (
+	"bytes"
+	"image/png"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOptions(t *testing.T) {
+	o, err := ParseOptions(url.Values{"format": {"SVG"}, "size": {"300"}, "level": {"h"}, "fg": {"#F00"}})
+	require.NoError(t, err)
+	assert.Equal(t, FormatSVG, o.Format)
+	assert.Equal(t, 300, o.Size)
+	assert.Equal(t, "H", o.Level)
+	assert.Equal(t, "ff0000", o.Foreground)
+	assert.Equal(t, "ffffff", o.Background)
+
+	// 等价参数得到相同的缓存key
+	same, _ := ParseOptions(url.Values{"format": {"svg"}, "size": {"300"}, "level": {"H"}, "fg": {"ff0000"}})
+	assert.Equal(t, o.Hash("https://s.example.com/abc"), same.Hash("https://s.example.com/abc"))
+	assert.NotEqual(t, o.Hash("https://s.example.com/abc"), o.Hash("https://s.example.com/abd"))
+
+	for _, q := range []url.Values{
+		{"format": {"gif"}},
+		{"size": {"10"}},
+		{"level": {"X"}},
+		{"margin": {"-1"}},
+		{"bg": {"zzzzzz"}},
+	} {
+		_, err := ParseOptions(q)
+		assert.Error(t, err, q.Encode())
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Run("PNG", func(t *testing.T) {
+		o := DefaultOptions()
+		o.Foreground = "112233"
+		data, err := Render("https://s.example.com/abc", o)
+		require.NoError(t, err)
+		img, err := png.Decode(bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, 256, img.Bounds().Dx())
+		// 左上角是留白，使用背景色
+		r, g, b, _ := img.At(0, 0).RGBA()
+		assert.Equal(t, [3]uint32{0xffff, 0xffff, 0xffff}, [3]uint32{r, g, b})
+	})
+
+	t.Run("SVG", func(t *testing.T) {
+		o := DefaultOptions()
+		o.Format = FormatSVG
+		o.Background = "eeeeee"
+		data, err := Render("https://s.example.com/abc", o)
+		require.NoError(t, err)
+		svg := string(data)
+		assert.True(t, strings.HasPrefix(svg, "<svg"))
+		assert.Contains(t, svg, `fill="#eeeeee"`)
+		assert.Contains(t, svg, `width="256"`)
+	})
+
+	t.Run("DataURI", func(t *testing.T) {
+		uri, err := DataURI("https://s.example.com/abc", DefaultOptions())
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(uri, "data:image/png;base64,"))
+	})
+}
@@ -0,0 +1,38 @@
+// Package event 服务之间通过 Kafka 传递的事件
+package event
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// ClickTopic 点击事件的默认 Topic
+const ClickTopic = "shortlink-click"
+
+// Click 一次短链接访问，由 shortlinkcore 在跳转时发送，analyticsservice 消费后写入统计表
+type Click struct {
+	Code        string `json:"code"` // 短链接 Key，自定义域名下为 domain/code
+	Domain      string `json:"domain,omitempty"`
+	ShortURL    string `json:"short_url"`
+	UserID      string `json:"user_id,omitempty"`      // 短链接所属用户
+	WorkspaceID string `json:"workspace_id,omitempty"` // 短链接所属工作区
+	Timestamp   int64  `json:"ts"`                     // 访问时间（Unix 毫秒）
+	IPHash      string `json:"ip_hash,omitempty"`      // 加盐哈希后的访问者IP，不记录原始IP
+	UserAgent   string `json:"user_agent,omitempty"`
+	Referer     string `json:"referer,omitempty"`
+	Country     string `json:"country,omitempty"` // ISO 国家代码，GeoIP 未启用时为空
+	Device      string `json:"device,omitempty"`  // mobile / tablet / desktop
+	Browser     string `json:"browser,omitempty"` // 浏览器，如 Chrome、Safari、WeChat
+	OS          string `json:"os,omitempty"`      // 操作系统，如 Windows、iOS、Android
+	Variant     string `json:"variant,omitempty"` // 命中的 A/B 分组
+	Bot         string `json:"bot,omitempty"`     // 识别为机器人的原因，正常访问时为空
+}
+
+// HashIP 对访问者IP加盐哈希，ip 为空时返回空
+func HashIP(salt, ip string) string {
+	if ip == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(salt + "|" + ip))
+	return hex.EncodeToString(sum[:16])
+}
@@ -21,18 +21,180 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// 跳转规则，按顺序匹配，命中第一条即使用其目标地址
+type RedirectRule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 匹配的平台：ios / android / desktop，为空表示不限平台
+	Platform string `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	// User-Agent 中包含的关键字（不区分大小写），为空表示不限
+	UaContains string `protobuf:"bytes,2,opt,name=ua_contains,json=uaContains,proto3" json:"ua_contains,omitempty"`
+	// 命中后的跳转地址，可以是网页地址，也可以是 App 深链接（如 myapp://item/1）
+	TargetUrl string `protobuf:"bytes,3,opt,name=target_url,json=targetUrl,proto3" json:"target_url,omitempty"`
+	// 深链接唤起失败时的网页地址，为空则使用原始链接
+	FallbackUrl string `protobuf:"bytes,4,opt,name=fallback_url,json=fallbackUrl,proto3" json:"fallback_url,omitempty"`
+	// 匹配的国家（ISO 3166-1 代码，如 US、CN），为空表示不限
+	Countries []string `protobuf:"bytes,5,rep,name=countries,proto3" json:"countries,omitempty"`
+	// 匹配的地区（ISO 3166-2 代码，如 US-CA、CN-GD），为空表示不限
+	Regions       []string `protobuf:"bytes,6,rep,name=regions,proto3" json:"regions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedirectRule) Reset() {
+	*x = RedirectRule{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedirectRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedirectRule) ProtoMessage() {}
+
+func (x *RedirectRule) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedirectRule.ProtoReflect.Descriptor instead.
+func (*RedirectRule) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RedirectRule) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *RedirectRule) GetUaContains() string {
+	if x != nil {
+		return x.UaContains
+	}
+	return ""
+}
+
+func (x *RedirectRule) GetTargetUrl() string {
+	if x != nil {
+		return x.TargetUrl
+	}
+	return ""
+}
+
+func (x *RedirectRule) GetFallbackUrl() string {
+	if x != nil {
+		return x.FallbackUrl
+	}
+	return ""
+}
+
+func (x *RedirectRule) GetCountries() []string {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+func (x *RedirectRule) GetRegions() []string {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+// A/B 分流目标
+type SplitVariant struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 分组名称，如 A、B，同一短链接内唯一
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	TargetUrl string `protobuf:"bytes,2,opt,name=target_url,json=targetUrl,proto3" json:"target_url,omitempty"`
+	// 权重，如 70 和 30
+	Weight        int32 `protobuf:"varint,3,opt,name=weight,proto3" json:"weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SplitVariant) Reset() {
+	*x = SplitVariant{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitVariant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitVariant) ProtoMessage() {}
+
+func (x *SplitVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitVariant.ProtoReflect.Descriptor instead.
+func (*SplitVariant) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SplitVariant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SplitVariant) GetTargetUrl() string {
+	if x != nil {
+		return x.TargetUrl
+	}
+	return ""
+}
+
+func (x *SplitVariant) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
 // 请求生成短链接
 type ShortenRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OriginalUrl   string                 `protobuf:"bytes,1,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	OriginalUrl string                 `protobuf:"bytes,1,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	UserId      string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// 可选的跳转规则，未命中时跳转原始链接
+	Rules []*RedirectRule `protobuf:"bytes,3,rep,name=rules,proto3" json:"rules,omitempty"`
+	// 可选的 A/B 分流目标，未命中跳转规则时按权重选择
+	Variants []*SplitVariant `protobuf:"bytes,4,rep,name=variants,proto3" json:"variants,omitempty"`
+	// 短链接域名，为空使用用户的默认域名
+	Domain string `protobuf:"bytes,5,opt,name=domain,proto3" json:"domain,omitempty"`
+	// 所属工作区，为空表示个人短链接
+	WorkspaceId   string `protobuf:"bytes,6,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ShortenRequest) Reset() {
 	*x = ShortenRequest{}
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[0]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -44,7 +206,7 @@ func (x *ShortenRequest) String() string {
 func (*ShortenRequest) ProtoMessage() {}
 
 func (x *ShortenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[0]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -57,7 +219,7 @@ func (x *ShortenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShortenRequest.ProtoReflect.Descriptor instead.
 func (*ShortenRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{0}
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ShortenRequest) GetOriginalUrl() string {
@@ -74,16 +236,48 @@ func (x *ShortenRequest) GetUserId() string {
 	return ""
 }
 
+func (x *ShortenRequest) GetRules() []*RedirectRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *ShortenRequest) GetVariants() []*SplitVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *ShortenRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *ShortenRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
 type ShortenResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	// 完整短链接，如 https://s.example.com/abc123
+	FullUrl string `protobuf:"bytes,2,opt,name=full_url,json=fullUrl,proto3" json:"full_url,omitempty"`
+	// 短链接所在域名，为空表示系统默认域名
+	Domain        string `protobuf:"bytes,3,opt,name=domain,proto3" json:"domain,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ShortenResponse) Reset() {
 	*x = ShortenResponse{}
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[1]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -95,7 +289,7 @@ func (x *ShortenResponse) String() string {
 func (*ShortenResponse) ProtoMessage() {}
 
 func (x *ShortenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[1]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -108,7 +302,7 @@ func (x *ShortenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShortenResponse.ProtoReflect.Descriptor instead.
 func (*ShortenResponse) Descriptor() ([]byte, []int) {
-	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{1}
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ShortenResponse) GetShortUrl() string {
@@ -118,17 +312,39 @@ func (x *ShortenResponse) GetShortUrl() string {
 	return ""
 }
 
+func (x *ShortenResponse) GetFullUrl() string {
+	if x != nil {
+		return x.FullUrl
+	}
+	return ""
+}
+
+func (x *ShortenResponse) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
 // 请求解析短链接
 type ResolveRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	// 网关转发的客户端请求头（User-Agent 等），用于匹配跳转规则
+	Headers map[string]string `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 客户端真实IP（网关根据 X-Forwarded-For 解析），用于地域规则
+	ClientIp string `protobuf:"bytes,3,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	// 访问者标识（网关下发的 Cookie），用于 A/B 分流保持粘性，为空时按IP哈希
+	VisitorId string `protobuf:"bytes,4,opt,name=visitor_id,json=visitorId,proto3" json:"visitor_id,omitempty"`
+	// 请求的 Host，用于区分不同域名下的同名短链接
+	Host          string `protobuf:"bytes,5,opt,name=host,proto3" json:"host,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ResolveRequest) Reset() {
 	*x = ResolveRequest{}
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[2]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -140,7 +356,7 @@ func (x *ResolveRequest) String() string {
 func (*ResolveRequest) ProtoMessage() {}
 
 func (x *ResolveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[2]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -153,7 +369,7 @@ func (x *ResolveRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResolveRequest.ProtoReflect.Descriptor instead.
 func (*ResolveRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{2}
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ResolveRequest) GetShortUrl() string {
@@ -163,16 +379,50 @@ func (x *ResolveRequest) GetShortUrl() string {
 	return ""
 }
 
+func (x *ResolveRequest) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *ResolveRequest) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+func (x *ResolveRequest) GetVisitorId() string {
+	if x != nil {
+		return x.VisitorId
+	}
+	return ""
+}
+
+func (x *ResolveRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
 type ResolveResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OriginalUrl   string                 `protobuf:"bytes,1,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	OriginalUrl string                 `protobuf:"bytes,1,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	// 目标为 App 深链接时的网页兜底地址，为空表示直接跳转
+	FallbackUrl string `protobuf:"bytes,2,opt,name=fallback_url,json=fallbackUrl,proto3" json:"fallback_url,omitempty"`
+	// 命中的 A/B 分组名称，未分流时为空
+	Variant string `protobuf:"bytes,3,opt,name=variant,proto3" json:"variant,omitempty"`
+	// 跳转状态码：301 / 302 / 307 / 308，0 表示默认 302
+	RedirectType  int32 `protobuf:"varint,4,opt,name=redirect_type,json=redirectType,proto3" json:"redirect_type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ResolveResponse) Reset() {
 	*x = ResolveResponse{}
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[3]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -184,7 +434,7 @@ func (x *ResolveResponse) String() string {
 func (*ResolveResponse) ProtoMessage() {}
 
 func (x *ResolveResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[3]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -197,7 +447,7 @@ func (x *ResolveResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResolveResponse.ProtoReflect.Descriptor instead.
 func (*ResolveResponse) Descriptor() ([]byte, []int) {
-	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{3}
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ResolveResponse) GetOriginalUrl() string {
@@ -207,16 +457,48 @@ func (x *ResolveResponse) GetOriginalUrl() string {
 	return ""
 }
 
+func (x *ResolveResponse) GetFallbackUrl() string {
+	if x != nil {
+		return x.FallbackUrl
+	}
+	return ""
+}
+
+func (x *ResolveResponse) GetVariant() string {
+	if x != nil {
+		return x.Variant
+	}
+	return ""
+}
+
+func (x *ResolveResponse) GetRedirectType() int32 {
+	if x != nil {
+		return x.RedirectType
+	}
+	return 0
+}
+
 type TopRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Count         int64                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 返回的数量，默认10，最多100
+	Count int64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// 时间窗口：hour（最近60分钟）/ day（最近24小时）/ week（最近7天）/ all（全部时间）；默认 all，按热度排行时默认 week
+	Window string `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`
+	// 排行方式：clicks（窗口内的点击量）/ trending（指数衰减后的热度），默认 clicks
+	Mode string `protobuf:"bytes,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	// 查询个人短链接的排行
+	UserId string `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// 查询工作区短链接的排行，优先于 user_id
+	WorkspaceId string `protobuf:"bytes,5,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	// 查询全站排行，只允许管理员使用，网关负责校验
+	Global        bool `protobuf:"varint,6,opt,name=global,proto3" json:"global,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TopRequest) Reset() {
 	*x = TopRequest{}
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[4]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -228,7 +510,7 @@ func (x *TopRequest) String() string {
 func (*TopRequest) ProtoMessage() {}
 
 func (x *TopRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[4]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -241,7 +523,7 @@ func (x *TopRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopRequest.ProtoReflect.Descriptor instead.
 func (*TopRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{4}
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *TopRequest) GetCount() int64 {
@@ -251,17 +533,59 @@ func (x *TopRequest) GetCount() int64 {
 	return 0
 }
 
+func (x *TopRequest) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *TopRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *TopRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TopRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *TopRequest) GetGlobal() bool {
+	if x != nil {
+		return x.Global
+	}
+	return false
+}
+
 type ShortLinkItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
-	Clicks        float64                `protobuf:"fixed64,2,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	// 按点击量排行时为窗口内的点击量，按热度排行时为衰减后的热度
+	Clicks float64 `protobuf:"fixed64,2,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	// 最近7天的独立访客数（估算）
+	UniqueVisitors int64 `protobuf:"varint,3,opt,name=unique_visitors,json=uniqueVisitors,proto3" json:"unique_visitors,omitempty"`
+	// 点击时的原始链接
+	OriginalUrl string `protobuf:"bytes,4,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	// 短链接域名，为空表示系统默认域名
+	Domain        string `protobuf:"bytes,5,opt,name=domain,proto3" json:"domain,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ShortLinkItem) Reset() {
 	*x = ShortLinkItem{}
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[5]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -273,7 +597,7 @@ func (x *ShortLinkItem) String() string {
 func (*ShortLinkItem) ProtoMessage() {}
 
 func (x *ShortLinkItem) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[5]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -286,7 +610,7 @@ func (x *ShortLinkItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShortLinkItem.ProtoReflect.Descriptor instead.
 func (*ShortLinkItem) Descriptor() ([]byte, []int) {
-	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{5}
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ShortLinkItem) GetShortUrl() string {
@@ -303,16 +627,39 @@ func (x *ShortLinkItem) GetClicks() float64 {
 	return 0
 }
 
+func (x *ShortLinkItem) GetUniqueVisitors() int64 {
+	if x != nil {
+		return x.UniqueVisitors
+	}
+	return 0
+}
+
+func (x *ShortLinkItem) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *ShortLinkItem) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
 type TopResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Top           []*ShortLinkItem       `protobuf:"bytes,1,rep,name=top,proto3" json:"top,omitempty"`
+	Window        string                 `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`
+	Mode          string                 `protobuf:"bytes,3,opt,name=mode,proto3" json:"mode,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TopResponse) Reset() {
 	*x = TopResponse{}
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[6]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -324,7 +671,7 @@ func (x *TopResponse) String() string {
 func (*TopResponse) ProtoMessage() {}
 
 func (x *TopResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[6]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -337,7 +684,7 @@ func (x *TopResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopResponse.ProtoReflect.Descriptor instead.
 func (*TopResponse) Descriptor() ([]byte, []int) {
-	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{6}
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *TopResponse) GetTop() []*ShortLinkItem {
@@ -347,6 +694,101 @@ func (x *TopResponse) GetTop() []*ShortLinkItem {
 	return nil
 }
 
+func (x *TopResponse) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *TopResponse) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+// 批量生成中带参数的单个短链接（如 CSV 导入的一行）
+type BatchItem struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	OriginalUrl string                 `protobuf:"bytes,1,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	// 自定义短链接，为空时自动生成
+	Alias string `protobuf:"bytes,2,opt,name=alias,proto3" json:"alias,omitempty"`
+	// 创建后打上的标签
+	Tags []string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	// 过期时间（Unix 秒），0 表示永不过期
+	ExpireAt int64 `protobuf:"varint,4,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	// 跳转状态码：301 / 302 / 307 / 308，0 表示默认 302
+	RedirectType  int32 `protobuf:"varint,5,opt,name=redirect_type,json=redirectType,proto3" json:"redirect_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchItem) Reset() {
+	*x = BatchItem{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchItem) ProtoMessage() {}
+
+func (x *BatchItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchItem.ProtoReflect.Descriptor instead.
+func (*BatchItem) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BatchItem) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *BatchItem) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *BatchItem) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *BatchItem) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+func (x *BatchItem) GetRedirectType() int32 {
+	if x != nil {
+		return x.RedirectType
+	}
+	return 0
+}
+
 // 批量生成短链接的请求
 type BatchShortenRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -354,14 +796,20 @@ type BatchShortenRequest struct {
 	OriginalUrls []string `protobuf:"bytes,1,rep,name=original_urls,json=originalUrls,proto3" json:"original_urls,omitempty"`
 	UserId       string   `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	// 并发处理的数量，默认为10
-	Concurrency   int32 `protobuf:"varint,3,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	Concurrency int32 `protobuf:"varint,3,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	// 短链接域名，为空使用用户的默认域名
+	Domain string `protobuf:"bytes,4,opt,name=domain,proto3" json:"domain,omitempty"`
+	// 带参数的短链接列表，与 original_urls 同时传入时追加在其后
+	Items []*BatchItem `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	// 所属工作区，为空表示个人短链接
+	WorkspaceId   string `protobuf:"bytes,6,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *BatchShortenRequest) Reset() {
 	*x = BatchShortenRequest{}
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[7]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -373,7 +821,7 @@ func (x *BatchShortenRequest) String() string {
 func (*BatchShortenRequest) ProtoMessage() {}
 
 func (x *BatchShortenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[7]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -386,7 +834,7 @@ func (x *BatchShortenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchShortenRequest.ProtoReflect.Descriptor instead.
 func (*BatchShortenRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{7}
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *BatchShortenRequest) GetOriginalUrls() []string {
@@ -410,6 +858,27 @@ func (x *BatchShortenRequest) GetConcurrency() int32 {
 	return 0
 }
 
+func (x *BatchShortenRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *BatchShortenRequest) GetItems() []*BatchItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BatchShortenRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
 // 批量生成短链接的单个结果
 type BatchShortenResult struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -418,14 +887,20 @@ type BatchShortenResult struct {
 	// 生成的短URL
 	ShortUrl string `protobuf:"bytes,2,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
 	// 错误信息，如果有的话
-	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// 完整短链接
+	FullUrl string `protobuf:"bytes,4,opt,name=full_url,json=fullUrl,proto3" json:"full_url,omitempty"`
+	// 在请求中的位置（original_urls 在前，items 在后）
+	Index int32 `protobuf:"varint,5,opt,name=index,proto3" json:"index,omitempty"`
+	// 与请求中前面的某一项完全相同，复用其结果
+	Duplicate     bool `protobuf:"varint,6,opt,name=duplicate,proto3" json:"duplicate,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *BatchShortenResult) Reset() {
 	*x = BatchShortenResult{}
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[8]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -437,7 +912,7 @@ func (x *BatchShortenResult) String() string {
 func (*BatchShortenResult) ProtoMessage() {}
 
 func (x *BatchShortenResult) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[8]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -450,7 +925,7 @@ func (x *BatchShortenResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchShortenResult.ProtoReflect.Descriptor instead.
 func (*BatchShortenResult) Descriptor() ([]byte, []int) {
-	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{8}
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *BatchShortenResult) GetOriginalUrl() string {
@@ -474,6 +949,27 @@ func (x *BatchShortenResult) GetError() string {
 	return ""
 }
 
+func (x *BatchShortenResult) GetFullUrl() string {
+	if x != nil {
+		return x.FullUrl
+	}
+	return ""
+}
+
+func (x *BatchShortenResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchShortenResult) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
 // 批量生成短链接的响应
 type BatchShortenResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -491,7 +987,7 @@ type BatchShortenResponse struct {
 
 func (x *BatchShortenResponse) Reset() {
 	*x = BatchShortenResponse{}
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[9]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -503,7 +999,7 @@ func (x *BatchShortenResponse) String() string {
 func (*BatchShortenResponse) ProtoMessage() {}
 
 func (x *BatchShortenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[9]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -516,7 +1012,7 @@ func (x *BatchShortenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchShortenResponse.ProtoReflect.Descriptor instead.
 func (*BatchShortenResponse) Descriptor() ([]byte, []int) {
-	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{9}
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *BatchShortenResponse) GetResults() []*BatchShortenResult {
@@ -557,7 +1053,7 @@ type DeleteUserURLsRequest struct {
 
 func (x *DeleteUserURLsRequest) Reset() {
 	*x = DeleteUserURLsRequest{}
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[10]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -569,7 +1065,7 @@ func (x *DeleteUserURLsRequest) String() string {
 func (*DeleteUserURLsRequest) ProtoMessage() {}
 
 func (x *DeleteUserURLsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[10]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -582,7 +1078,7 @@ func (x *DeleteUserURLsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserURLsRequest.ProtoReflect.Descriptor instead.
 func (*DeleteUserURLsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{10}
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *DeleteUserURLsRequest) GetUserId() string {
@@ -595,14 +1091,14 @@ func (x *DeleteUserURLsRequest) GetUserId() string {
 // 删除用户短链接的响应
 type DeleteUserURLsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeletedCount  int32                  `protobuf:"varint,1,opt,name=deleted_count,json=deletedCount,proto3" json:"deleted_count,omitempty"` // 删除的短链接数量
+	DeletedCount  int32                  `protobuf:"varint,1,opt,name=deleted_count,json=deletedCount,proto3" json:"deleted_count,omitempty"` // 移入回收站的短链接数量
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeleteUserURLsResponse) Reset() {
 	*x = DeleteUserURLsResponse{}
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[11]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -614,7 +1110,7 @@ func (x *DeleteUserURLsResponse) String() string {
 func (*DeleteUserURLsResponse) ProtoMessage() {}
 
 func (x *DeleteUserURLsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[11]
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -627,7 +1123,7 @@ func (x *DeleteUserURLsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserURLsResponse.ProtoReflect.Descriptor instead.
 func (*DeleteUserURLsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{11}
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *DeleteUserURLsResponse) GetDeletedCount() int32 {
@@ -637,53 +1133,5279 @@ func (x *DeleteUserURLsResponse) GetDeletedCount() int32 {
 	return 0
 }
 
-var File_proto_shortlinkpb_shortlink_proto protoreflect.FileDescriptor
+// 更新短链接跳转规则的请求
+type UpdateLinkRulesRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	UserId   string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// 新的规则列表，为空表示清空规则
+	Rules []*RedirectRule `protobuf:"bytes,3,rep,name=rules,proto3" json:"rules,omitempty"`
+	// 短链接域名，为空表示系统默认域名
+	Domain string `protobuf:"bytes,4,opt,name=domain,proto3" json:"domain,omitempty"`
+	// 短链接所属的工作区，为空表示个人短链接
+	WorkspaceId   string `protobuf:"bytes,5,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_shortlinkpb_shortlink_proto_rawDesc = "" +
-	"\n" +
-	"!proto/shortlinkpb/shortlink.proto\x12\tshortlink\"L\n" +
-	"\x0eShortenRequest\x12!\n" +
-	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\".\n" +
-	"\x0fShortenResponse\x12\x1b\n" +
-	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\"-\n" +
-	"\x0eResolveRequest\x12\x1b\n" +
-	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\"4\n" +
-	"\x0fResolveResponse\x12!\n" +
-	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\"\"\n" +
-	"\n" +
-	"TopRequest\x12\x14\n" +
-	"\x05count\x18\x01 \x01(\x03R\x05count\"D\n" +
-	"\rShortLinkItem\x12\x1b\n" +
-	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12\x16\n" +
-	"\x06clicks\x18\x02 \x01(\x01R\x06clicks\"9\n" +
-	"\vTopResponse\x12*\n" +
-	"\x03top\x18\x01 \x03(\v2\x18.shortlink.ShortLinkItemR\x03top\"u\n" +
-	"\x13BatchShortenRequest\x12#\n" +
-	"\roriginal_urls\x18\x01 \x03(\tR\foriginalUrls\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12 \n" +
-	"\vconcurrency\x18\x03 \x01(\x05R\vconcurrency\"j\n" +
-	"\x12BatchShortenResult\x12!\n" +
-	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\x12\x1b\n" +
-	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\"\xb8\x01\n" +
-	"\x14BatchShortenResponse\x127\n" +
-	"\aresults\x18\x01 \x03(\v2\x1d.shortlink.BatchShortenResultR\aresults\x12\x1f\n" +
-	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\x12#\n" +
-	"\rsuccess_count\x18\x03 \x01(\x05R\fsuccessCount\x12!\n" +
-	"\felapsed_time\x18\x04 \x01(\tR\velapsedTime\"0\n" +
-	"\x15DeleteUserURLsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"=\n" +
-	"\x16DeleteUserURLsResponse\x12#\n" +
-	"\rdeleted_count\x18\x01 \x01(\x05R\fdeletedCount2\x85\x03\n" +
-	"\x10ShortlinkService\x12C\n" +
-	"\n" +
-	"ShortenURL\x12\x19.shortlink.ShortenRequest\x1a\x1a.shortlink.ShortenResponse\x12B\n" +
-	"\tRedierect\x12\x19.shortlink.ResolveRequest\x1a\x1a.shortlink.ResolveResponse\x12<\n" +
-	"\vGetTopLinks\x12\x15.shortlink.TopRequest\x1a\x16.shortlink.TopResponse\x12S\n" +
-	"\x10BatchShortenURLs\x12\x1e.shortlink.BatchShortenRequest\x1a\x1f.shortlink.BatchShortenResponse\x12U\n" +
-	"\x0eDeleteUserURLs\x12 .shortlink.DeleteUserURLsRequest\x1a!.shortlink.DeleteUserURLsResponseB\x15Z\x13./proto/shortlinkpbb\x06proto3"
+func (x *UpdateLinkRulesRequest) Reset() {
+	*x = UpdateLinkRulesRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLinkRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLinkRulesRequest) ProtoMessage() {}
+
+func (x *UpdateLinkRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLinkRulesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLinkRulesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateLinkRulesRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *UpdateLinkRulesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateLinkRulesRequest) GetRules() []*RedirectRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *UpdateLinkRulesRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *UpdateLinkRulesRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+// 更新短链接跳转规则的响应
+type UpdateLinkRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleCount     int32                  `protobuf:"varint,1,opt,name=rule_count,json=ruleCount,proto3" json:"rule_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLinkRulesResponse) Reset() {
+	*x = UpdateLinkRulesResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLinkRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLinkRulesResponse) ProtoMessage() {}
+
+func (x *UpdateLinkRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLinkRulesResponse.ProtoReflect.Descriptor instead.
+func (*UpdateLinkRulesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateLinkRulesResponse) GetRuleCount() int32 {
+	if x != nil {
+		return x.RuleCount
+	}
+	return 0
+}
+
+// 更新短链接 A/B 分流配置的请求
+type UpdateLinkVariantsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	UserId   string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// 新的分流目标列表，为空表示关闭分流
+	Variants []*SplitVariant `protobuf:"bytes,3,rep,name=variants,proto3" json:"variants,omitempty"`
+	Domain   string          `protobuf:"bytes,4,opt,name=domain,proto3" json:"domain,omitempty"`
+	// 短链接所属的工作区，为空表示个人短链接
+	WorkspaceId   string `protobuf:"bytes,5,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLinkVariantsRequest) Reset() {
+	*x = UpdateLinkVariantsRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLinkVariantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLinkVariantsRequest) ProtoMessage() {}
+
+func (x *UpdateLinkVariantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLinkVariantsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLinkVariantsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateLinkVariantsRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *UpdateLinkVariantsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateLinkVariantsRequest) GetVariants() []*SplitVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *UpdateLinkVariantsRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *UpdateLinkVariantsRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+// 更新短链接 A/B 分流配置的响应
+type UpdateLinkVariantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VariantCount  int32                  `protobuf:"varint,1,opt,name=variant_count,json=variantCount,proto3" json:"variant_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLinkVariantsResponse) Reset() {
+	*x = UpdateLinkVariantsResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLinkVariantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLinkVariantsResponse) ProtoMessage() {}
+
+func (x *UpdateLinkVariantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLinkVariantsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateLinkVariantsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UpdateLinkVariantsResponse) GetVariantCount() int32 {
+	if x != nil {
+		return x.VariantCount
+	}
+	return 0
+}
+
+// 查询 A/B 分组点击量的请求
+type GetVariantStatsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	UserId   string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Domain   string                 `protobuf:"bytes,3,opt,name=domain,proto3" json:"domain,omitempty"`
+	// 短链接所属的工作区，为空表示个人短链接
+	WorkspaceId   string `protobuf:"bytes,4,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVariantStatsRequest) Reset() {
+	*x = GetVariantStatsRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVariantStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVariantStatsRequest) ProtoMessage() {}
+
+func (x *GetVariantStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVariantStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetVariantStatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetVariantStatsRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *GetVariantStatsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetVariantStatsRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *GetVariantStatsRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+// 单个 A/B 分组的点击统计
+type VariantStat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	TargetUrl     string                 `protobuf:"bytes,2,opt,name=target_url,json=targetUrl,proto3" json:"target_url,omitempty"`
+	Weight        int32                  `protobuf:"varint,3,opt,name=weight,proto3" json:"weight,omitempty"`
+	Clicks        int64                  `protobuf:"varint,4,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VariantStat) Reset() {
+	*x = VariantStat{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VariantStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VariantStat) ProtoMessage() {}
+
+func (x *VariantStat) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VariantStat.ProtoReflect.Descriptor instead.
+func (*VariantStat) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *VariantStat) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VariantStat) GetTargetUrl() string {
+	if x != nil {
+		return x.TargetUrl
+	}
+	return ""
+}
+
+func (x *VariantStat) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *VariantStat) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+// 查询 A/B 分组点击量的响应
+type GetVariantStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Variants      []*VariantStat         `protobuf:"bytes,1,rep,name=variants,proto3" json:"variants,omitempty"`
+	TotalClicks   int64                  `protobuf:"varint,2,opt,name=total_clicks,json=totalClicks,proto3" json:"total_clicks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVariantStatsResponse) Reset() {
+	*x = GetVariantStatsResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVariantStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVariantStatsResponse) ProtoMessage() {}
+
+func (x *GetVariantStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVariantStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetVariantStatsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetVariantStatsResponse) GetVariants() []*VariantStat {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *GetVariantStatsResponse) GetTotalClicks() int64 {
+	if x != nil {
+		return x.TotalClicks
+	}
+	return 0
+}
+
+// 查询短链接点击趋势的请求
+type GetLinkStatsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	UserId   string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Domain   string                 `protobuf:"bytes,3,opt,name=domain,proto3" json:"domain,omitempty"`
+	// 短链接所属的工作区，为空表示个人短链接
+	WorkspaceId string `protobuf:"bytes,4,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	// 起止时间（Unix 秒），包含 from 所在的时间段，不包含 to；为0时 to 为当前时间，from 按粒度取默认范围
+	From int64 `protobuf:"varint,5,opt,name=from,proto3" json:"from,omitempty"`
+	To   int64 `protobuf:"varint,6,opt,name=to,proto3" json:"to,omitempty"`
+	// minute / hour / day，默认 hour
+	Granularity   string `protobuf:"bytes,7,opt,name=granularity,proto3" json:"granularity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkStatsRequest) Reset() {
+	*x = GetLinkStatsRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkStatsRequest) ProtoMessage() {}
+
+func (x *GetLinkStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetLinkStatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetLinkStatsRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *GetLinkStatsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetLinkStatsRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *GetLinkStatsRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *GetLinkStatsRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetLinkStatsRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *GetLinkStatsRequest) GetGranularity() string {
+	if x != nil {
+		return x.Granularity
+	}
+	return ""
+}
+
+// 一个时间段的点击量
+type StatsBucket struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 时间段的开始时间（Unix 秒）
+	Time   int64 `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	Clicks int64 `protobuf:"varint,2,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	// 当天的独立访客数（估算），仅按天查询时返回
+	UniqueVisitors int64 `protobuf:"varint,3,opt,name=unique_visitors,json=uniqueVisitors,proto3" json:"unique_visitors,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StatsBucket) Reset() {
+	*x = StatsBucket{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsBucket) ProtoMessage() {}
+
+func (x *StatsBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsBucket.ProtoReflect.Descriptor instead.
+func (*StatsBucket) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *StatsBucket) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *StatsBucket) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+func (x *StatsBucket) GetUniqueVisitors() int64 {
+	if x != nil {
+		return x.UniqueVisitors
+	}
+	return 0
+}
+
+// 查询短链接点击趋势的响应
+type GetLinkStatsResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Granularity string                 `protobuf:"bytes,1,opt,name=granularity,proto3" json:"granularity,omitempty"`
+	From        int64                  `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To          int64                  `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	Buckets     []*StatsBucket         `protobuf:"bytes,4,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	TotalClicks int64                  `protobuf:"varint,5,opt,name=total_clicks,json=totalClicks,proto3" json:"total_clicks,omitempty"`
+	// 查询范围覆盖的自然日内的独立访客数（估算），同一访客多天访问只计一次
+	UniqueVisitors int64 `protobuf:"varint,6,opt,name=unique_visitors,json=uniqueVisitors,proto3" json:"unique_visitors,omitempty"`
+	// 与 total_clicks 相同时间段内的机器人点击量（链接预览、爬虫、监控等），不计入 total_clicks 和各时间段
+	BotClicks     int64 `protobuf:"varint,7,opt,name=bot_clicks,json=botClicks,proto3" json:"bot_clicks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkStatsResponse) Reset() {
+	*x = GetLinkStatsResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkStatsResponse) ProtoMessage() {}
+
+func (x *GetLinkStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetLinkStatsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetLinkStatsResponse) GetGranularity() string {
+	if x != nil {
+		return x.Granularity
+	}
+	return ""
+}
+
+func (x *GetLinkStatsResponse) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetLinkStatsResponse) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *GetLinkStatsResponse) GetBuckets() []*StatsBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+func (x *GetLinkStatsResponse) GetTotalClicks() int64 {
+	if x != nil {
+		return x.TotalClicks
+	}
+	return 0
+}
+
+func (x *GetLinkStatsResponse) GetUniqueVisitors() int64 {
+	if x != nil {
+		return x.UniqueVisitors
+	}
+	return 0
+}
+
+func (x *GetLinkStatsResponse) GetBotClicks() int64 {
+	if x != nil {
+		return x.BotClicks
+	}
+	return 0
+}
+
+// 查询短链接访问来源分布的请求
+type GetLinkBreakdownRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	UserId   string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Domain   string                 `protobuf:"bytes,3,opt,name=domain,proto3" json:"domain,omitempty"`
+	// 短链接所属的工作区，为空表示个人短链接
+	WorkspaceId string `protobuf:"bytes,4,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	// 起止时间（Unix 秒），按自然日统计，包含 from 和 to 所在的日期；to 为0时为当前时间，from 为0时查询最近30天
+	From int64 `protobuf:"varint,5,opt,name=from,proto3" json:"from,omitempty"`
+	To   int64 `protobuf:"varint,6,opt,name=to,proto3" json:"to,omitempty"`
+	// 要查询的维度：referer / browser / os / device / country，为空时返回全部
+	Dimensions []string `protobuf:"bytes,7,rep,name=dimensions,proto3" json:"dimensions,omitempty"`
+	// 每个维度返回的取值数量，默认10，最多50
+	Limit         int32 `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkBreakdownRequest) Reset() {
+	*x = GetLinkBreakdownRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkBreakdownRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkBreakdownRequest) ProtoMessage() {}
+
+func (x *GetLinkBreakdownRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkBreakdownRequest.ProtoReflect.Descriptor instead.
+func (*GetLinkBreakdownRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetLinkBreakdownRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *GetLinkBreakdownRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetLinkBreakdownRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *GetLinkBreakdownRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *GetLinkBreakdownRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetLinkBreakdownRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *GetLinkBreakdownRequest) GetDimensions() []string {
+	if x != nil {
+		return x.Dimensions
+	}
+	return nil
+}
+
+func (x *GetLinkBreakdownRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// 维度中一个取值的点击量
+type BreakdownItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Clicks        int64                  `protobuf:"varint,2,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BreakdownItem) Reset() {
+	*x = BreakdownItem{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BreakdownItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BreakdownItem) ProtoMessage() {}
+
+func (x *BreakdownItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BreakdownItem.ProtoReflect.Descriptor instead.
+func (*BreakdownItem) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *BreakdownItem) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *BreakdownItem) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+// 一个维度的分布
+type Breakdown struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Dimension string                 `protobuf:"bytes,1,opt,name=dimension,proto3" json:"dimension,omitempty"`
+	// 按点击量从高到低排列
+	Items []*BreakdownItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// 未列出的取值的点击量之和
+	OtherClicks   int64 `protobuf:"varint,3,opt,name=other_clicks,json=otherClicks,proto3" json:"other_clicks,omitempty"`
+	TotalClicks   int64 `protobuf:"varint,4,opt,name=total_clicks,json=totalClicks,proto3" json:"total_clicks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Breakdown) Reset() {
+	*x = Breakdown{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Breakdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Breakdown) ProtoMessage() {}
+
+func (x *Breakdown) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Breakdown.ProtoReflect.Descriptor instead.
+func (*Breakdown) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Breakdown) GetDimension() string {
+	if x != nil {
+		return x.Dimension
+	}
+	return ""
+}
+
+func (x *Breakdown) GetItems() []*BreakdownItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Breakdown) GetOtherClicks() int64 {
+	if x != nil {
+		return x.OtherClicks
+	}
+	return 0
+}
+
+func (x *Breakdown) GetTotalClicks() int64 {
+	if x != nil {
+		return x.TotalClicks
+	}
+	return 0
+}
+
+// 查询短链接访问来源分布的响应
+type GetLinkBreakdownResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          int64                  `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            int64                  `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	Breakdowns    []*Breakdown           `protobuf:"bytes,3,rep,name=breakdowns,proto3" json:"breakdowns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkBreakdownResponse) Reset() {
+	*x = GetLinkBreakdownResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkBreakdownResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkBreakdownResponse) ProtoMessage() {}
+
+func (x *GetLinkBreakdownResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkBreakdownResponse.ProtoReflect.Descriptor instead.
+func (*GetLinkBreakdownResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetLinkBreakdownResponse) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetLinkBreakdownResponse) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *GetLinkBreakdownResponse) GetBreakdowns() []*Breakdown {
+	if x != nil {
+		return x.Breakdowns
+	}
+	return nil
+}
+
+// 机器人 User-Agent 特征
+type BotSignature struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Pattern string                 `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	// 是否为内置特征
+	Builtin bool `protobuf:"varint,2,opt,name=builtin,proto3" json:"builtin,omitempty"`
+	// 内置特征被管理员停用时为 false
+	Enabled       bool `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BotSignature) Reset() {
+	*x = BotSignature{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BotSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BotSignature) ProtoMessage() {}
+
+func (x *BotSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BotSignature.ProtoReflect.Descriptor instead.
+func (*BotSignature) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *BotSignature) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *BotSignature) GetBuiltin() bool {
+	if x != nil {
+		return x.Builtin
+	}
+	return false
+}
+
+func (x *BotSignature) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type ListBotSignaturesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBotSignaturesRequest) Reset() {
+	*x = ListBotSignaturesRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBotSignaturesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBotSignaturesRequest) ProtoMessage() {}
+
+func (x *ListBotSignaturesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBotSignaturesRequest.ProtoReflect.Descriptor instead.
+func (*ListBotSignaturesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{30}
+}
+
+type ListBotSignaturesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Signatures    []*BotSignature        `protobuf:"bytes,1,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBotSignaturesResponse) Reset() {
+	*x = ListBotSignaturesResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBotSignaturesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBotSignaturesResponse) ProtoMessage() {}
+
+func (x *ListBotSignaturesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBotSignaturesResponse.ProtoReflect.Descriptor instead.
+func (*ListBotSignaturesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListBotSignaturesResponse) GetSignatures() []*BotSignature {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+// 更新机器人 User-Agent 特征的请求，只允许管理员调用，网关负责校验
+type UpdateBotSignaturesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 增加的特征，内置特征已停用时重新启用
+	Add []string `protobuf:"bytes,1,rep,name=add,proto3" json:"add,omitempty"`
+	// 删除的自定义特征，或停用的内置特征
+	Remove []string `protobuf:"bytes,2,rep,name=remove,proto3" json:"remove,omitempty"`
+	// 操作的管理员
+	UserId        string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBotSignaturesRequest) Reset() {
+	*x = UpdateBotSignaturesRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBotSignaturesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBotSignaturesRequest) ProtoMessage() {}
+
+func (x *UpdateBotSignaturesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBotSignaturesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBotSignaturesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UpdateBotSignaturesRequest) GetAdd() []string {
+	if x != nil {
+		return x.Add
+	}
+	return nil
+}
+
+func (x *UpdateBotSignaturesRequest) GetRemove() []string {
+	if x != nil {
+		return x.Remove
+	}
+	return nil
+}
+
+func (x *UpdateBotSignaturesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UpdateBotSignaturesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Signatures    []*BotSignature        `protobuf:"bytes,1,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBotSignaturesResponse) Reset() {
+	*x = UpdateBotSignaturesResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBotSignaturesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBotSignaturesResponse) ProtoMessage() {}
+
+func (x *UpdateBotSignaturesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBotSignaturesResponse.ProtoReflect.Descriptor instead.
+func (*UpdateBotSignaturesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UpdateBotSignaturesResponse) GetSignatures() []*BotSignature {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+// 查询短链接基本信息的请求
+type GetLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	Domain        string                 `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkRequest) Reset() {
+	*x = GetLinkRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkRequest) ProtoMessage() {}
+
+func (x *GetLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkRequest.ProtoReflect.Descriptor instead.
+func (*GetLinkRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetLinkRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *GetLinkRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+// 短链接的基本信息，不包含目标地址
+type GetLinkResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	// 短链接所在域名，默认域名为空
+	Domain string `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	// 完整短链接，未配置系统默认域名时默认域名下的短链接为空
+	FullUrl       string `protobuf:"bytes,3,opt,name=full_url,json=fullUrl,proto3" json:"full_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkResponse) Reset() {
+	*x = GetLinkResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkResponse) ProtoMessage() {}
+
+func (x *GetLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkResponse.ProtoReflect.Descriptor instead.
+func (*GetLinkResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetLinkResponse) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *GetLinkResponse) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *GetLinkResponse) GetFullUrl() string {
+	if x != nil {
+		return x.FullUrl
+	}
+	return ""
+}
+
+// 查询短链接预览的请求
+type GetLinkPreviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	Domain        string                 `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkPreviewRequest) Reset() {
+	*x = GetLinkPreviewRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkPreviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkPreviewRequest) ProtoMessage() {}
+
+func (x *GetLinkPreviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkPreviewRequest.ProtoReflect.Descriptor instead.
+func (*GetLinkPreviewRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetLinkPreviewRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *GetLinkPreviewRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+// 短链接目标页面的预览信息，用于前端渲染链接卡片
+type LinkPreview struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Image       string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	// 页面声明的规范地址（link rel="canonical" / og:url），没有时为最终访问的地址
+	CanonicalUrl string `protobuf:"bytes,4,opt,name=canonical_url,json=canonicalUrl,proto3" json:"canonical_url,omitempty"`
+	// 抓取时间（Unix 秒）
+	FetchedAt int64 `protobuf:"varint,5,opt,name=fetched_at,json=fetchedAt,proto3" json:"fetched_at,omitempty"`
+	// 页面摘要，异步生成，可能为空
+	Summary       string `protobuf:"bytes,6,opt,name=summary,proto3" json:"summary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkPreview) Reset() {
+	*x = LinkPreview{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkPreview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkPreview) ProtoMessage() {}
+
+func (x *LinkPreview) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkPreview.ProtoReflect.Descriptor instead.
+func (*LinkPreview) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *LinkPreview) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetCanonicalUrl() string {
+	if x != nil {
+		return x.CanonicalUrl
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetFetchedAt() int64 {
+	if x != nil {
+		return x.FetchedAt
+	}
+	return 0
+}
+
+func (x *LinkPreview) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+// 查询短链接预览的响应
+type GetLinkPreviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Preview       *LinkPreview           `protobuf:"bytes,1,opt,name=preview,proto3" json:"preview,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkPreviewResponse) Reset() {
+	*x = GetLinkPreviewResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkPreviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkPreviewResponse) ProtoMessage() {}
+
+func (x *GetLinkPreviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkPreviewResponse.ProtoReflect.Descriptor instead.
+func (*GetLinkPreviewResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetLinkPreviewResponse) GetPreview() *LinkPreview {
+	if x != nil {
+		return x.Preview
+	}
+	return nil
+}
+
+// 修改短链接目标地址的请求
+type UpdateLinkRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl    string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	UserId      string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OriginalUrl string                 `protobuf:"bytes,3,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	Domain      string                 `protobuf:"bytes,4,opt,name=domain,proto3" json:"domain,omitempty"`
+	// 短链接所属的工作区，为空表示个人短链接
+	WorkspaceId   string `protobuf:"bytes,5,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLinkRequest) Reset() {
+	*x = UpdateLinkRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLinkRequest) ProtoMessage() {}
+
+func (x *UpdateLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLinkRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLinkRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *UpdateLinkRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *UpdateLinkRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateLinkRequest) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *UpdateLinkRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *UpdateLinkRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+// 修改短链接目标地址的响应
+type UpdateLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	OriginalUrl   string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	FullUrl       string                 `protobuf:"bytes,3,opt,name=full_url,json=fullUrl,proto3" json:"full_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLinkResponse) Reset() {
+	*x = UpdateLinkResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLinkResponse) ProtoMessage() {}
+
+func (x *UpdateLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLinkResponse.ProtoReflect.Descriptor instead.
+func (*UpdateLinkResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *UpdateLinkResponse) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *UpdateLinkResponse) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *UpdateLinkResponse) GetFullUrl() string {
+	if x != nil {
+		return x.FullUrl
+	}
+	return ""
+}
+
+// 自定义短链接域名
+type DomainInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Host  string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	// 是否为创建短链接时的默认域名
+	IsDefault bool `protobuf:"varint,2,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
+	Verified  bool `protobuf:"varint,3,opt,name=verified,proto3" json:"verified,omitempty"`
+	// 验证方式：dns / http
+	VerifyMethod string `protobuf:"bytes,4,opt,name=verify_method,json=verifyMethod,proto3" json:"verify_method,omitempty"`
+	VerifyToken  string `protobuf:"bytes,5,opt,name=verify_token,json=verifyToken,proto3" json:"verify_token,omitempty"`
+	// 验证说明，告诉用户需要添加的 TXT 记录或验证文件
+	Instructions  string `protobuf:"bytes,6,opt,name=instructions,proto3" json:"instructions,omitempty"`
+	CreateTime    int64  `protobuf:"varint,7,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DomainInfo) Reset() {
+	*x = DomainInfo{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DomainInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainInfo) ProtoMessage() {}
+
+func (x *DomainInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainInfo.ProtoReflect.Descriptor instead.
+func (*DomainInfo) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *DomainInfo) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *DomainInfo) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+func (x *DomainInfo) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *DomainInfo) GetVerifyMethod() string {
+	if x != nil {
+		return x.VerifyMethod
+	}
+	return ""
+}
+
+func (x *DomainInfo) GetVerifyToken() string {
+	if x != nil {
+		return x.VerifyToken
+	}
+	return ""
+}
+
+func (x *DomainInfo) GetInstructions() string {
+	if x != nil {
+		return x.Instructions
+	}
+	return ""
+}
+
+func (x *DomainInfo) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
+	}
+	return 0
+}
+
+// 登记自定义域名的请求
+type AddDomainRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Host   string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	// 验证方式：dns（默认）/ http
+	VerifyMethod  string `protobuf:"bytes,3,opt,name=verify_method,json=verifyMethod,proto3" json:"verify_method,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDomainRequest) Reset() {
+	*x = AddDomainRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDomainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDomainRequest) ProtoMessage() {}
+
+func (x *AddDomainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDomainRequest.ProtoReflect.Descriptor instead.
+func (*AddDomainRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *AddDomainRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AddDomainRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *AddDomainRequest) GetVerifyMethod() string {
+	if x != nil {
+		return x.VerifyMethod
+	}
+	return ""
+}
+
+type AddDomainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domain        *DomainInfo            `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDomainResponse) Reset() {
+	*x = AddDomainResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDomainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDomainResponse) ProtoMessage() {}
+
+func (x *AddDomainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDomainResponse.ProtoReflect.Descriptor instead.
+func (*AddDomainResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *AddDomainResponse) GetDomain() *DomainInfo {
+	if x != nil {
+		return x.Domain
+	}
+	return nil
+}
+
+// 验证域名的请求
+type VerifyDomainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Host          string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyDomainRequest) Reset() {
+	*x = VerifyDomainRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyDomainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyDomainRequest) ProtoMessage() {}
+
+func (x *VerifyDomainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyDomainRequest.ProtoReflect.Descriptor instead.
+func (*VerifyDomainRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *VerifyDomainRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *VerifyDomainRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+type VerifyDomainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domain        *DomainInfo            `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyDomainResponse) Reset() {
+	*x = VerifyDomainResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyDomainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyDomainResponse) ProtoMessage() {}
+
+func (x *VerifyDomainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyDomainResponse.ProtoReflect.Descriptor instead.
+func (*VerifyDomainResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *VerifyDomainResponse) GetDomain() *DomainInfo {
+	if x != nil {
+		return x.Domain
+	}
+	return nil
+}
+
+// 查询用户域名的请求
+type ListDomainsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDomainsRequest) Reset() {
+	*x = ListDomainsRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDomainsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDomainsRequest) ProtoMessage() {}
+
+func (x *ListDomainsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDomainsRequest.ProtoReflect.Descriptor instead.
+func (*ListDomainsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListDomainsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListDomainsResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Domains []*DomainInfo          `protobuf:"bytes,1,rep,name=domains,proto3" json:"domains,omitempty"`
+	// 系统默认域名，所有用户都可以使用
+	SystemDomain  string `protobuf:"bytes,2,opt,name=system_domain,json=systemDomain,proto3" json:"system_domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDomainsResponse) Reset() {
+	*x = ListDomainsResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDomainsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDomainsResponse) ProtoMessage() {}
+
+func (x *ListDomainsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDomainsResponse.ProtoReflect.Descriptor instead.
+func (*ListDomainsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ListDomainsResponse) GetDomains() []*DomainInfo {
+	if x != nil {
+		return x.Domains
+	}
+	return nil
+}
+
+func (x *ListDomainsResponse) GetSystemDomain() string {
+	if x != nil {
+		return x.SystemDomain
+	}
+	return ""
+}
+
+// 设置默认域名的请求
+type SetDefaultDomainRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// 为空表示恢复使用系统默认域名
+	Host          string `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDefaultDomainRequest) Reset() {
+	*x = SetDefaultDomainRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDefaultDomainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDefaultDomainRequest) ProtoMessage() {}
+
+func (x *SetDefaultDomainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDefaultDomainRequest.ProtoReflect.Descriptor instead.
+func (*SetDefaultDomainRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SetDefaultDomainRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetDefaultDomainRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+type SetDefaultDomainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDefaultDomainResponse) Reset() {
+	*x = SetDefaultDomainResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDefaultDomainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDefaultDomainResponse) ProtoMessage() {}
+
+func (x *SetDefaultDomainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDefaultDomainResponse.ProtoReflect.Descriptor instead.
+func (*SetDefaultDomainResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{49}
+}
+
+// 删除域名的请求
+type DeleteDomainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Host          string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDomainRequest) Reset() {
+	*x = DeleteDomainRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDomainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDomainRequest) ProtoMessage() {}
+
+func (x *DeleteDomainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDomainRequest.ProtoReflect.Descriptor instead.
+func (*DeleteDomainRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *DeleteDomainRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteDomainRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+type DeleteDomainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDomainResponse) Reset() {
+	*x = DeleteDomainResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDomainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDomainResponse) ProtoMessage() {}
+
+func (x *DeleteDomainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDomainResponse.ProtoReflect.Descriptor instead.
+func (*DeleteDomainResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{51}
+}
+
+// 短链接的唯一标识：域名 + 短链接
+type LinkRef struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	// 为空表示系统默认域名
+	Domain        string `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkRef) Reset() {
+	*x = LinkRef{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkRef) ProtoMessage() {}
+
+func (x *LinkRef) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkRef.ProtoReflect.Descriptor instead.
+func (*LinkRef) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *LinkRef) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *LinkRef) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+// 批量打标签 / 移除标签的请求
+type TagLinksRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// 类型：tag（默认）/ campaign
+	Kind  string     `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Tags  []string   `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	Links []*LinkRef `protobuf:"bytes,4,rep,name=links,proto3" json:"links,omitempty"`
+	// 操作工作区的短链接，为空时只能操作个人短链接
+	WorkspaceId   string `protobuf:"bytes,5,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagLinksRequest) Reset() {
+	*x = TagLinksRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagLinksRequest) ProtoMessage() {}
+
+func (x *TagLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagLinksRequest.ProtoReflect.Descriptor instead.
+func (*TagLinksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *TagLinksRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TagLinksRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *TagLinksRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TagLinksRequest) GetLinks() []*LinkRef {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *TagLinksRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+type TagLinksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 新增或删除的标签关系数量
+	Affected int64 `protobuf:"varint,1,opt,name=affected,proto3" json:"affected,omitempty"`
+	// 不存在或不属于该用户而被忽略的短链接数量
+	Skipped       int32 `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagLinksResponse) Reset() {
+	*x = TagLinksResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagLinksResponse) ProtoMessage() {}
+
+func (x *TagLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagLinksResponse.ProtoReflect.Descriptor instead.
+func (*TagLinksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *TagLinksResponse) GetAffected() int64 {
+	if x != nil {
+		return x.Affected
+	}
+	return 0
+}
+
+func (x *TagLinksResponse) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+// 查询用户标签的请求
+type ListTagsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// 为空时返回所有类型
+	Kind          string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsRequest) Reset() {
+	*x = ListTagsRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsRequest) ProtoMessage() {}
+
+func (x *ListTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsRequest.ProtoReflect.Descriptor instead.
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ListTagsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListTagsRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+type TagInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	LinkCount     int64                  `protobuf:"varint,3,opt,name=link_count,json=linkCount,proto3" json:"link_count,omitempty"`
+	CreateTime    int64                  `protobuf:"varint,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagInfo) Reset() {
+	*x = TagInfo{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagInfo) ProtoMessage() {}
+
+func (x *TagInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagInfo.ProtoReflect.Descriptor instead.
+func (*TagInfo) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *TagInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TagInfo) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *TagInfo) GetLinkCount() int64 {
+	if x != nil {
+		return x.LinkCount
+	}
+	return 0
+}
+
+func (x *TagInfo) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
+	}
+	return 0
+}
+
+type ListTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []*TagInfo             `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsResponse) Reset() {
+	*x = ListTagsResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsResponse) ProtoMessage() {}
+
+func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ListTagsResponse) GetTags() []*TagInfo {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// 分页查询短链接的请求
+type ListLinksRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// 查询工作区的短链接，为空查询个人短链接
+	WorkspaceId   string `protobuf:"bytes,2,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	Page          int32  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLinksRequest) Reset() {
+	*x = ListLinksRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLinksRequest) ProtoMessage() {}
+
+func (x *ListLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLinksRequest.ProtoReflect.Descriptor instead.
+func (*ListLinksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ListLinksRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListLinksRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *ListLinksRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListLinksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListLinksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*LinkInfo            `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLinksResponse) Reset() {
+	*x = ListLinksResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLinksResponse) ProtoMessage() {}
+
+func (x *ListLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLinksResponse.ProtoReflect.Descriptor instead.
+func (*ListLinksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ListLinksResponse) GetLinks() []*LinkInfo {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *ListLinksResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// 按标签查询短链接的请求
+type ListLinksByTagRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Kind     string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Tag      string                 `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
+	Page     int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// 只返回该工作区的短链接，为空时只返回个人短链接
+	WorkspaceId   string `protobuf:"bytes,6,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLinksByTagRequest) Reset() {
+	*x = ListLinksByTagRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLinksByTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLinksByTagRequest) ProtoMessage() {}
+
+func (x *ListLinksByTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLinksByTagRequest.ProtoReflect.Descriptor instead.
+func (*ListLinksByTagRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ListLinksByTagRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListLinksByTagRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ListLinksByTagRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListLinksByTagRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListLinksByTagRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListLinksByTagRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+type LinkInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	Domain        string                 `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	FullUrl       string                 `protobuf:"bytes,3,opt,name=full_url,json=fullUrl,proto3" json:"full_url,omitempty"`
+	OriginalUrl   string                 `protobuf:"bytes,4,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	CreateTime    int64                  `protobuf:"varint,6,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkInfo) Reset() {
+	*x = LinkInfo{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkInfo) ProtoMessage() {}
+
+func (x *LinkInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkInfo.ProtoReflect.Descriptor instead.
+func (*LinkInfo) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *LinkInfo) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *LinkInfo) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *LinkInfo) GetFullUrl() string {
+	if x != nil {
+		return x.FullUrl
+	}
+	return ""
+}
+
+func (x *LinkInfo) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *LinkInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *LinkInfo) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
+	}
+	return 0
+}
+
+type ListLinksByTagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*LinkInfo            `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLinksByTagResponse) Reset() {
+	*x = ListLinksByTagResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLinksByTagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLinksByTagResponse) ProtoMessage() {}
+
+func (x *ListLinksByTagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLinksByTagResponse.ProtoReflect.Descriptor instead.
+func (*ListLinksByTagResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ListLinksByTagResponse) GetLinks() []*LinkInfo {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *ListLinksByTagResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// 查询标签汇总点击量的请求
+type GetTagClicksRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Kind   string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Tag    string                 `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
+	// 只统计该工作区的短链接，为空时只统计个人短链接
+	WorkspaceId   string `protobuf:"bytes,4,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTagClicksRequest) Reset() {
+	*x = GetTagClicksRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTagClicksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTagClicksRequest) ProtoMessage() {}
+
+func (x *GetTagClicksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTagClicksRequest.ProtoReflect.Descriptor instead.
+func (*GetTagClicksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GetTagClicksRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetTagClicksRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *GetTagClicksRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *GetTagClicksRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+type LinkClicks struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	Domain        string                 `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	Clicks        int64                  `protobuf:"varint,3,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkClicks) Reset() {
+	*x = LinkClicks{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkClicks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkClicks) ProtoMessage() {}
+
+func (x *LinkClicks) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkClicks.ProtoReflect.Descriptor instead.
+func (*LinkClicks) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *LinkClicks) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *LinkClicks) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *LinkClicks) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+type GetTagClicksResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	TotalClicks int64                  `protobuf:"varint,1,opt,name=total_clicks,json=totalClicks,proto3" json:"total_clicks,omitempty"`
+	LinkCount   int64                  `protobuf:"varint,2,opt,name=link_count,json=linkCount,proto3" json:"link_count,omitempty"`
+	// 各短链接点击量，按点击量倒序
+	Links         []*LinkClicks `protobuf:"bytes,3,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTagClicksResponse) Reset() {
+	*x = GetTagClicksResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTagClicksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTagClicksResponse) ProtoMessage() {}
+
+func (x *GetTagClicksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTagClicksResponse.ProtoReflect.Descriptor instead.
+func (*GetTagClicksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *GetTagClicksResponse) GetTotalClicks() int64 {
+	if x != nil {
+		return x.TotalClicks
+	}
+	return 0
+}
+
+func (x *GetTagClicksResponse) GetLinkCount() int64 {
+	if x != nil {
+		return x.LinkCount
+	}
+	return 0
+}
+
+func (x *GetTagClicksResponse) GetLinks() []*LinkClicks {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+// 导出用户短链接的请求
+type ExportLinksRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// 每次从数据库读取的条数，默认500
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// 导出工作区的短链接，为空导出个人短链接
+	WorkspaceId   string `protobuf:"bytes,3,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportLinksRequest) Reset() {
+	*x = ExportLinksRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportLinksRequest) ProtoMessage() {}
+
+func (x *ExportLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportLinksRequest.ProtoReflect.Descriptor instead.
+func (*ExportLinksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ExportLinksRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ExportLinksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ExportLinksRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+// 导出的单个短链接
+type ExportedLink struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl    string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	Domain      string                 `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	FullUrl     string                 `protobuf:"bytes,3,opt,name=full_url,json=fullUrl,proto3" json:"full_url,omitempty"`
+	OriginalUrl string                 `protobuf:"bytes,4,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	Status      string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Tags        []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	// 过期时间（Unix 秒），0 表示永不过期
+	ExpireAt      int64 `protobuf:"varint,7,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	RedirectType  int32 `protobuf:"varint,8,opt,name=redirect_type,json=redirectType,proto3" json:"redirect_type,omitempty"`
+	Clicks        int64 `protobuf:"varint,9,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	CreateTime    int64 `protobuf:"varint,10,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportedLink) Reset() {
+	*x = ExportedLink{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportedLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportedLink) ProtoMessage() {}
+
+func (x *ExportedLink) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportedLink.ProtoReflect.Descriptor instead.
+func (*ExportedLink) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ExportedLink) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *ExportedLink) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *ExportedLink) GetFullUrl() string {
+	if x != nil {
+		return x.FullUrl
+	}
+	return ""
+}
+
+func (x *ExportedLink) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *ExportedLink) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ExportedLink) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ExportedLink) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+func (x *ExportedLink) GetRedirectType() int32 {
+	if x != nil {
+		return x.RedirectType
+	}
+	return 0
+}
+
+func (x *ExportedLink) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+func (x *ExportedLink) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
+	}
+	return 0
+}
+
+// 异步批量任务的进度
+type BatchJobInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	JobId string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// pending / running / completed / cancelled
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Total  int32  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	// 已处理的数量（成功 + 失败）
+	Processed    int32 `protobuf:"varint,4,opt,name=processed,proto3" json:"processed,omitempty"`
+	SuccessCount int32 `protobuf:"varint,5,opt,name=success_count,json=successCount,proto3" json:"success_count,omitempty"`
+	FailedCount  int32 `protobuf:"varint,6,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`
+	CreateTime   int64 `protobuf:"varint,7,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime   int64 `protobuf:"varint,8,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	// 结束时间，未结束时为0
+	FinishTime    int64 `protobuf:"varint,9,opt,name=finish_time,json=finishTime,proto3" json:"finish_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchJobInfo) Reset() {
+	*x = BatchJobInfo{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchJobInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchJobInfo) ProtoMessage() {}
+
+func (x *BatchJobInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchJobInfo.ProtoReflect.Descriptor instead.
+func (*BatchJobInfo) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *BatchJobInfo) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *BatchJobInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BatchJobInfo) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *BatchJobInfo) GetProcessed() int32 {
+	if x != nil {
+		return x.Processed
+	}
+	return 0
+}
+
+func (x *BatchJobInfo) GetSuccessCount() int32 {
+	if x != nil {
+		return x.SuccessCount
+	}
+	return 0
+}
+
+func (x *BatchJobInfo) GetFailedCount() int32 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+func (x *BatchJobInfo) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
+	}
+	return 0
+}
+
+func (x *BatchJobInfo) GetUpdateTime() int64 {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return 0
+}
+
+func (x *BatchJobInfo) GetFinishTime() int64 {
+	if x != nil {
+		return x.FinishTime
+	}
+	return 0
+}
+
+// 提交异步批量任务的响应
+type SubmitBatchJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *BatchJobInfo          `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitBatchJobResponse) Reset() {
+	*x = SubmitBatchJobResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitBatchJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitBatchJobResponse) ProtoMessage() {}
+
+func (x *SubmitBatchJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitBatchJobResponse.ProtoReflect.Descriptor instead.
+func (*SubmitBatchJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *SubmitBatchJobResponse) GetJob() *BatchJobInfo {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+// 查询异步批量任务的请求
+type GetBatchJobRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	JobId  string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// 按结果筛选：success / failed，为空返回所有已处理的项
+	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Offset int32  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	// 每页结果数量，默认100，最大1000
+	Limit         int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBatchJobRequest) Reset() {
+	*x = GetBatchJobRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBatchJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBatchJobRequest) ProtoMessage() {}
+
+func (x *GetBatchJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBatchJobRequest.ProtoReflect.Descriptor instead.
+func (*GetBatchJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *GetBatchJobRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetBatchJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *GetBatchJobRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetBatchJobRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetBatchJobRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// 查询异步批量任务的响应
+type GetBatchJobResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Job   *BatchJobInfo          `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	// 已处理的结果，按请求中的位置排序
+	Results []*BatchShortenResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	// 符合筛选条件的结果总数
+	ResultTotal   int64 `protobuf:"varint,3,opt,name=result_total,json=resultTotal,proto3" json:"result_total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBatchJobResponse) Reset() {
+	*x = GetBatchJobResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBatchJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBatchJobResponse) ProtoMessage() {}
+
+func (x *GetBatchJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBatchJobResponse.ProtoReflect.Descriptor instead.
+func (*GetBatchJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *GetBatchJobResponse) GetJob() *BatchJobInfo {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *GetBatchJobResponse) GetResults() []*BatchShortenResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *GetBatchJobResponse) GetResultTotal() int64 {
+	if x != nil {
+		return x.ResultTotal
+	}
+	return 0
+}
+
+// 取消异步批量任务的请求
+type CancelBatchJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelBatchJobRequest) Reset() {
+	*x = CancelBatchJobRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBatchJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBatchJobRequest) ProtoMessage() {}
+
+func (x *CancelBatchJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBatchJobRequest.ProtoReflect.Descriptor instead.
+func (*CancelBatchJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *CancelBatchJobRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CancelBatchJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// 取消异步批量任务的响应
+type CancelBatchJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *BatchJobInfo          `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelBatchJobResponse) Reset() {
+	*x = CancelBatchJobResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBatchJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBatchJobResponse) ProtoMessage() {}
+
+func (x *CancelBatchJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBatchJobResponse.ProtoReflect.Descriptor instead.
+func (*CancelBatchJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *CancelBatchJobResponse) GetJob() *BatchJobInfo {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+// 查询回收站的请求
+type ListTrashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashRequest) Reset() {
+	*x = ListTrashRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashRequest) ProtoMessage() {}
+
+func (x *ListTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashRequest.ProtoReflect.Descriptor instead.
+func (*ListTrashRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ListTrashRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListTrashRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListTrashRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// 回收站中的短链接
+type TrashedLink struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl    string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	Domain      string                 `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	FullUrl     string                 `protobuf:"bytes,3,opt,name=full_url,json=fullUrl,proto3" json:"full_url,omitempty"`
+	OriginalUrl string                 `protobuf:"bytes,4,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	// 移入回收站的时间
+	DeletedAt int64 `protobuf:"varint,5,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// 彻底删除的时间
+	PurgeAt       int64 `protobuf:"varint,6,opt,name=purge_at,json=purgeAt,proto3" json:"purge_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrashedLink) Reset() {
+	*x = TrashedLink{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrashedLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrashedLink) ProtoMessage() {}
+
+func (x *TrashedLink) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrashedLink.ProtoReflect.Descriptor instead.
+func (*TrashedLink) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *TrashedLink) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *TrashedLink) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *TrashedLink) GetFullUrl() string {
+	if x != nil {
+		return x.FullUrl
+	}
+	return ""
+}
+
+func (x *TrashedLink) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *TrashedLink) GetDeletedAt() int64 {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return 0
+}
+
+func (x *TrashedLink) GetPurgeAt() int64 {
+	if x != nil {
+		return x.PurgeAt
+	}
+	return 0
+}
+
+// 查询回收站的响应
+type ListTrashResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*TrashedLink         `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashResponse) Reset() {
+	*x = ListTrashResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashResponse) ProtoMessage() {}
+
+func (x *ListTrashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashResponse.ProtoReflect.Descriptor instead.
+func (*ListTrashResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ListTrashResponse) GetLinks() []*TrashedLink {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *ListTrashResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// 恢复短链接的请求
+type RestoreLinksRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// 要恢复的短链接，为空时恢复整个回收站
+	Links         []*LinkRef `protobuf:"bytes,2,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreLinksRequest) Reset() {
+	*x = RestoreLinksRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreLinksRequest) ProtoMessage() {}
+
+func (x *RestoreLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreLinksRequest.ProtoReflect.Descriptor instead.
+func (*RestoreLinksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *RestoreLinksRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RestoreLinksRequest) GetLinks() []*LinkRef {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+// 恢复短链接的响应
+type RestoreLinksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 恢复的数量
+	Restored int64 `protobuf:"varint,1,opt,name=restored,proto3" json:"restored,omitempty"`
+	// 不在回收站中或不属于该用户而被忽略的数量
+	Skipped       int64 `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreLinksResponse) Reset() {
+	*x = RestoreLinksResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreLinksResponse) ProtoMessage() {}
+
+func (x *RestoreLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreLinksResponse.ProtoReflect.Descriptor instead.
+func (*RestoreLinksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *RestoreLinksResponse) GetRestored() int64 {
+	if x != nil {
+		return x.Restored
+	}
+	return 0
+}
+
+func (x *RestoreLinksResponse) GetSkipped() int64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+// Webhook 信息，不包含签名密钥
+type WebhookInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url   string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// 订阅的事件
+	Events        []string `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	CreateTime    int64    `protobuf:"varint,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookInfo) Reset() {
+	*x = WebhookInfo{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookInfo) ProtoMessage() {}
+
+func (x *WebhookInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookInfo.ProtoReflect.Descriptor instead.
+func (*WebhookInfo) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *WebhookInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebhookInfo) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebhookInfo) GetEvents() []string {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *WebhookInfo) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
+	}
+	return 0
+}
+
+// 创建 Webhook 的请求
+type CreateWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Events        []string               `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookRequest) Reset() {
+	*x = CreateWebhookRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookRequest) ProtoMessage() {}
+
+func (x *CreateWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *CreateWebhookRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateWebhookRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateWebhookRequest) GetEvents() []string {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// 创建 Webhook 的响应，签名密钥只在此时返回
+type CreateWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhook       *WebhookInfo           `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookResponse) Reset() {
+	*x = CreateWebhookResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookResponse) ProtoMessage() {}
+
+func (x *CreateWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookResponse.ProtoReflect.Descriptor instead.
+func (*CreateWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *CreateWebhookResponse) GetWebhook() *WebhookInfo {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+func (x *CreateWebhookResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+// 查询 Webhook 的请求
+type ListWebhooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksRequest) Reset() {
+	*x = ListWebhooksRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksRequest) ProtoMessage() {}
+
+func (x *ListWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *ListWebhooksRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// 查询 Webhook 的响应
+type ListWebhooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhooks      []*WebhookInfo         `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksResponse) Reset() {
+	*x = ListWebhooksResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksResponse) ProtoMessage() {}
+
+func (x *ListWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *ListWebhooksResponse) GetWebhooks() []*WebhookInfo {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+// 删除 Webhook 的请求
+type DeleteWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WebhookId     string                 `protobuf:"bytes,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookRequest) Reset() {
+	*x = DeleteWebhookRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookRequest) ProtoMessage() {}
+
+func (x *DeleteWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *DeleteWebhookRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteWebhookRequest) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+// 删除 Webhook 的响应
+type DeleteWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookResponse) Reset() {
+	*x = DeleteWebhookResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookResponse) ProtoMessage() {}
+
+func (x *DeleteWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *DeleteWebhookResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// 一次事件投递
+type WebhookDeliveryInfo struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	WebhookId string                 `protobuf:"bytes,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	// 同一事件投递给多个 Webhook 时相同，接收方可用于去重
+	EventId string `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Event   string `protobuf:"bytes,4,opt,name=event,proto3" json:"event,omitempty"`
+	// 投递的请求体（JSON）
+	Payload string `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	// pending / succeeded / failed
+	Status   string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Attempts int32  `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// 最近一次投递的响应状态码，0 表示请求未完成
+	ResponseCode int32  `protobuf:"varint,8,opt,name=response_code,json=responseCode,proto3" json:"response_code,omitempty"`
+	LastError    string `protobuf:"bytes,9,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// 下次投递的时间，仅 pending 状态有效
+	NextAttemptAt int64 `protobuf:"varint,10,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	// 手动重放时为原投递的ID
+	ReplayOf      string `protobuf:"bytes,11,opt,name=replay_of,json=replayOf,proto3" json:"replay_of,omitempty"`
+	CreateTime    int64  `protobuf:"varint,12,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	DeliveredAt   int64  `protobuf:"varint,13,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookDeliveryInfo) Reset() {
+	*x = WebhookDeliveryInfo{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDeliveryInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDeliveryInfo) ProtoMessage() {}
+
+func (x *WebhookDeliveryInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDeliveryInfo.ProtoReflect.Descriptor instead.
+func (*WebhookDeliveryInfo) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *WebhookDeliveryInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebhookDeliveryInfo) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *WebhookDeliveryInfo) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *WebhookDeliveryInfo) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *WebhookDeliveryInfo) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *WebhookDeliveryInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WebhookDeliveryInfo) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *WebhookDeliveryInfo) GetResponseCode() int32 {
+	if x != nil {
+		return x.ResponseCode
+	}
+	return 0
+}
+
+func (x *WebhookDeliveryInfo) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *WebhookDeliveryInfo) GetNextAttemptAt() int64 {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return 0
+}
+
+func (x *WebhookDeliveryInfo) GetReplayOf() string {
+	if x != nil {
+		return x.ReplayOf
+	}
+	return ""
+}
+
+func (x *WebhookDeliveryInfo) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
+	}
+	return 0
+}
+
+func (x *WebhookDeliveryInfo) GetDeliveredAt() int64 {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return 0
+}
+
+// 查询投递日志的请求
+type ListWebhookDeliveriesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	UserId    string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WebhookId string                 `protobuf:"bytes,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	// 按状态过滤，为空时返回所有状态
+	Status        string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Page          int32  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *ListWebhookDeliveriesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesRequest) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListWebhookDeliveriesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// 查询投递日志的响应
+type ListWebhookDeliveriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deliveries    []*WebhookDeliveryInfo `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDeliveryInfo {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+func (x *ListWebhookDeliveriesResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// 重放投递的请求
+type ReplayWebhookDeliveryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WebhookId     string                 `protobuf:"bytes,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	DeliveryId    string                 `protobuf:"bytes,3,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayWebhookDeliveryRequest) Reset() {
+	*x = ReplayWebhookDeliveryRequest{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayWebhookDeliveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayWebhookDeliveryRequest) ProtoMessage() {}
+
+func (x *ReplayWebhookDeliveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayWebhookDeliveryRequest.ProtoReflect.Descriptor instead.
+func (*ReplayWebhookDeliveryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *ReplayWebhookDeliveryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReplayWebhookDeliveryRequest) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *ReplayWebhookDeliveryRequest) GetDeliveryId() string {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return ""
+}
+
+// 重放投递的响应，返回新建的投递
+type ReplayWebhookDeliveryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Delivery      *WebhookDeliveryInfo   `protobuf:"bytes,1,opt,name=delivery,proto3" json:"delivery,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayWebhookDeliveryResponse) Reset() {
+	*x = ReplayWebhookDeliveryResponse{}
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayWebhookDeliveryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayWebhookDeliveryResponse) ProtoMessage() {}
+
+func (x *ReplayWebhookDeliveryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlinkpb_shortlink_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayWebhookDeliveryResponse.ProtoReflect.Descriptor instead.
+func (*ReplayWebhookDeliveryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlinkpb_shortlink_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *ReplayWebhookDeliveryResponse) GetDelivery() *WebhookDeliveryInfo {
+	if x != nil {
+		return x.Delivery
+	}
+	return nil
+}
+
+var File_proto_shortlinkpb_shortlink_proto protoreflect.FileDescriptor
+
+const file_proto_shortlinkpb_shortlink_proto_rawDesc = "" +
+	"\n" +
+	"!proto/shortlinkpb/shortlink.proto\x12\tshortlink\"\xc5\x01\n" +
+	"\fRedirectRule\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12\x1f\n" +
+	"\vua_contains\x18\x02 \x01(\tR\n" +
+	"uaContains\x12\x1d\n" +
+	"\n" +
+	"target_url\x18\x03 \x01(\tR\ttargetUrl\x12!\n" +
+	"\ffallback_url\x18\x04 \x01(\tR\vfallbackUrl\x12\x1c\n" +
+	"\tcountries\x18\x05 \x03(\tR\tcountries\x12\x18\n" +
+	"\aregions\x18\x06 \x03(\tR\aregions\"Y\n" +
+	"\fSplitVariant\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"target_url\x18\x02 \x01(\tR\ttargetUrl\x12\x16\n" +
+	"\x06weight\x18\x03 \x01(\x05R\x06weight\"\xeb\x01\n" +
+	"\x0eShortenRequest\x12!\n" +
+	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12-\n" +
+	"\x05rules\x18\x03 \x03(\v2\x17.shortlink.RedirectRuleR\x05rules\x123\n" +
+	"\bvariants\x18\x04 \x03(\v2\x17.shortlink.SplitVariantR\bvariants\x12\x16\n" +
+	"\x06domain\x18\x05 \x01(\tR\x06domain\x12!\n" +
+	"\fworkspace_id\x18\x06 \x01(\tR\vworkspaceId\"a\n" +
+	"\x0fShortenResponse\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12\x19\n" +
+	"\bfull_url\x18\x02 \x01(\tR\afullUrl\x12\x16\n" +
+	"\x06domain\x18\x03 \x01(\tR\x06domain\"\xfb\x01\n" +
+	"\x0eResolveRequest\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12@\n" +
+	"\aheaders\x18\x02 \x03(\v2&.shortlink.ResolveRequest.HeadersEntryR\aheaders\x12\x1b\n" +
+	"\tclient_ip\x18\x03 \x01(\tR\bclientIp\x12\x1d\n" +
+	"\n" +
+	"visitor_id\x18\x04 \x01(\tR\tvisitorId\x12\x12\n" +
+	"\x04host\x18\x05 \x01(\tR\x04host\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x96\x01\n" +
+	"\x0fResolveResponse\x12!\n" +
+	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\x12!\n" +
+	"\ffallback_url\x18\x02 \x01(\tR\vfallbackUrl\x12\x18\n" +
+	"\avariant\x18\x03 \x01(\tR\avariant\x12#\n" +
+	"\rredirect_type\x18\x04 \x01(\x05R\fredirectType\"\xa2\x01\n" +
+	"\n" +
+	"TopRequest\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x03R\x05count\x12\x16\n" +
+	"\x06window\x18\x02 \x01(\tR\x06window\x12\x12\n" +
+	"\x04mode\x18\x03 \x01(\tR\x04mode\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12!\n" +
+	"\fworkspace_id\x18\x05 \x01(\tR\vworkspaceId\x12\x16\n" +
+	"\x06global\x18\x06 \x01(\bR\x06global\"\xa8\x01\n" +
+	"\rShortLinkItem\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12\x16\n" +
+	"\x06clicks\x18\x02 \x01(\x01R\x06clicks\x12'\n" +
+	"\x0funique_visitors\x18\x03 \x01(\x03R\x0euniqueVisitors\x12!\n" +
+	"\foriginal_url\x18\x04 \x01(\tR\voriginalUrl\x12\x16\n" +
+	"\x06domain\x18\x05 \x01(\tR\x06domain\"e\n" +
+	"\vTopResponse\x12*\n" +
+	"\x03top\x18\x01 \x03(\v2\x18.shortlink.ShortLinkItemR\x03top\x12\x16\n" +
+	"\x06window\x18\x02 \x01(\tR\x06window\x12\x12\n" +
+	"\x04mode\x18\x03 \x01(\tR\x04mode\"\x9a\x01\n" +
+	"\tBatchItem\x12!\n" +
+	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\x12\x14\n" +
+	"\x05alias\x18\x02 \x01(\tR\x05alias\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x1b\n" +
+	"\texpire_at\x18\x04 \x01(\x03R\bexpireAt\x12#\n" +
+	"\rredirect_type\x18\x05 \x01(\x05R\fredirectType\"\xdc\x01\n" +
+	"\x13BatchShortenRequest\x12#\n" +
+	"\roriginal_urls\x18\x01 \x03(\tR\foriginalUrls\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12 \n" +
+	"\vconcurrency\x18\x03 \x01(\x05R\vconcurrency\x12\x16\n" +
+	"\x06domain\x18\x04 \x01(\tR\x06domain\x12*\n" +
+	"\x05items\x18\x05 \x03(\v2\x14.shortlink.BatchItemR\x05items\x12!\n" +
+	"\fworkspace_id\x18\x06 \x01(\tR\vworkspaceId\"\xb9\x01\n" +
+	"\x12BatchShortenResult\x12!\n" +
+	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\x12\x1b\n" +
+	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x19\n" +
+	"\bfull_url\x18\x04 \x01(\tR\afullUrl\x12\x14\n" +
+	"\x05index\x18\x05 \x01(\x05R\x05index\x12\x1c\n" +
+	"\tduplicate\x18\x06 \x01(\bR\tduplicate\"\xb8\x01\n" +
+	"\x14BatchShortenResponse\x127\n" +
+	"\aresults\x18\x01 \x03(\v2\x1d.shortlink.BatchShortenResultR\aresults\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12#\n" +
+	"\rsuccess_count\x18\x03 \x01(\x05R\fsuccessCount\x12!\n" +
+	"\felapsed_time\x18\x04 \x01(\tR\velapsedTime\"0\n" +
+	"\x15DeleteUserURLsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"=\n" +
+	"\x16DeleteUserURLsResponse\x12#\n" +
+	"\rdeleted_count\x18\x01 \x01(\x05R\fdeletedCount\"\xb8\x01\n" +
+	"\x16UpdateLinkRulesRequest\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12-\n" +
+	"\x05rules\x18\x03 \x03(\v2\x17.shortlink.RedirectRuleR\x05rules\x12\x16\n" +
+	"\x06domain\x18\x04 \x01(\tR\x06domain\x12!\n" +
+	"\fworkspace_id\x18\x05 \x01(\tR\vworkspaceId\"8\n" +
+	"\x17UpdateLinkRulesResponse\x12\x1d\n" +
+	"\n" +
+	"rule_count\x18\x01 \x01(\x05R\truleCount\"\xc1\x01\n" +
+	"\x19UpdateLinkVariantsRequest\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x123\n" +
+	"\bvariants\x18\x03 \x03(\v2\x17.shortlink.SplitVariantR\bvariants\x12\x16\n" +
+	"\x06domain\x18\x04 \x01(\tR\x06domain\x12!\n" +
+	"\fworkspace_id\x18\x05 \x01(\tR\vworkspaceId\"A\n" +
+	"\x1aUpdateLinkVariantsResponse\x12#\n" +
+	"\rvariant_count\x18\x01 \x01(\x05R\fvariantCount\"\x89\x01\n" +
+	"\x16GetVariantStatsRequest\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06domain\x18\x03 \x01(\tR\x06domain\x12!\n" +
+	"\fworkspace_id\x18\x04 \x01(\tR\vworkspaceId\"p\n" +
+	"\vVariantStat\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"target_url\x18\x02 \x01(\tR\ttargetUrl\x12\x16\n" +
+	"\x06weight\x18\x03 \x01(\x05R\x06weight\x12\x16\n" +
+	"\x06clicks\x18\x04 \x01(\x03R\x06clicks\"p\n" +
+	"\x17GetVariantStatsResponse\x122\n" +
+	"\bvariants\x18\x01 \x03(\v2\x16.shortlink.VariantStatR\bvariants\x12!\n" +
+	"\ftotal_clicks\x18\x02 \x01(\x03R\vtotalClicks\"\xcc\x01\n" +
+	"\x13GetLinkStatsRequest\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06domain\x18\x03 \x01(\tR\x06domain\x12!\n" +
+	"\fworkspace_id\x18\x04 \x01(\tR\vworkspaceId\x12\x12\n" +
+	"\x04from\x18\x05 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x06 \x01(\x03R\x02to\x12 \n" +
+	"\vgranularity\x18\a \x01(\tR\vgranularity\"b\n" +
+	"\vStatsBucket\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\x03R\x04time\x12\x16\n" +
+	"\x06clicks\x18\x02 \x01(\x03R\x06clicks\x12'\n" +
+	"\x0funique_visitors\x18\x03 \x01(\x03R\x0euniqueVisitors\"\xf9\x01\n" +
+	"\x14GetLinkStatsResponse\x12 \n" +
+	"\vgranularity\x18\x01 \x01(\tR\vgranularity\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x03R\x02to\x120\n" +
+	"\abuckets\x18\x04 \x03(\v2\x16.shortlink.StatsBucketR\abuckets\x12!\n" +
+	"\ftotal_clicks\x18\x05 \x01(\x03R\vtotalClicks\x12'\n" +
+	"\x0funique_visitors\x18\x06 \x01(\x03R\x0euniqueVisitors\x12\x1d\n" +
+	"\n" +
+	"bot_clicks\x18\a \x01(\x03R\tbotClicks\"\xe4\x01\n" +
+	"\x17GetLinkBreakdownRequest\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06domain\x18\x03 \x01(\tR\x06domain\x12!\n" +
+	"\fworkspace_id\x18\x04 \x01(\tR\vworkspaceId\x12\x12\n" +
+	"\x04from\x18\x05 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x06 \x01(\x03R\x02to\x12\x1e\n" +
+	"\n" +
+	"dimensions\x18\a \x03(\tR\n" +
+	"dimensions\x12\x14\n" +
+	"\x05limit\x18\b \x01(\x05R\x05limit\"=\n" +
+	"\rBreakdownItem\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x16\n" +
+	"\x06clicks\x18\x02 \x01(\x03R\x06clicks\"\x9f\x01\n" +
+	"\tBreakdown\x12\x1c\n" +
+	"\tdimension\x18\x01 \x01(\tR\tdimension\x12.\n" +
+	"\x05items\x18\x02 \x03(\v2\x18.shortlink.BreakdownItemR\x05items\x12!\n" +
+	"\fother_clicks\x18\x03 \x01(\x03R\votherClicks\x12!\n" +
+	"\ftotal_clicks\x18\x04 \x01(\x03R\vtotalClicks\"t\n" +
+	"\x18GetLinkBreakdownResponse\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\x03R\x02to\x124\n" +
+	"\n" +
+	"breakdowns\x18\x03 \x03(\v2\x14.shortlink.BreakdownR\n" +
+	"breakdowns\"\\\n" +
+	"\fBotSignature\x12\x18\n" +
+	"\apattern\x18\x01 \x01(\tR\apattern\x12\x18\n" +
+	"\abuiltin\x18\x02 \x01(\bR\abuiltin\x12\x18\n" +
+	"\aenabled\x18\x03 \x01(\bR\aenabled\"\x1a\n" +
+	"\x18ListBotSignaturesRequest\"T\n" +
+	"\x19ListBotSignaturesResponse\x127\n" +
+	"\n" +
+	"signatures\x18\x01 \x03(\v2\x17.shortlink.BotSignatureR\n" +
+	"signatures\"_\n" +
+	"\x1aUpdateBotSignaturesRequest\x12\x10\n" +
+	"\x03add\x18\x01 \x03(\tR\x03add\x12\x16\n" +
+	"\x06remove\x18\x02 \x03(\tR\x06remove\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"V\n" +
+	"\x1bUpdateBotSignaturesResponse\x127\n" +
+	"\n" +
+	"signatures\x18\x01 \x03(\v2\x17.shortlink.BotSignatureR\n" +
+	"signatures\"E\n" +
+	"\x0eGetLinkRequest\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\"a\n" +
+	"\x0fGetLinkResponse\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\x12\x19\n" +
+	"\bfull_url\x18\x03 \x01(\tR\afullUrl\"L\n" +
+	"\x15GetLinkPreviewRequest\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\"\xb9\x01\n" +
+	"\vLinkPreview\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05image\x18\x03 \x01(\tR\x05image\x12#\n" +
+	"\rcanonical_url\x18\x04 \x01(\tR\fcanonicalUrl\x12\x1d\n" +
+	"\n" +
+	"fetched_at\x18\x05 \x01(\x03R\tfetchedAt\x12\x18\n" +
+	"\asummary\x18\x06 \x01(\tR\asummary\"J\n" +
+	"\x16GetLinkPreviewResponse\x120\n" +
+	"\apreview\x18\x01 \x01(\v2\x16.shortlink.LinkPreviewR\apreview\"\xa7\x01\n" +
+	"\x11UpdateLinkRequest\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12!\n" +
+	"\foriginal_url\x18\x03 \x01(\tR\voriginalUrl\x12\x16\n" +
+	"\x06domain\x18\x04 \x01(\tR\x06domain\x12!\n" +
+	"\fworkspace_id\x18\x05 \x01(\tR\vworkspaceId\"o\n" +
+	"\x12UpdateLinkResponse\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x12\x19\n" +
+	"\bfull_url\x18\x03 \x01(\tR\afullUrl\"\xe8\x01\n" +
+	"\n" +
+	"DomainInfo\x12\x12\n" +
+	"\x04host\x18\x01 \x01(\tR\x04host\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\x02 \x01(\bR\tisDefault\x12\x1a\n" +
+	"\bverified\x18\x03 \x01(\bR\bverified\x12#\n" +
+	"\rverify_method\x18\x04 \x01(\tR\fverifyMethod\x12!\n" +
+	"\fverify_token\x18\x05 \x01(\tR\vverifyToken\x12\"\n" +
+	"\finstructions\x18\x06 \x01(\tR\finstructions\x12\x1f\n" +
+	"\vcreate_time\x18\a \x01(\x03R\n" +
+	"createTime\"d\n" +
+	"\x10AddDomainRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04host\x18\x02 \x01(\tR\x04host\x12#\n" +
+	"\rverify_method\x18\x03 \x01(\tR\fverifyMethod\"B\n" +
+	"\x11AddDomainResponse\x12-\n" +
+	"\x06domain\x18\x01 \x01(\v2\x15.shortlink.DomainInfoR\x06domain\"B\n" +
+	"\x13VerifyDomainRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04host\x18\x02 \x01(\tR\x04host\"E\n" +
+	"\x14VerifyDomainResponse\x12-\n" +
+	"\x06domain\x18\x01 \x01(\v2\x15.shortlink.DomainInfoR\x06domain\"-\n" +
+	"\x12ListDomainsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"k\n" +
+	"\x13ListDomainsResponse\x12/\n" +
+	"\adomains\x18\x01 \x03(\v2\x15.shortlink.DomainInfoR\adomains\x12#\n" +
+	"\rsystem_domain\x18\x02 \x01(\tR\fsystemDomain\"F\n" +
+	"\x17SetDefaultDomainRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04host\x18\x02 \x01(\tR\x04host\"\x1a\n" +
+	"\x18SetDefaultDomainResponse\"B\n" +
+	"\x13DeleteDomainRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04host\x18\x02 \x01(\tR\x04host\"\x16\n" +
+	"\x14DeleteDomainResponse\">\n" +
+	"\aLinkRef\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\"\x9f\x01\n" +
+	"\x0fTagLinksRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12(\n" +
+	"\x05links\x18\x04 \x03(\v2\x12.shortlink.LinkRefR\x05links\x12!\n" +
+	"\fworkspace_id\x18\x05 \x01(\tR\vworkspaceId\"H\n" +
+	"\x10TagLinksResponse\x12\x1a\n" +
+	"\baffected\x18\x01 \x01(\x03R\baffected\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\x05R\askipped\">\n" +
+	"\x0fListTagsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\"q\n" +
+	"\aTagInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1d\n" +
+	"\n" +
+	"link_count\x18\x03 \x01(\x03R\tlinkCount\x12\x1f\n" +
+	"\vcreate_time\x18\x04 \x01(\x03R\n" +
+	"createTime\":\n" +
+	"\x10ListTagsResponse\x12&\n" +
+	"\x04tags\x18\x01 \x03(\v2\x12.shortlink.TagInfoR\x04tags\"\x7f\n" +
+	"\x10ListLinksRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\fworkspace_id\x18\x02 \x01(\tR\vworkspaceId\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"T\n" +
+	"\x11ListLinksResponse\x12)\n" +
+	"\x05links\x18\x01 \x03(\v2\x13.shortlink.LinkInfoR\x05links\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"\xaa\x01\n" +
+	"\x15ListLinksByTagRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x10\n" +
+	"\x03tag\x18\x03 \x01(\tR\x03tag\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12!\n" +
+	"\fworkspace_id\x18\x06 \x01(\tR\vworkspaceId\"\xb6\x01\n" +
+	"\bLinkInfo\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\x12\x19\n" +
+	"\bfull_url\x18\x03 \x01(\tR\afullUrl\x12!\n" +
+	"\foriginal_url\x18\x04 \x01(\tR\voriginalUrl\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1f\n" +
+	"\vcreate_time\x18\x06 \x01(\x03R\n" +
+	"createTime\"Y\n" +
+	"\x16ListLinksByTagResponse\x12)\n" +
+	"\x05links\x18\x01 \x03(\v2\x13.shortlink.LinkInfoR\x05links\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"w\n" +
+	"\x13GetTagClicksRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x10\n" +
+	"\x03tag\x18\x03 \x01(\tR\x03tag\x12!\n" +
+	"\fworkspace_id\x18\x04 \x01(\tR\vworkspaceId\"Y\n" +
+	"\n" +
+	"LinkClicks\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\x12\x16\n" +
+	"\x06clicks\x18\x03 \x01(\x03R\x06clicks\"\x85\x01\n" +
+	"\x14GetTagClicksResponse\x12!\n" +
+	"\ftotal_clicks\x18\x01 \x01(\x03R\vtotalClicks\x12\x1d\n" +
+	"\n" +
+	"link_count\x18\x02 \x01(\x03R\tlinkCount\x12+\n" +
+	"\x05links\x18\x03 \x03(\v2\x15.shortlink.LinkClicksR\x05links\"m\n" +
+	"\x12ExportLinksRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12!\n" +
+	"\fworkspace_id\x18\x03 \x01(\tR\vworkspaceId\"\xa8\x02\n" +
+	"\fExportedLink\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\x12\x19\n" +
+	"\bfull_url\x18\x03 \x01(\tR\afullUrl\x12!\n" +
+	"\foriginal_url\x18\x04 \x01(\tR\voriginalUrl\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12\x1b\n" +
+	"\texpire_at\x18\a \x01(\x03R\bexpireAt\x12#\n" +
+	"\rredirect_type\x18\b \x01(\x05R\fredirectType\x12\x16\n" +
+	"\x06clicks\x18\t \x01(\x03R\x06clicks\x12\x1f\n" +
+	"\vcreate_time\x18\n" +
+	" \x01(\x03R\n" +
+	"createTime\"\x9c\x02\n" +
+	"\fBatchJobInfo\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x1c\n" +
+	"\tprocessed\x18\x04 \x01(\x05R\tprocessed\x12#\n" +
+	"\rsuccess_count\x18\x05 \x01(\x05R\fsuccessCount\x12!\n" +
+	"\ffailed_count\x18\x06 \x01(\x05R\vfailedCount\x12\x1f\n" +
+	"\vcreate_time\x18\a \x01(\x03R\n" +
+	"createTime\x12\x1f\n" +
+	"\vupdate_time\x18\b \x01(\x03R\n" +
+	"updateTime\x12\x1f\n" +
+	"\vfinish_time\x18\t \x01(\x03R\n" +
+	"finishTime\"C\n" +
+	"\x16SubmitBatchJobResponse\x12)\n" +
+	"\x03job\x18\x01 \x01(\v2\x17.shortlink.BatchJobInfoR\x03job\"\x8a\x01\n" +
+	"\x12GetBatchJobRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"\x9c\x01\n" +
+	"\x13GetBatchJobResponse\x12)\n" +
+	"\x03job\x18\x01 \x01(\v2\x17.shortlink.BatchJobInfoR\x03job\x127\n" +
+	"\aresults\x18\x02 \x03(\v2\x1d.shortlink.BatchShortenResultR\aresults\x12!\n" +
+	"\fresult_total\x18\x03 \x01(\x03R\vresultTotal\"G\n" +
+	"\x15CancelBatchJobRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\"C\n" +
+	"\x16CancelBatchJobResponse\x12)\n" +
+	"\x03job\x18\x01 \x01(\v2\x17.shortlink.BatchJobInfoR\x03job\"\\\n" +
+	"\x10ListTrashRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\xba\x01\n" +
+	"\vTrashedLink\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\x12\x19\n" +
+	"\bfull_url\x18\x03 \x01(\tR\afullUrl\x12!\n" +
+	"\foriginal_url\x18\x04 \x01(\tR\voriginalUrl\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\x05 \x01(\x03R\tdeletedAt\x12\x19\n" +
+	"\bpurge_at\x18\x06 \x01(\x03R\apurgeAt\"W\n" +
+	"\x11ListTrashResponse\x12,\n" +
+	"\x05links\x18\x01 \x03(\v2\x16.shortlink.TrashedLinkR\x05links\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"X\n" +
+	"\x13RestoreLinksRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12(\n" +
+	"\x05links\x18\x02 \x03(\v2\x12.shortlink.LinkRefR\x05links\"L\n" +
+	"\x14RestoreLinksResponse\x12\x1a\n" +
+	"\brestored\x18\x01 \x01(\x03R\brestored\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\x03R\askipped\"h\n" +
+	"\vWebhookInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06events\x18\x03 \x03(\tR\x06events\x12\x1f\n" +
+	"\vcreate_time\x18\x04 \x01(\x03R\n" +
+	"createTime\"Y\n" +
+	"\x14CreateWebhookRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06events\x18\x03 \x03(\tR\x06events\"a\n" +
+	"\x15CreateWebhookResponse\x120\n" +
+	"\awebhook\x18\x01 \x01(\v2\x16.shortlink.WebhookInfoR\awebhook\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\".\n" +
+	"\x13ListWebhooksRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"J\n" +
+	"\x14ListWebhooksResponse\x122\n" +
+	"\bwebhooks\x18\x01 \x03(\v2\x16.shortlink.WebhookInfoR\bwebhooks\"N\n" +
+	"\x14DeleteWebhookRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x02 \x01(\tR\twebhookId\"1\n" +
+	"\x15DeleteWebhookResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\x90\x03\n" +
+	"\x13WebhookDeliveryInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x02 \x01(\tR\twebhookId\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12\x14\n" +
+	"\x05event\x18\x04 \x01(\tR\x05event\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\tR\apayload\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1a\n" +
+	"\battempts\x18\a \x01(\x05R\battempts\x12#\n" +
+	"\rresponse_code\x18\b \x01(\x05R\fresponseCode\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\t \x01(\tR\tlastError\x12&\n" +
+	"\x0fnext_attempt_at\x18\n" +
+	" \x01(\x03R\rnextAttemptAt\x12\x1b\n" +
+	"\treplay_of\x18\v \x01(\tR\breplayOf\x12\x1f\n" +
+	"\vcreate_time\x18\f \x01(\x03R\n" +
+	"createTime\x12!\n" +
+	"\fdelivered_at\x18\r \x01(\x03R\vdeliveredAt\"\x9f\x01\n" +
+	"\x1cListWebhookDeliveriesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x02 \x01(\tR\twebhookId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\"u\n" +
+	"\x1dListWebhookDeliveriesResponse\x12>\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x1e.shortlink.WebhookDeliveryInfoR\n" +
+	"deliveries\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"w\n" +
+	"\x1cReplayWebhookDeliveryRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x02 \x01(\tR\twebhookId\x12\x1f\n" +
+	"\vdelivery_id\x18\x03 \x01(\tR\n" +
+	"deliveryId\"[\n" +
+	"\x1dReplayWebhookDeliveryResponse\x12:\n" +
+	"\bdelivery\x18\x01 \x01(\v2\x1e.shortlink.WebhookDeliveryInfoR\bdelivery2\xd2\x18\n" +
+	"\x10ShortlinkService\x12C\n" +
+	"\n" +
+	"ShortenURL\x12\x19.shortlink.ShortenRequest\x1a\x1a.shortlink.ShortenResponse\x12B\n" +
+	"\tRedierect\x12\x19.shortlink.ResolveRequest\x1a\x1a.shortlink.ResolveResponse\x12<\n" +
+	"\vGetTopLinks\x12\x15.shortlink.TopRequest\x1a\x16.shortlink.TopResponse\x12S\n" +
+	"\x10BatchShortenURLs\x12\x1e.shortlink.BatchShortenRequest\x1a\x1f.shortlink.BatchShortenResponse\x12Y\n" +
+	"\x16BatchShortenURLsStream\x12\x1e.shortlink.BatchShortenRequest\x1a\x1d.shortlink.BatchShortenResult0\x01\x12U\n" +
+	"\x0eDeleteUserURLs\x12 .shortlink.DeleteUserURLsRequest\x1a!.shortlink.DeleteUserURLsResponse\x12F\n" +
+	"\tListTrash\x12\x1b.shortlink.ListTrashRequest\x1a\x1c.shortlink.ListTrashResponse\x12O\n" +
+	"\fRestoreLinks\x12\x1e.shortlink.RestoreLinksRequest\x1a\x1f.shortlink.RestoreLinksResponse\x12X\n" +
+	"\x0fUpdateLinkRules\x12!.shortlink.UpdateLinkRulesRequest\x1a\".shortlink.UpdateLinkRulesResponse\x12a\n" +
+	"\x12UpdateLinkVariants\x12$.shortlink.UpdateLinkVariantsRequest\x1a%.shortlink.UpdateLinkVariantsResponse\x12X\n" +
+	"\x0fGetVariantStats\x12!.shortlink.GetVariantStatsRequest\x1a\".shortlink.GetVariantStatsResponse\x12O\n" +
+	"\fGetLinkStats\x12\x1e.shortlink.GetLinkStatsRequest\x1a\x1f.shortlink.GetLinkStatsResponse\x12[\n" +
+	"\x10GetLinkBreakdown\x12\".shortlink.GetLinkBreakdownRequest\x1a#.shortlink.GetLinkBreakdownResponse\x12^\n" +
+	"\x11ListBotSignatures\x12#.shortlink.ListBotSignaturesRequest\x1a$.shortlink.ListBotSignaturesResponse\x12d\n" +
+	"\x13UpdateBotSignatures\x12%.shortlink.UpdateBotSignaturesRequest\x1a&.shortlink.UpdateBotSignaturesResponse\x12I\n" +
+	"\n" +
+	"UpdateLink\x12\x1c.shortlink.UpdateLinkRequest\x1a\x1d.shortlink.UpdateLinkResponse\x12F\n" +
+	"\tListLinks\x12\x1b.shortlink.ListLinksRequest\x1a\x1c.shortlink.ListLinksResponse\x12@\n" +
+	"\aGetLink\x12\x19.shortlink.GetLinkRequest\x1a\x1a.shortlink.GetLinkResponse\x12U\n" +
+	"\x0eGetLinkPreview\x12 .shortlink.GetLinkPreviewRequest\x1a!.shortlink.GetLinkPreviewResponse\x12F\n" +
+	"\tAddDomain\x12\x1b.shortlink.AddDomainRequest\x1a\x1c.shortlink.AddDomainResponse\x12O\n" +
+	"\fVerifyDomain\x12\x1e.shortlink.VerifyDomainRequest\x1a\x1f.shortlink.VerifyDomainResponse\x12L\n" +
+	"\vListDomains\x12\x1d.shortlink.ListDomainsRequest\x1a\x1e.shortlink.ListDomainsResponse\x12[\n" +
+	"\x10SetDefaultDomain\x12\".shortlink.SetDefaultDomainRequest\x1a#.shortlink.SetDefaultDomainResponse\x12O\n" +
+	"\fDeleteDomain\x12\x1e.shortlink.DeleteDomainRequest\x1a\x1f.shortlink.DeleteDomainResponse\x12C\n" +
+	"\bTagLinks\x12\x1a.shortlink.TagLinksRequest\x1a\x1b.shortlink.TagLinksResponse\x12E\n" +
+	"\n" +
+	"UntagLinks\x12\x1a.shortlink.TagLinksRequest\x1a\x1b.shortlink.TagLinksResponse\x12C\n" +
+	"\bListTags\x12\x1a.shortlink.ListTagsRequest\x1a\x1b.shortlink.ListTagsResponse\x12U\n" +
+	"\x0eListLinksByTag\x12 .shortlink.ListLinksByTagRequest\x1a!.shortlink.ListLinksByTagResponse\x12O\n" +
+	"\fGetTagClicks\x12\x1e.shortlink.GetTagClicksRequest\x1a\x1f.shortlink.GetTagClicksResponse\x12G\n" +
+	"\vExportLinks\x12\x1d.shortlink.ExportLinksRequest\x1a\x17.shortlink.ExportedLink0\x01\x12S\n" +
+	"\x0eSubmitBatchJob\x12\x1e.shortlink.BatchShortenRequest\x1a!.shortlink.SubmitBatchJobResponse\x12L\n" +
+	"\vGetBatchJob\x12\x1d.shortlink.GetBatchJobRequest\x1a\x1e.shortlink.GetBatchJobResponse\x12U\n" +
+	"\x0eCancelBatchJob\x12 .shortlink.CancelBatchJobRequest\x1a!.shortlink.CancelBatchJobResponse\x12R\n" +
+	"\rCreateWebhook\x12\x1f.shortlink.CreateWebhookRequest\x1a .shortlink.CreateWebhookResponse\x12O\n" +
+	"\fListWebhooks\x12\x1e.shortlink.ListWebhooksRequest\x1a\x1f.shortlink.ListWebhooksResponse\x12R\n" +
+	"\rDeleteWebhook\x12\x1f.shortlink.DeleteWebhookRequest\x1a .shortlink.DeleteWebhookResponse\x12j\n" +
+	"\x15ListWebhookDeliveries\x12'.shortlink.ListWebhookDeliveriesRequest\x1a(.shortlink.ListWebhookDeliveriesResponse\x12j\n" +
+	"\x15ReplayWebhookDelivery\x12'.shortlink.ReplayWebhookDeliveryRequest\x1a(.shortlink.ReplayWebhookDeliveryResponseB\x15Z\x13./proto/shortlinkpbb\x06proto3"
 
 var (
 	file_proto_shortlinkpb_shortlink_proto_rawDescOnce sync.Once
@@ -697,39 +6419,216 @@ func file_proto_shortlinkpb_shortlink_proto_rawDescGZIP() []byte {
 	return file_proto_shortlinkpb_shortlink_proto_rawDescData
 }
 
-var file_proto_shortlinkpb_shortlink_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_proto_shortlinkpb_shortlink_proto_msgTypes = make([]protoimpl.MessageInfo, 92)
 var file_proto_shortlinkpb_shortlink_proto_goTypes = []any{
-	(*ShortenRequest)(nil),         // 0: shortlink.ShortenRequest
-	(*ShortenResponse)(nil),        // 1: shortlink.ShortenResponse
-	(*ResolveRequest)(nil),         // 2: shortlink.ResolveRequest
-	(*ResolveResponse)(nil),        // 3: shortlink.ResolveResponse
-	(*TopRequest)(nil),             // 4: shortlink.TopRequest
-	(*ShortLinkItem)(nil),          // 5: shortlink.ShortLinkItem
-	(*TopResponse)(nil),            // 6: shortlink.TopResponse
-	(*BatchShortenRequest)(nil),    // 7: shortlink.BatchShortenRequest
-	(*BatchShortenResult)(nil),     // 8: shortlink.BatchShortenResult
-	(*BatchShortenResponse)(nil),   // 9: shortlink.BatchShortenResponse
-	(*DeleteUserURLsRequest)(nil),  // 10: shortlink.DeleteUserURLsRequest
-	(*DeleteUserURLsResponse)(nil), // 11: shortlink.DeleteUserURLsResponse
+	(*RedirectRule)(nil),                  // 0: shortlink.RedirectRule
+	(*SplitVariant)(nil),                  // 1: shortlink.SplitVariant
+	(*ShortenRequest)(nil),                // 2: shortlink.ShortenRequest
+	(*ShortenResponse)(nil),               // 3: shortlink.ShortenResponse
+	(*ResolveRequest)(nil),                // 4: shortlink.ResolveRequest
+	(*ResolveResponse)(nil),               // 5: shortlink.ResolveResponse
+	(*TopRequest)(nil),                    // 6: shortlink.TopRequest
+	(*ShortLinkItem)(nil),                 // 7: shortlink.ShortLinkItem
+	(*TopResponse)(nil),                   // 8: shortlink.TopResponse
+	(*BatchItem)(nil),                     // 9: shortlink.BatchItem
+	(*BatchShortenRequest)(nil),           // 10: shortlink.BatchShortenRequest
+	(*BatchShortenResult)(nil),            // 11: shortlink.BatchShortenResult
+	(*BatchShortenResponse)(nil),          // 12: shortlink.BatchShortenResponse
+	(*DeleteUserURLsRequest)(nil),         // 13: shortlink.DeleteUserURLsRequest
+	(*DeleteUserURLsResponse)(nil),        // 14: shortlink.DeleteUserURLsResponse
+	(*UpdateLinkRulesRequest)(nil),        // 15: shortlink.UpdateLinkRulesRequest
+	(*UpdateLinkRulesResponse)(nil),       // 16: shortlink.UpdateLinkRulesResponse
+	(*UpdateLinkVariantsRequest)(nil),     // 17: shortlink.UpdateLinkVariantsRequest
+	(*UpdateLinkVariantsResponse)(nil),    // 18: shortlink.UpdateLinkVariantsResponse
+	(*GetVariantStatsRequest)(nil),        // 19: shortlink.GetVariantStatsRequest
+	(*VariantStat)(nil),                   // 20: shortlink.VariantStat
+	(*GetVariantStatsResponse)(nil),       // 21: shortlink.GetVariantStatsResponse
+	(*GetLinkStatsRequest)(nil),           // 22: shortlink.GetLinkStatsRequest
+	(*StatsBucket)(nil),                   // 23: shortlink.StatsBucket
+	(*GetLinkStatsResponse)(nil),          // 24: shortlink.GetLinkStatsResponse
+	(*GetLinkBreakdownRequest)(nil),       // 25: shortlink.GetLinkBreakdownRequest
+	(*BreakdownItem)(nil),                 // 26: shortlink.BreakdownItem
+	(*Breakdown)(nil),                     // 27: shortlink.Breakdown
+	(*GetLinkBreakdownResponse)(nil),      // 28: shortlink.GetLinkBreakdownResponse
+	(*BotSignature)(nil),                  // 29: shortlink.BotSignature
+	(*ListBotSignaturesRequest)(nil),      // 30: shortlink.ListBotSignaturesRequest
+	(*ListBotSignaturesResponse)(nil),     // 31: shortlink.ListBotSignaturesResponse
+	(*UpdateBotSignaturesRequest)(nil),    // 32: shortlink.UpdateBotSignaturesRequest
+	(*UpdateBotSignaturesResponse)(nil),   // 33: shortlink.UpdateBotSignaturesResponse
+	(*GetLinkRequest)(nil),                // 34: shortlink.GetLinkRequest
+	(*GetLinkResponse)(nil),               // 35: shortlink.GetLinkResponse
+	(*GetLinkPreviewRequest)(nil),         // 36: shortlink.GetLinkPreviewRequest
+	(*LinkPreview)(nil),                   // 37: shortlink.LinkPreview
+	(*GetLinkPreviewResponse)(nil),        // 38: shortlink.GetLinkPreviewResponse
+	(*UpdateLinkRequest)(nil),             // 39: shortlink.UpdateLinkRequest
+	(*UpdateLinkResponse)(nil),            // 40: shortlink.UpdateLinkResponse
+	(*DomainInfo)(nil),                    // 41: shortlink.DomainInfo
+	(*AddDomainRequest)(nil),              // 42: shortlink.AddDomainRequest
+	(*AddDomainResponse)(nil),             // 43: shortlink.AddDomainResponse
+	(*VerifyDomainRequest)(nil),           // 44: shortlink.VerifyDomainRequest
+	(*VerifyDomainResponse)(nil),          // 45: shortlink.VerifyDomainResponse
+	(*ListDomainsRequest)(nil),            // 46: shortlink.ListDomainsRequest
+	(*ListDomainsResponse)(nil),           // 47: shortlink.ListDomainsResponse
+	(*SetDefaultDomainRequest)(nil),       // 48: shortlink.SetDefaultDomainRequest
+	(*SetDefaultDomainResponse)(nil),      // 49: shortlink.SetDefaultDomainResponse
+	(*DeleteDomainRequest)(nil),           // 50: shortlink.DeleteDomainRequest
+	(*DeleteDomainResponse)(nil),          // 51: shortlink.DeleteDomainResponse
+	(*LinkRef)(nil),                       // 52: shortlink.LinkRef
+	(*TagLinksRequest)(nil),               // 53: shortlink.TagLinksRequest
+	(*TagLinksResponse)(nil),              // 54: shortlink.TagLinksResponse
+	(*ListTagsRequest)(nil),               // 55: shortlink.ListTagsRequest
+	(*TagInfo)(nil),                       // 56: shortlink.TagInfo
+	(*ListTagsResponse)(nil),              // 57: shortlink.ListTagsResponse
+	(*ListLinksRequest)(nil),              // 58: shortlink.ListLinksRequest
+	(*ListLinksResponse)(nil),             // 59: shortlink.ListLinksResponse
+	(*ListLinksByTagRequest)(nil),         // 60: shortlink.ListLinksByTagRequest
+	(*LinkInfo)(nil),                      // 61: shortlink.LinkInfo
+	(*ListLinksByTagResponse)(nil),        // 62: shortlink.ListLinksByTagResponse
+	(*GetTagClicksRequest)(nil),           // 63: shortlink.GetTagClicksRequest
+	(*LinkClicks)(nil),                    // 64: shortlink.LinkClicks
+	(*GetTagClicksResponse)(nil),          // 65: shortlink.GetTagClicksResponse
+	(*ExportLinksRequest)(nil),            // 66: shortlink.ExportLinksRequest
+	(*ExportedLink)(nil),                  // 67: shortlink.ExportedLink
+	(*BatchJobInfo)(nil),                  // 68: shortlink.BatchJobInfo
+	(*SubmitBatchJobResponse)(nil),        // 69: shortlink.SubmitBatchJobResponse
+	(*GetBatchJobRequest)(nil),            // 70: shortlink.GetBatchJobRequest
+	(*GetBatchJobResponse)(nil),           // 71: shortlink.GetBatchJobResponse
+	(*CancelBatchJobRequest)(nil),         // 72: shortlink.CancelBatchJobRequest
+	(*CancelBatchJobResponse)(nil),        // 73: shortlink.CancelBatchJobResponse
+	(*ListTrashRequest)(nil),              // 74: shortlink.ListTrashRequest
+	(*TrashedLink)(nil),                   // 75: shortlink.TrashedLink
+	(*ListTrashResponse)(nil),             // 76: shortlink.ListTrashResponse
+	(*RestoreLinksRequest)(nil),           // 77: shortlink.RestoreLinksRequest
+	(*RestoreLinksResponse)(nil),          // 78: shortlink.RestoreLinksResponse
+	(*WebhookInfo)(nil),                   // 79: shortlink.WebhookInfo
+	(*CreateWebhookRequest)(nil),          // 80: shortlink.CreateWebhookRequest
+	(*CreateWebhookResponse)(nil),         // 81: shortlink.CreateWebhookResponse
+	(*ListWebhooksRequest)(nil),           // 82: shortlink.ListWebhooksRequest
+	(*ListWebhooksResponse)(nil),          // 83: shortlink.ListWebhooksResponse
+	(*DeleteWebhookRequest)(nil),          // 84: shortlink.DeleteWebhookRequest
+	(*DeleteWebhookResponse)(nil),         // 85: shortlink.DeleteWebhookResponse
+	(*WebhookDeliveryInfo)(nil),           // 86: shortlink.WebhookDeliveryInfo
+	(*ListWebhookDeliveriesRequest)(nil),  // 87: shortlink.ListWebhookDeliveriesRequest
+	(*ListWebhookDeliveriesResponse)(nil), // 88: shortlink.ListWebhookDeliveriesResponse
+	(*ReplayWebhookDeliveryRequest)(nil),  // 89: shortlink.ReplayWebhookDeliveryRequest
+	(*ReplayWebhookDeliveryResponse)(nil), // 90: shortlink.ReplayWebhookDeliveryResponse
+	nil,                                   // 91: shortlink.ResolveRequest.HeadersEntry
 }
 var file_proto_shortlinkpb_shortlink_proto_depIdxs = []int32{
-	5,  // 0: shortlink.TopResponse.top:type_name -> shortlink.ShortLinkItem
-	8,  // 1: shortlink.BatchShortenResponse.results:type_name -> shortlink.BatchShortenResult
-	0,  // 2: shortlink.ShortlinkService.ShortenURL:input_type -> shortlink.ShortenRequest
-	2,  // 3: shortlink.ShortlinkService.Redierect:input_type -> shortlink.ResolveRequest
-	4,  // 4: shortlink.ShortlinkService.GetTopLinks:input_type -> shortlink.TopRequest
-	7,  // 5: shortlink.ShortlinkService.BatchShortenURLs:input_type -> shortlink.BatchShortenRequest
-	10, // 6: shortlink.ShortlinkService.DeleteUserURLs:input_type -> shortlink.DeleteUserURLsRequest
-	1,  // 7: shortlink.ShortlinkService.ShortenURL:output_type -> shortlink.ShortenResponse
-	3,  // 8: shortlink.ShortlinkService.Redierect:output_type -> shortlink.ResolveResponse
-	6,  // 9: shortlink.ShortlinkService.GetTopLinks:output_type -> shortlink.TopResponse
-	9,  // 10: shortlink.ShortlinkService.BatchShortenURLs:output_type -> shortlink.BatchShortenResponse
-	11, // 11: shortlink.ShortlinkService.DeleteUserURLs:output_type -> shortlink.DeleteUserURLsResponse
-	7,  // [7:12] is the sub-list for method output_type
-	2,  // [2:7] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	0,  // 0: shortlink.ShortenRequest.rules:type_name -> shortlink.RedirectRule
+	1,  // 1: shortlink.ShortenRequest.variants:type_name -> shortlink.SplitVariant
+	91, // 2: shortlink.ResolveRequest.headers:type_name -> shortlink.ResolveRequest.HeadersEntry
+	7,  // 3: shortlink.TopResponse.top:type_name -> shortlink.ShortLinkItem
+	9,  // 4: shortlink.BatchShortenRequest.items:type_name -> shortlink.BatchItem
+	11, // 5: shortlink.BatchShortenResponse.results:type_name -> shortlink.BatchShortenResult
+	0,  // 6: shortlink.UpdateLinkRulesRequest.rules:type_name -> shortlink.RedirectRule
+	1,  // 7: shortlink.UpdateLinkVariantsRequest.variants:type_name -> shortlink.SplitVariant
+	20, // 8: shortlink.GetVariantStatsResponse.variants:type_name -> shortlink.VariantStat
+	23, // 9: shortlink.GetLinkStatsResponse.buckets:type_name -> shortlink.StatsBucket
+	26, // 10: shortlink.Breakdown.items:type_name -> shortlink.BreakdownItem
+	27, // 11: shortlink.GetLinkBreakdownResponse.breakdowns:type_name -> shortlink.Breakdown
+	29, // 12: shortlink.ListBotSignaturesResponse.signatures:type_name -> shortlink.BotSignature
+	29, // 13: shortlink.UpdateBotSignaturesResponse.signatures:type_name -> shortlink.BotSignature
+	37, // 14: shortlink.GetLinkPreviewResponse.preview:type_name -> shortlink.LinkPreview
+	41, // 15: shortlink.AddDomainResponse.domain:type_name -> shortlink.DomainInfo
+	41, // 16: shortlink.VerifyDomainResponse.domain:type_name -> shortlink.DomainInfo
+	41, // 17: shortlink.ListDomainsResponse.domains:type_name -> shortlink.DomainInfo
+	52, // 18: shortlink.TagLinksRequest.links:type_name -> shortlink.LinkRef
+	56, // 19: shortlink.ListTagsResponse.tags:type_name -> shortlink.TagInfo
+	61, // 20: shortlink.ListLinksResponse.links:type_name -> shortlink.LinkInfo
+	61, // 21: shortlink.ListLinksByTagResponse.links:type_name -> shortlink.LinkInfo
+	64, // 22: shortlink.GetTagClicksResponse.links:type_name -> shortlink.LinkClicks
+	68, // 23: shortlink.SubmitBatchJobResponse.job:type_name -> shortlink.BatchJobInfo
+	68, // 24: shortlink.GetBatchJobResponse.job:type_name -> shortlink.BatchJobInfo
+	11, // 25: shortlink.GetBatchJobResponse.results:type_name -> shortlink.BatchShortenResult
+	68, // 26: shortlink.CancelBatchJobResponse.job:type_name -> shortlink.BatchJobInfo
+	75, // 27: shortlink.ListTrashResponse.links:type_name -> shortlink.TrashedLink
+	52, // 28: shortlink.RestoreLinksRequest.links:type_name -> shortlink.LinkRef
+	79, // 29: shortlink.CreateWebhookResponse.webhook:type_name -> shortlink.WebhookInfo
+	79, // 30: shortlink.ListWebhooksResponse.webhooks:type_name -> shortlink.WebhookInfo
+	86, // 31: shortlink.ListWebhookDeliveriesResponse.deliveries:type_name -> shortlink.WebhookDeliveryInfo
+	86, // 32: shortlink.ReplayWebhookDeliveryResponse.delivery:type_name -> shortlink.WebhookDeliveryInfo
+	2,  // 33: shortlink.ShortlinkService.ShortenURL:input_type -> shortlink.ShortenRequest
+	4,  // 34: shortlink.ShortlinkService.Redierect:input_type -> shortlink.ResolveRequest
+	6,  // 35: shortlink.ShortlinkService.GetTopLinks:input_type -> shortlink.TopRequest
+	10, // 36: shortlink.ShortlinkService.BatchShortenURLs:input_type -> shortlink.BatchShortenRequest
+	10, // 37: shortlink.ShortlinkService.BatchShortenURLsStream:input_type -> shortlink.BatchShortenRequest
+	13, // 38: shortlink.ShortlinkService.DeleteUserURLs:input_type -> shortlink.DeleteUserURLsRequest
+	74, // 39: shortlink.ShortlinkService.ListTrash:input_type -> shortlink.ListTrashRequest
+	77, // 40: shortlink.ShortlinkService.RestoreLinks:input_type -> shortlink.RestoreLinksRequest
+	15, // 41: shortlink.ShortlinkService.UpdateLinkRules:input_type -> shortlink.UpdateLinkRulesRequest
+	17, // 42: shortlink.ShortlinkService.UpdateLinkVariants:input_type -> shortlink.UpdateLinkVariantsRequest
+	19, // 43: shortlink.ShortlinkService.GetVariantStats:input_type -> shortlink.GetVariantStatsRequest
+	22, // 44: shortlink.ShortlinkService.GetLinkStats:input_type -> shortlink.GetLinkStatsRequest
+	25, // 45: shortlink.ShortlinkService.GetLinkBreakdown:input_type -> shortlink.GetLinkBreakdownRequest
+	30, // 46: shortlink.ShortlinkService.ListBotSignatures:input_type -> shortlink.ListBotSignaturesRequest
+	32, // 47: shortlink.ShortlinkService.UpdateBotSignatures:input_type -> shortlink.UpdateBotSignaturesRequest
+	39, // 48: shortlink.ShortlinkService.UpdateLink:input_type -> shortlink.UpdateLinkRequest
+	58, // 49: shortlink.ShortlinkService.ListLinks:input_type -> shortlink.ListLinksRequest
+	34, // 50: shortlink.ShortlinkService.GetLink:input_type -> shortlink.GetLinkRequest
+	36, // 51: shortlink.ShortlinkService.GetLinkPreview:input_type -> shortlink.GetLinkPreviewRequest
+	42, // 52: shortlink.ShortlinkService.AddDomain:input_type -> shortlink.AddDomainRequest
+	44, // 53: shortlink.ShortlinkService.VerifyDomain:input_type -> shortlink.VerifyDomainRequest
+	46, // 54: shortlink.ShortlinkService.ListDomains:input_type -> shortlink.ListDomainsRequest
+	48, // 55: shortlink.ShortlinkService.SetDefaultDomain:input_type -> shortlink.SetDefaultDomainRequest
+	50, // 56: shortlink.ShortlinkService.DeleteDomain:input_type -> shortlink.DeleteDomainRequest
+	53, // 57: shortlink.ShortlinkService.TagLinks:input_type -> shortlink.TagLinksRequest
+	53, // 58: shortlink.ShortlinkService.UntagLinks:input_type -> shortlink.TagLinksRequest
+	55, // 59: shortlink.ShortlinkService.ListTags:input_type -> shortlink.ListTagsRequest
+	60, // 60: shortlink.ShortlinkService.ListLinksByTag:input_type -> shortlink.ListLinksByTagRequest
+	63, // 61: shortlink.ShortlinkService.GetTagClicks:input_type -> shortlink.GetTagClicksRequest
+	66, // 62: shortlink.ShortlinkService.ExportLinks:input_type -> shortlink.ExportLinksRequest
+	10, // 63: shortlink.ShortlinkService.SubmitBatchJob:input_type -> shortlink.BatchShortenRequest
+	70, // 64: shortlink.ShortlinkService.GetBatchJob:input_type -> shortlink.GetBatchJobRequest
+	72, // 65: shortlink.ShortlinkService.CancelBatchJob:input_type -> shortlink.CancelBatchJobRequest
+	80, // 66: shortlink.ShortlinkService.CreateWebhook:input_type -> shortlink.CreateWebhookRequest
+	82, // 67: shortlink.ShortlinkService.ListWebhooks:input_type -> shortlink.ListWebhooksRequest
+	84, // 68: shortlink.ShortlinkService.DeleteWebhook:input_type -> shortlink.DeleteWebhookRequest
+	87, // 69: shortlink.ShortlinkService.ListWebhookDeliveries:input_type -> shortlink.ListWebhookDeliveriesRequest
+	89, // 70: shortlink.ShortlinkService.ReplayWebhookDelivery:input_type -> shortlink.ReplayWebhookDeliveryRequest
+	3,  // 71: shortlink.ShortlinkService.ShortenURL:output_type -> shortlink.ShortenResponse
+	5,  // 72: shortlink.ShortlinkService.Redierect:output_type -> shortlink.ResolveResponse
+	8,  // 73: shortlink.ShortlinkService.GetTopLinks:output_type -> shortlink.TopResponse
+	12, // 74: shortlink.ShortlinkService.BatchShortenURLs:output_type -> shortlink.BatchShortenResponse
+	11, // 75: shortlink.ShortlinkService.BatchShortenURLsStream:output_type -> shortlink.BatchShortenResult
+	14, // 76: shortlink.ShortlinkService.DeleteUserURLs:output_type -> shortlink.DeleteUserURLsResponse
+	76, // 77: shortlink.ShortlinkService.ListTrash:output_type -> shortlink.ListTrashResponse
+	78, // 78: shortlink.ShortlinkService.RestoreLinks:output_type -> shortlink.RestoreLinksResponse
+	16, // 79: shortlink.ShortlinkService.UpdateLinkRules:output_type -> shortlink.UpdateLinkRulesResponse
+	18, // 80: shortlink.ShortlinkService.UpdateLinkVariants:output_type -> shortlink.UpdateLinkVariantsResponse
+	21, // 81: shortlink.ShortlinkService.GetVariantStats:output_type -> shortlink.GetVariantStatsResponse
+	24, // 82: shortlink.ShortlinkService.GetLinkStats:output_type -> shortlink.GetLinkStatsResponse
+	28, // 83: shortlink.ShortlinkService.GetLinkBreakdown:output_type -> shortlink.GetLinkBreakdownResponse
+	31, // 84: shortlink.ShortlinkService.ListBotSignatures:output_type -> shortlink.ListBotSignaturesResponse
+	33, // 85: shortlink.ShortlinkService.UpdateBotSignatures:output_type -> shortlink.UpdateBotSignaturesResponse
+	40, // 86: shortlink.ShortlinkService.UpdateLink:output_type -> shortlink.UpdateLinkResponse
+	59, // 87: shortlink.ShortlinkService.ListLinks:output_type -> shortlink.ListLinksResponse
+	35, // 88: shortlink.ShortlinkService.GetLink:output_type -> shortlink.GetLinkResponse
+	38, // 89: shortlink.ShortlinkService.GetLinkPreview:output_type -> shortlink.GetLinkPreviewResponse
+	43, // 90: shortlink.ShortlinkService.AddDomain:output_type -> shortlink.AddDomainResponse
+	45, // 91: shortlink.ShortlinkService.VerifyDomain:output_type -> shortlink.VerifyDomainResponse
+	47, // 92: shortlink.ShortlinkService.ListDomains:output_type -> shortlink.ListDomainsResponse
+	49, // 93: shortlink.ShortlinkService.SetDefaultDomain:output_type -> shortlink.SetDefaultDomainResponse
+	51, // 94: shortlink.ShortlinkService.DeleteDomain:output_type -> shortlink.DeleteDomainResponse
+	54, // 95: shortlink.ShortlinkService.TagLinks:output_type -> shortlink.TagLinksResponse
+	54, // 96: shortlink.ShortlinkService.UntagLinks:output_type -> shortlink.TagLinksResponse
+	57, // 97: shortlink.ShortlinkService.ListTags:output_type -> shortlink.ListTagsResponse
+	62, // 98: shortlink.ShortlinkService.ListLinksByTag:output_type -> shortlink.ListLinksByTagResponse
+	65, // 99: shortlink.ShortlinkService.GetTagClicks:output_type -> shortlink.GetTagClicksResponse
+	67, // 100: shortlink.ShortlinkService.ExportLinks:output_type -> shortlink.ExportedLink
+	69, // 101: shortlink.ShortlinkService.SubmitBatchJob:output_type -> shortlink.SubmitBatchJobResponse
+	71, // 102: shortlink.ShortlinkService.GetBatchJob:output_type -> shortlink.GetBatchJobResponse
+	73, // 103: shortlink.ShortlinkService.CancelBatchJob:output_type -> shortlink.CancelBatchJobResponse
+	81, // 104: shortlink.ShortlinkService.CreateWebhook:output_type -> shortlink.CreateWebhookResponse
+	83, // 105: shortlink.ShortlinkService.ListWebhooks:output_type -> shortlink.ListWebhooksResponse
+	85, // 106: shortlink.ShortlinkService.DeleteWebhook:output_type -> shortlink.DeleteWebhookResponse
+	88, // 107: shortlink.ShortlinkService.ListWebhookDeliveries:output_type -> shortlink.ListWebhookDeliveriesResponse
+	90, // 108: shortlink.ShortlinkService.ReplayWebhookDelivery:output_type -> shortlink.ReplayWebhookDeliveryResponse
+	71, // [71:109] is the sub-list for method output_type
+	33, // [33:71] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_proto_shortlinkpb_shortlink_proto_init() }
@@ -743,7 +6642,7 @@ func file_proto_shortlinkpb_shortlink_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_shortlinkpb_shortlink_proto_rawDesc), len(file_proto_shortlinkpb_shortlink_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   92,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
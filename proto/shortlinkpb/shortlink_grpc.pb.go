@@ -19,11 +19,44 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ShortlinkService_ShortenURL_FullMethodName       = "/shortlink.ShortlinkService/ShortenURL"
-	ShortlinkService_Redierect_FullMethodName        = "/shortlink.ShortlinkService/Redierect"
-	ShortlinkService_GetTopLinks_FullMethodName      = "/shortlink.ShortlinkService/GetTopLinks"
-	ShortlinkService_BatchShortenURLs_FullMethodName = "/shortlink.ShortlinkService/BatchShortenURLs"
-	ShortlinkService_DeleteUserURLs_FullMethodName   = "/shortlink.ShortlinkService/DeleteUserURLs"
+	ShortlinkService_ShortenURL_FullMethodName             = "/shortlink.ShortlinkService/ShortenURL"
+	ShortlinkService_Redierect_FullMethodName              = "/shortlink.ShortlinkService/Redierect"
+	ShortlinkService_GetTopLinks_FullMethodName            = "/shortlink.ShortlinkService/GetTopLinks"
+	ShortlinkService_BatchShortenURLs_FullMethodName       = "/shortlink.ShortlinkService/BatchShortenURLs"
+	ShortlinkService_BatchShortenURLsStream_FullMethodName = "/shortlink.ShortlinkService/BatchShortenURLsStream"
+	ShortlinkService_DeleteUserURLs_FullMethodName         = "/shortlink.ShortlinkService/DeleteUserURLs"
+	ShortlinkService_ListTrash_FullMethodName              = "/shortlink.ShortlinkService/ListTrash"
+	ShortlinkService_RestoreLinks_FullMethodName           = "/shortlink.ShortlinkService/RestoreLinks"
+	ShortlinkService_UpdateLinkRules_FullMethodName        = "/shortlink.ShortlinkService/UpdateLinkRules"
+	ShortlinkService_UpdateLinkVariants_FullMethodName     = "/shortlink.ShortlinkService/UpdateLinkVariants"
+	ShortlinkService_GetVariantStats_FullMethodName        = "/shortlink.ShortlinkService/GetVariantStats"
+	ShortlinkService_GetLinkStats_FullMethodName           = "/shortlink.ShortlinkService/GetLinkStats"
+	ShortlinkService_GetLinkBreakdown_FullMethodName       = "/shortlink.ShortlinkService/GetLinkBreakdown"
+	ShortlinkService_ListBotSignatures_FullMethodName      = "/shortlink.ShortlinkService/ListBotSignatures"
+	ShortlinkService_UpdateBotSignatures_FullMethodName    = "/shortlink.ShortlinkService/UpdateBotSignatures"
+	ShortlinkService_UpdateLink_FullMethodName             = "/shortlink.ShortlinkService/UpdateLink"
+	ShortlinkService_ListLinks_FullMethodName              = "/shortlink.ShortlinkService/ListLinks"
+	ShortlinkService_GetLink_FullMethodName                = "/shortlink.ShortlinkService/GetLink"
+	ShortlinkService_GetLinkPreview_FullMethodName         = "/shortlink.ShortlinkService/GetLinkPreview"
+	ShortlinkService_AddDomain_FullMethodName              = "/shortlink.ShortlinkService/AddDomain"
+	ShortlinkService_VerifyDomain_FullMethodName           = "/shortlink.ShortlinkService/VerifyDomain"
+	ShortlinkService_ListDomains_FullMethodName            = "/shortlink.ShortlinkService/ListDomains"
+	ShortlinkService_SetDefaultDomain_FullMethodName       = "/shortlink.ShortlinkService/SetDefaultDomain"
+	ShortlinkService_DeleteDomain_FullMethodName           = "/shortlink.ShortlinkService/DeleteDomain"
+	ShortlinkService_TagLinks_FullMethodName               = "/shortlink.ShortlinkService/TagLinks"
+	ShortlinkService_UntagLinks_FullMethodName             = "/shortlink.ShortlinkService/UntagLinks"
+	ShortlinkService_ListTags_FullMethodName               = "/shortlink.ShortlinkService/ListTags"
+	ShortlinkService_ListLinksByTag_FullMethodName         = "/shortlink.ShortlinkService/ListLinksByTag"
+	ShortlinkService_GetTagClicks_FullMethodName           = "/shortlink.ShortlinkService/GetTagClicks"
+	ShortlinkService_ExportLinks_FullMethodName            = "/shortlink.ShortlinkService/ExportLinks"
+	ShortlinkService_SubmitBatchJob_FullMethodName         = "/shortlink.ShortlinkService/SubmitBatchJob"
+	ShortlinkService_GetBatchJob_FullMethodName            = "/shortlink.ShortlinkService/GetBatchJob"
+	ShortlinkService_CancelBatchJob_FullMethodName         = "/shortlink.ShortlinkService/CancelBatchJob"
+	ShortlinkService_CreateWebhook_FullMethodName          = "/shortlink.ShortlinkService/CreateWebhook"
+	ShortlinkService_ListWebhooks_FullMethodName           = "/shortlink.ShortlinkService/ListWebhooks"
+	ShortlinkService_DeleteWebhook_FullMethodName          = "/shortlink.ShortlinkService/DeleteWebhook"
+	ShortlinkService_ListWebhookDeliveries_FullMethodName  = "/shortlink.ShortlinkService/ListWebhookDeliveries"
+	ShortlinkService_ReplayWebhookDelivery_FullMethodName  = "/shortlink.ShortlinkService/ReplayWebhookDelivery"
 )
 
 // ShortlinkServiceClient is the client API for ShortlinkService service.
@@ -38,8 +71,74 @@ type ShortlinkServiceClient interface {
 	GetTopLinks(ctx context.Context, in *TopRequest, opts ...grpc.CallOption) (*TopResponse, error)
 	// 批量生成短链接
 	BatchShortenURLs(ctx context.Context, in *BatchShortenRequest, opts ...grpc.CallOption) (*BatchShortenResponse, error)
-	// 删除用户的所有短链接
+	// 批量生成短链接，每完成一项立即返回（按完成顺序，用 index 对应请求）
+	BatchShortenURLsStream(ctx context.Context, in *BatchShortenRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BatchShortenResult], error)
+	// 将用户的所有短链接移入回收站
 	DeleteUserURLs(ctx context.Context, in *DeleteUserURLsRequest, opts ...grpc.CallOption) (*DeleteUserURLsResponse, error)
+	// 查询回收站中的短链接
+	ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error)
+	// 将短链接移出回收站
+	RestoreLinks(ctx context.Context, in *RestoreLinksRequest, opts ...grpc.CallOption) (*RestoreLinksResponse, error)
+	// 更新短链接的跳转规则
+	UpdateLinkRules(ctx context.Context, in *UpdateLinkRulesRequest, opts ...grpc.CallOption) (*UpdateLinkRulesResponse, error)
+	// 更新短链接的 A/B 分流权重
+	UpdateLinkVariants(ctx context.Context, in *UpdateLinkVariantsRequest, opts ...grpc.CallOption) (*UpdateLinkVariantsResponse, error)
+	// 查询各 A/B 分组的点击量
+	GetVariantStats(ctx context.Context, in *GetVariantStatsRequest, opts ...grpc.CallOption) (*GetVariantStatsResponse, error)
+	// 按分钟、小时或天查询短链接的点击趋势
+	GetLinkStats(ctx context.Context, in *GetLinkStatsRequest, opts ...grpc.CallOption) (*GetLinkStatsResponse, error)
+	// 按来源域名、浏览器、操作系统、设备和国家查询短链接的点击分布
+	GetLinkBreakdown(ctx context.Context, in *GetLinkBreakdownRequest, opts ...grpc.CallOption) (*GetLinkBreakdownResponse, error)
+	// 查询机器人 User-Agent 特征
+	ListBotSignatures(ctx context.Context, in *ListBotSignaturesRequest, opts ...grpc.CallOption) (*ListBotSignaturesResponse, error)
+	// 增加、删除或停用机器人 User-Agent 特征
+	UpdateBotSignatures(ctx context.Context, in *UpdateBotSignaturesRequest, opts ...grpc.CallOption) (*UpdateBotSignaturesResponse, error)
+	// 修改短链接的目标地址
+	UpdateLink(ctx context.Context, in *UpdateLinkRequest, opts ...grpc.CallOption) (*UpdateLinkResponse, error)
+	// 分页查询个人或工作区的短链接
+	ListLinks(ctx context.Context, in *ListLinksRequest, opts ...grpc.CallOption) (*ListLinksResponse, error)
+	// 查询短链接是否存在及其完整地址，不计入点击
+	GetLink(ctx context.Context, in *GetLinkRequest, opts ...grpc.CallOption) (*GetLinkResponse, error)
+	// 获取短链接目标页面的预览信息
+	GetLinkPreview(ctx context.Context, in *GetLinkPreviewRequest, opts ...grpc.CallOption) (*GetLinkPreviewResponse, error)
+	// 登记自定义短链接域名
+	AddDomain(ctx context.Context, in *AddDomainRequest, opts ...grpc.CallOption) (*AddDomainResponse, error)
+	// 验证域名（DNS TXT 记录或 HTTP 验证文件）
+	VerifyDomain(ctx context.Context, in *VerifyDomainRequest, opts ...grpc.CallOption) (*VerifyDomainResponse, error)
+	// 查询用户的域名
+	ListDomains(ctx context.Context, in *ListDomainsRequest, opts ...grpc.CallOption) (*ListDomainsResponse, error)
+	// 设置创建短链接时的默认域名
+	SetDefaultDomain(ctx context.Context, in *SetDefaultDomainRequest, opts ...grpc.CallOption) (*SetDefaultDomainResponse, error)
+	// 删除域名（域名下没有短链接时才允许）
+	DeleteDomain(ctx context.Context, in *DeleteDomainRequest, opts ...grpc.CallOption) (*DeleteDomainResponse, error)
+	// 批量为短链接打标签或加入活动
+	TagLinks(ctx context.Context, in *TagLinksRequest, opts ...grpc.CallOption) (*TagLinksResponse, error)
+	// 批量移除短链接的标签或活动
+	UntagLinks(ctx context.Context, in *TagLinksRequest, opts ...grpc.CallOption) (*TagLinksResponse, error)
+	// 查询用户的标签和活动
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	// 查询标签或活动下的短链接
+	ListLinksByTag(ctx context.Context, in *ListLinksByTagRequest, opts ...grpc.CallOption) (*ListLinksByTagResponse, error)
+	// 查询标签或活动的汇总点击量
+	GetTagClicks(ctx context.Context, in *GetTagClicksRequest, opts ...grpc.CallOption) (*GetTagClicksResponse, error)
+	// 分页流式导出用户的所有短链接及点击量
+	ExportLinks(ctx context.Context, in *ExportLinksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportedLink], error)
+	// 提交异步批量生成任务，立即返回任务ID，由后台处理
+	SubmitBatchJob(ctx context.Context, in *BatchShortenRequest, opts ...grpc.CallOption) (*SubmitBatchJobResponse, error)
+	// 查询异步批量任务的进度和已处理的结果
+	GetBatchJob(ctx context.Context, in *GetBatchJobRequest, opts ...grpc.CallOption) (*GetBatchJobResponse, error)
+	// 取消异步批量任务，已处理的结果保留
+	CancelBatchJob(ctx context.Context, in *CancelBatchJobRequest, opts ...grpc.CallOption) (*CancelBatchJobResponse, error)
+	// 登记 Webhook，订阅短链接事件
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error)
+	// 查询用户的 Webhook
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	// 删除 Webhook，尚未投递的事件不再投递
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error)
+	// 分页查询 Webhook 的投递日志
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+	// 重新投递一次事件
+	ReplayWebhookDelivery(ctx context.Context, in *ReplayWebhookDeliveryRequest, opts ...grpc.CallOption) (*ReplayWebhookDeliveryResponse, error)
 }
 
 type shortlinkServiceClient struct {
@@ -90,6 +189,25 @@ func (c *shortlinkServiceClient) BatchShortenURLs(ctx context.Context, in *Batch
 	return out, nil
 }
 
+func (c *shortlinkServiceClient) BatchShortenURLsStream(ctx context.Context, in *BatchShortenRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BatchShortenResult], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ShortlinkService_ServiceDesc.Streams[0], ShortlinkService_BatchShortenURLsStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BatchShortenRequest, BatchShortenResult]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShortlinkService_BatchShortenURLsStreamClient = grpc.ServerStreamingClient[BatchShortenResult]
+
 func (c *shortlinkServiceClient) DeleteUserURLs(ctx context.Context, in *DeleteUserURLsRequest, opts ...grpc.CallOption) (*DeleteUserURLsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DeleteUserURLsResponse)
@@ -97,155 +215,1229 @@ func (c *shortlinkServiceClient) DeleteUserURLs(ctx context.Context, in *DeleteU
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTrashResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_ListTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) RestoreLinks(ctx context.Context, in *RestoreLinksRequest, opts ...grpc.CallOption) (*RestoreLinksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreLinksResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_RestoreLinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) UpdateLinkRules(ctx context.Context, in *UpdateLinkRulesRequest, opts ...grpc.CallOption) (*UpdateLinkRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateLinkRulesResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_UpdateLinkRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) UpdateLinkVariants(ctx context.Context, in *UpdateLinkVariantsRequest, opts ...grpc.CallOption) (*UpdateLinkVariantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateLinkVariantsResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_UpdateLinkVariants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) GetVariantStats(ctx context.Context, in *GetVariantStatsRequest, opts ...grpc.CallOption) (*GetVariantStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVariantStatsResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_GetVariantStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) GetLinkStats(ctx context.Context, in *GetLinkStatsRequest, opts ...grpc.CallOption) (*GetLinkStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLinkStatsResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_GetLinkStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) GetLinkBreakdown(ctx context.Context, in *GetLinkBreakdownRequest, opts ...grpc.CallOption) (*GetLinkBreakdownResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLinkBreakdownResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_GetLinkBreakdown_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) ListBotSignatures(ctx context.Context, in *ListBotSignaturesRequest, opts ...grpc.CallOption) (*ListBotSignaturesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBotSignaturesResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_ListBotSignatures_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) UpdateBotSignatures(ctx context.Context, in *UpdateBotSignaturesRequest, opts ...grpc.CallOption) (*UpdateBotSignaturesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateBotSignaturesResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_UpdateBotSignatures_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) UpdateLink(ctx context.Context, in *UpdateLinkRequest, opts ...grpc.CallOption) (*UpdateLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateLinkResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_UpdateLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) ListLinks(ctx context.Context, in *ListLinksRequest, opts ...grpc.CallOption) (*ListLinksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLinksResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_ListLinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) GetLink(ctx context.Context, in *GetLinkRequest, opts ...grpc.CallOption) (*GetLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLinkResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_GetLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) GetLinkPreview(ctx context.Context, in *GetLinkPreviewRequest, opts ...grpc.CallOption) (*GetLinkPreviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLinkPreviewResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_GetLinkPreview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) AddDomain(ctx context.Context, in *AddDomainRequest, opts ...grpc.CallOption) (*AddDomainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddDomainResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_AddDomain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) VerifyDomain(ctx context.Context, in *VerifyDomainRequest, opts ...grpc.CallOption) (*VerifyDomainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyDomainResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_VerifyDomain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) ListDomains(ctx context.Context, in *ListDomainsRequest, opts ...grpc.CallOption) (*ListDomainsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDomainsResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_ListDomains_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) SetDefaultDomain(ctx context.Context, in *SetDefaultDomainRequest, opts ...grpc.CallOption) (*SetDefaultDomainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetDefaultDomainResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_SetDefaultDomain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) DeleteDomain(ctx context.Context, in *DeleteDomainRequest, opts ...grpc.CallOption) (*DeleteDomainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteDomainResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_DeleteDomain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) TagLinks(ctx context.Context, in *TagLinksRequest, opts ...grpc.CallOption) (*TagLinksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TagLinksResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_TagLinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) UntagLinks(ctx context.Context, in *TagLinksRequest, opts ...grpc.CallOption) (*TagLinksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TagLinksResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_UntagLinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_ListTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) ListLinksByTag(ctx context.Context, in *ListLinksByTagRequest, opts ...grpc.CallOption) (*ListLinksByTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLinksByTagResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_ListLinksByTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) GetTagClicks(ctx context.Context, in *GetTagClicksRequest, opts ...grpc.CallOption) (*GetTagClicksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTagClicksResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_GetTagClicks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) ExportLinks(ctx context.Context, in *ExportLinksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportedLink], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ShortlinkService_ServiceDesc.Streams[1], ShortlinkService_ExportLinks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportLinksRequest, ExportedLink]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShortlinkService_ExportLinksClient = grpc.ServerStreamingClient[ExportedLink]
+
+func (c *shortlinkServiceClient) SubmitBatchJob(ctx context.Context, in *BatchShortenRequest, opts ...grpc.CallOption) (*SubmitBatchJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitBatchJobResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_SubmitBatchJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) GetBatchJob(ctx context.Context, in *GetBatchJobRequest, opts ...grpc.CallOption) (*GetBatchJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBatchJobResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_GetBatchJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) CancelBatchJob(ctx context.Context, in *CancelBatchJobRequest, opts ...grpc.CallOption) (*CancelBatchJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelBatchJobResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_CancelBatchJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateWebhookResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_CreateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_ListWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteWebhookResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_DeleteWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_ListWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortlinkServiceClient) ReplayWebhookDelivery(ctx context.Context, in *ReplayWebhookDeliveryRequest, opts ...grpc.CallOption) (*ReplayWebhookDeliveryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplayWebhookDeliveryResponse)
+	err := c.cc.Invoke(ctx, ShortlinkService_ReplayWebhookDelivery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ShortlinkServiceServer is the server API for ShortlinkService service.
+// All implementations must embed UnimplementedShortlinkServiceServer
+// for forward compatibility.
+type ShortlinkServiceServer interface {
+	// 长链接 → 短链接
+	ShortenURL(context.Context, *ShortenRequest) (*ShortenResponse, error)
+	// 短链接 → 长链接
+	Redierect(context.Context, *ResolveRequest) (*ResolveResponse, error)
+	// 获取前N条热点link
+	GetTopLinks(context.Context, *TopRequest) (*TopResponse, error)
+	// 批量生成短链接
+	BatchShortenURLs(context.Context, *BatchShortenRequest) (*BatchShortenResponse, error)
+	// 批量生成短链接，每完成一项立即返回（按完成顺序，用 index 对应请求）
+	BatchShortenURLsStream(*BatchShortenRequest, grpc.ServerStreamingServer[BatchShortenResult]) error
+	// 将用户的所有短链接移入回收站
+	DeleteUserURLs(context.Context, *DeleteUserURLsRequest) (*DeleteUserURLsResponse, error)
+	// 查询回收站中的短链接
+	ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error)
+	// 将短链接移出回收站
+	RestoreLinks(context.Context, *RestoreLinksRequest) (*RestoreLinksResponse, error)
+	// 更新短链接的跳转规则
+	UpdateLinkRules(context.Context, *UpdateLinkRulesRequest) (*UpdateLinkRulesResponse, error)
+	// 更新短链接的 A/B 分流权重
+	UpdateLinkVariants(context.Context, *UpdateLinkVariantsRequest) (*UpdateLinkVariantsResponse, error)
+	// 查询各 A/B 分组的点击量
+	GetVariantStats(context.Context, *GetVariantStatsRequest) (*GetVariantStatsResponse, error)
+	// 按分钟、小时或天查询短链接的点击趋势
+	GetLinkStats(context.Context, *GetLinkStatsRequest) (*GetLinkStatsResponse, error)
+	// 按来源域名、浏览器、操作系统、设备和国家查询短链接的点击分布
+	GetLinkBreakdown(context.Context, *GetLinkBreakdownRequest) (*GetLinkBreakdownResponse, error)
+	// 查询机器人 User-Agent 特征
+	ListBotSignatures(context.Context, *ListBotSignaturesRequest) (*ListBotSignaturesResponse, error)
+	// 增加、删除或停用机器人 User-Agent 特征
+	UpdateBotSignatures(context.Context, *UpdateBotSignaturesRequest) (*UpdateBotSignaturesResponse, error)
+	// 修改短链接的目标地址
+	UpdateLink(context.Context, *UpdateLinkRequest) (*UpdateLinkResponse, error)
+	// 分页查询个人或工作区的短链接
+	ListLinks(context.Context, *ListLinksRequest) (*ListLinksResponse, error)
+	// 查询短链接是否存在及其完整地址，不计入点击
+	GetLink(context.Context, *GetLinkRequest) (*GetLinkResponse, error)
+	// 获取短链接目标页面的预览信息
+	GetLinkPreview(context.Context, *GetLinkPreviewRequest) (*GetLinkPreviewResponse, error)
+	// 登记自定义短链接域名
+	AddDomain(context.Context, *AddDomainRequest) (*AddDomainResponse, error)
+	// 验证域名（DNS TXT 记录或 HTTP 验证文件）
+	VerifyDomain(context.Context, *VerifyDomainRequest) (*VerifyDomainResponse, error)
+	// 查询用户的域名
+	ListDomains(context.Context, *ListDomainsRequest) (*ListDomainsResponse, error)
+	// 设置创建短链接时的默认域名
+	SetDefaultDomain(context.Context, *SetDefaultDomainRequest) (*SetDefaultDomainResponse, error)
+	// 删除域名（域名下没有短链接时才允许）
+	DeleteDomain(context.Context, *DeleteDomainRequest) (*DeleteDomainResponse, error)
+	// 批量为短链接打标签或加入活动
+	TagLinks(context.Context, *TagLinksRequest) (*TagLinksResponse, error)
+	// 批量移除短链接的标签或活动
+	UntagLinks(context.Context, *TagLinksRequest) (*TagLinksResponse, error)
+	// 查询用户的标签和活动
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	// 查询标签或活动下的短链接
+	ListLinksByTag(context.Context, *ListLinksByTagRequest) (*ListLinksByTagResponse, error)
+	// 查询标签或活动的汇总点击量
+	GetTagClicks(context.Context, *GetTagClicksRequest) (*GetTagClicksResponse, error)
+	// 分页流式导出用户的所有短链接及点击量
+	ExportLinks(*ExportLinksRequest, grpc.ServerStreamingServer[ExportedLink]) error
+	// 提交异步批量生成任务，立即返回任务ID，由后台处理
+	SubmitBatchJob(context.Context, *BatchShortenRequest) (*SubmitBatchJobResponse, error)
+	// 查询异步批量任务的进度和已处理的结果
+	GetBatchJob(context.Context, *GetBatchJobRequest) (*GetBatchJobResponse, error)
+	// 取消异步批量任务，已处理的结果保留
+	CancelBatchJob(context.Context, *CancelBatchJobRequest) (*CancelBatchJobResponse, error)
+	// 登记 Webhook，订阅短链接事件
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error)
+	// 查询用户的 Webhook
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	// 删除 Webhook，尚未投递的事件不再投递
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	// 分页查询 Webhook 的投递日志
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	// 重新投递一次事件
+	ReplayWebhookDelivery(context.Context, *ReplayWebhookDeliveryRequest) (*ReplayWebhookDeliveryResponse, error)
+	mustEmbedUnimplementedShortlinkServiceServer()
+}
+
+// UnimplementedShortlinkServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedShortlinkServiceServer struct{}
+
+func (UnimplementedShortlinkServiceServer) ShortenURL(context.Context, *ShortenRequest) (*ShortenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShortenURL not implemented")
+}
+func (UnimplementedShortlinkServiceServer) Redierect(context.Context, *ResolveRequest) (*ResolveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Redierect not implemented")
+}
+func (UnimplementedShortlinkServiceServer) GetTopLinks(context.Context, *TopRequest) (*TopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTopLinks not implemented")
+}
+func (UnimplementedShortlinkServiceServer) BatchShortenURLs(context.Context, *BatchShortenRequest) (*BatchShortenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchShortenURLs not implemented")
+}
+func (UnimplementedShortlinkServiceServer) BatchShortenURLsStream(*BatchShortenRequest, grpc.ServerStreamingServer[BatchShortenResult]) error {
+	return status.Errorf(codes.Unimplemented, "method BatchShortenURLsStream not implemented")
+}
+func (UnimplementedShortlinkServiceServer) DeleteUserURLs(context.Context, *DeleteUserURLsRequest) (*DeleteUserURLsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserURLs not implemented")
+}
+func (UnimplementedShortlinkServiceServer) ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (UnimplementedShortlinkServiceServer) RestoreLinks(context.Context, *RestoreLinksRequest) (*RestoreLinksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreLinks not implemented")
+}
+func (UnimplementedShortlinkServiceServer) UpdateLinkRules(context.Context, *UpdateLinkRulesRequest) (*UpdateLinkRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLinkRules not implemented")
+}
+func (UnimplementedShortlinkServiceServer) UpdateLinkVariants(context.Context, *UpdateLinkVariantsRequest) (*UpdateLinkVariantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLinkVariants not implemented")
+}
+func (UnimplementedShortlinkServiceServer) GetVariantStats(context.Context, *GetVariantStatsRequest) (*GetVariantStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVariantStats not implemented")
+}
+func (UnimplementedShortlinkServiceServer) GetLinkStats(context.Context, *GetLinkStatsRequest) (*GetLinkStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLinkStats not implemented")
+}
+func (UnimplementedShortlinkServiceServer) GetLinkBreakdown(context.Context, *GetLinkBreakdownRequest) (*GetLinkBreakdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLinkBreakdown not implemented")
+}
+func (UnimplementedShortlinkServiceServer) ListBotSignatures(context.Context, *ListBotSignaturesRequest) (*ListBotSignaturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBotSignatures not implemented")
+}
+func (UnimplementedShortlinkServiceServer) UpdateBotSignatures(context.Context, *UpdateBotSignaturesRequest) (*UpdateBotSignaturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBotSignatures not implemented")
+}
+func (UnimplementedShortlinkServiceServer) UpdateLink(context.Context, *UpdateLinkRequest) (*UpdateLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLink not implemented")
+}
+func (UnimplementedShortlinkServiceServer) ListLinks(context.Context, *ListLinksRequest) (*ListLinksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLinks not implemented")
+}
+func (UnimplementedShortlinkServiceServer) GetLink(context.Context, *GetLinkRequest) (*GetLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLink not implemented")
+}
+func (UnimplementedShortlinkServiceServer) GetLinkPreview(context.Context, *GetLinkPreviewRequest) (*GetLinkPreviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLinkPreview not implemented")
+}
+func (UnimplementedShortlinkServiceServer) AddDomain(context.Context, *AddDomainRequest) (*AddDomainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDomain not implemented")
+}
+func (UnimplementedShortlinkServiceServer) VerifyDomain(context.Context, *VerifyDomainRequest) (*VerifyDomainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyDomain not implemented")
+}
+func (UnimplementedShortlinkServiceServer) ListDomains(context.Context, *ListDomainsRequest) (*ListDomainsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDomains not implemented")
+}
+func (UnimplementedShortlinkServiceServer) SetDefaultDomain(context.Context, *SetDefaultDomainRequest) (*SetDefaultDomainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDefaultDomain not implemented")
+}
+func (UnimplementedShortlinkServiceServer) DeleteDomain(context.Context, *DeleteDomainRequest) (*DeleteDomainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDomain not implemented")
+}
+func (UnimplementedShortlinkServiceServer) TagLinks(context.Context, *TagLinksRequest) (*TagLinksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TagLinks not implemented")
+}
+func (UnimplementedShortlinkServiceServer) UntagLinks(context.Context, *TagLinksRequest) (*TagLinksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UntagLinks not implemented")
+}
+func (UnimplementedShortlinkServiceServer) ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedShortlinkServiceServer) ListLinksByTag(context.Context, *ListLinksByTagRequest) (*ListLinksByTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLinksByTag not implemented")
+}
+func (UnimplementedShortlinkServiceServer) GetTagClicks(context.Context, *GetTagClicksRequest) (*GetTagClicksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTagClicks not implemented")
+}
+func (UnimplementedShortlinkServiceServer) ExportLinks(*ExportLinksRequest, grpc.ServerStreamingServer[ExportedLink]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportLinks not implemented")
+}
+func (UnimplementedShortlinkServiceServer) SubmitBatchJob(context.Context, *BatchShortenRequest) (*SubmitBatchJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitBatchJob not implemented")
+}
+func (UnimplementedShortlinkServiceServer) GetBatchJob(context.Context, *GetBatchJobRequest) (*GetBatchJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBatchJob not implemented")
+}
+func (UnimplementedShortlinkServiceServer) CancelBatchJob(context.Context, *CancelBatchJobRequest) (*CancelBatchJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelBatchJob not implemented")
+}
+func (UnimplementedShortlinkServiceServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedShortlinkServiceServer) ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedShortlinkServiceServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedShortlinkServiceServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedShortlinkServiceServer) ReplayWebhookDelivery(context.Context, *ReplayWebhookDeliveryRequest) (*ReplayWebhookDeliveryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayWebhookDelivery not implemented")
+}
+func (UnimplementedShortlinkServiceServer) mustEmbedUnimplementedShortlinkServiceServer() {}
+func (UnimplementedShortlinkServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeShortlinkServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ShortlinkServiceServer will
+// result in compilation errors.
+type UnsafeShortlinkServiceServer interface {
+	mustEmbedUnimplementedShortlinkServiceServer()
+}
+
+func RegisterShortlinkServiceServer(s grpc.ServiceRegistrar, srv ShortlinkServiceServer) {
+	// If the following call pancis, it indicates UnimplementedShortlinkServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ShortlinkService_ServiceDesc, srv)
+}
+
+func _ShortlinkService_ShortenURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShortenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).ShortenURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_ShortenURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).ShortenURL(ctx, req.(*ShortenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortlinkService_Redierect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).Redierect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_Redierect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).Redierect(ctx, req.(*ResolveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortlinkService_GetTopLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).GetTopLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_GetTopLinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).GetTopLinks(ctx, req.(*TopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortlinkService_BatchShortenURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchShortenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).BatchShortenURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_BatchShortenURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).BatchShortenURLs(ctx, req.(*BatchShortenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortlinkService_BatchShortenURLsStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BatchShortenRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ShortlinkServiceServer).BatchShortenURLsStream(m, &grpc.GenericServerStream[BatchShortenRequest, BatchShortenResult]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShortlinkService_BatchShortenURLsStreamServer = grpc.ServerStreamingServer[BatchShortenResult]
+
+func _ShortlinkService_DeleteUserURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserURLsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).DeleteUserURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_DeleteUserURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).DeleteUserURLs(ctx, req.(*DeleteUserURLsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortlinkService_ListTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).ListTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_ListTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).ListTrash(ctx, req.(*ListTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortlinkService_RestoreLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreLinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).RestoreLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_RestoreLinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).RestoreLinks(ctx, req.(*RestoreLinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortlinkService_UpdateLinkRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLinkRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).UpdateLinkRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_UpdateLinkRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).UpdateLinkRules(ctx, req.(*UpdateLinkRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortlinkService_UpdateLinkVariants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLinkVariantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).UpdateLinkVariants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_UpdateLinkVariants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).UpdateLinkVariants(ctx, req.(*UpdateLinkVariantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortlinkService_GetVariantStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVariantStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).GetVariantStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_GetVariantStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).GetVariantStats(ctx, req.(*GetVariantStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortlinkService_GetLinkStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLinkStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).GetLinkStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_GetLinkStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).GetLinkStats(ctx, req.(*GetLinkStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortlinkService_GetLinkBreakdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLinkBreakdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).GetLinkBreakdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_GetLinkBreakdown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).GetLinkBreakdown(ctx, req.(*GetLinkBreakdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortlinkService_ListBotSignatures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBotSignaturesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).ListBotSignatures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_ListBotSignatures_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).ListBotSignatures(ctx, req.(*ListBotSignaturesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortlinkService_UpdateBotSignatures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBotSignaturesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).UpdateBotSignatures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_UpdateBotSignatures_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).UpdateBotSignatures(ctx, req.(*UpdateBotSignaturesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortlinkService_UpdateLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).UpdateLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_UpdateLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).UpdateLink(ctx, req.(*UpdateLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortlinkService_ListLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).ListLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_ListLinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).ListLinks(ctx, req.(*ListLinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortlinkService_GetLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).GetLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_GetLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).GetLink(ctx, req.(*GetLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortlinkService_GetLinkPreview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLinkPreviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).GetLinkPreview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_GetLinkPreview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).GetLinkPreview(ctx, req.(*GetLinkPreviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortlinkService_AddDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).AddDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_AddDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).AddDomain(ctx, req.(*AddDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortlinkService_VerifyDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).VerifyDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_VerifyDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).VerifyDomain(ctx, req.(*VerifyDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortlinkService_ListDomains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDomainsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).ListDomains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_ListDomains_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).ListDomains(ctx, req.(*ListDomainsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortlinkService_SetDefaultDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDefaultDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).SetDefaultDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_SetDefaultDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).SetDefaultDomain(ctx, req.(*SetDefaultDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// ShortlinkServiceServer is the server API for ShortlinkService service.
-// All implementations must embed UnimplementedShortlinkServiceServer
-// for forward compatibility.
-type ShortlinkServiceServer interface {
-	// 长链接 → 短链接
-	ShortenURL(context.Context, *ShortenRequest) (*ShortenResponse, error)
-	// 短链接 → 长链接
-	Redierect(context.Context, *ResolveRequest) (*ResolveResponse, error)
-	// 获取前N条热点link
-	GetTopLinks(context.Context, *TopRequest) (*TopResponse, error)
-	// 批量生成短链接
-	BatchShortenURLs(context.Context, *BatchShortenRequest) (*BatchShortenResponse, error)
-	// 删除用户的所有短链接
-	DeleteUserURLs(context.Context, *DeleteUserURLsRequest) (*DeleteUserURLsResponse, error)
-	mustEmbedUnimplementedShortlinkServiceServer()
+func _ShortlinkService_DeleteDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).DeleteDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_DeleteDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).DeleteDomain(ctx, req.(*DeleteDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedShortlinkServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedShortlinkServiceServer struct{}
+func _ShortlinkService_TagLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TagLinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).TagLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_TagLinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).TagLinks(ctx, req.(*TagLinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-func (UnimplementedShortlinkServiceServer) ShortenURL(context.Context, *ShortenRequest) (*ShortenResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ShortenURL not implemented")
+func _ShortlinkService_UntagLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TagLinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).UntagLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_UntagLinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).UntagLinks(ctx, req.(*TagLinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedShortlinkServiceServer) Redierect(context.Context, *ResolveRequest) (*ResolveResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Redierect not implemented")
+
+func _ShortlinkService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_ListTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedShortlinkServiceServer) GetTopLinks(context.Context, *TopRequest) (*TopResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetTopLinks not implemented")
+
+func _ShortlinkService_ListLinksByTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLinksByTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).ListLinksByTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_ListLinksByTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).ListLinksByTag(ctx, req.(*ListLinksByTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedShortlinkServiceServer) BatchShortenURLs(context.Context, *BatchShortenRequest) (*BatchShortenResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method BatchShortenURLs not implemented")
+
+func _ShortlinkService_GetTagClicks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTagClicksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).GetTagClicks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_GetTagClicks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).GetTagClicks(ctx, req.(*GetTagClicksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedShortlinkServiceServer) DeleteUserURLs(context.Context, *DeleteUserURLsRequest) (*DeleteUserURLsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserURLs not implemented")
+
+func _ShortlinkService_ExportLinks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportLinksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ShortlinkServiceServer).ExportLinks(m, &grpc.GenericServerStream[ExportLinksRequest, ExportedLink]{ServerStream: stream})
 }
-func (UnimplementedShortlinkServiceServer) mustEmbedUnimplementedShortlinkServiceServer() {}
-func (UnimplementedShortlinkServiceServer) testEmbeddedByValue()                          {}
 
-// UnsafeShortlinkServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ShortlinkServiceServer will
-// result in compilation errors.
-type UnsafeShortlinkServiceServer interface {
-	mustEmbedUnimplementedShortlinkServiceServer()
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShortlinkService_ExportLinksServer = grpc.ServerStreamingServer[ExportedLink]
+
+func _ShortlinkService_SubmitBatchJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchShortenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).SubmitBatchJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_SubmitBatchJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).SubmitBatchJob(ctx, req.(*BatchShortenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterShortlinkServiceServer(s grpc.ServiceRegistrar, srv ShortlinkServiceServer) {
-	// If the following call pancis, it indicates UnimplementedShortlinkServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _ShortlinkService_GetBatchJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBatchJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&ShortlinkService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).GetBatchJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_GetBatchJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).GetBatchJob(ctx, req.(*GetBatchJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _ShortlinkService_ShortenURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ShortenRequest)
+func _ShortlinkService_CancelBatchJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelBatchJobRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShortlinkServiceServer).ShortenURL(ctx, in)
+		return srv.(ShortlinkServiceServer).CancelBatchJob(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ShortlinkService_ShortenURL_FullMethodName,
+		FullMethod: ShortlinkService_CancelBatchJob_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShortlinkServiceServer).ShortenURL(ctx, req.(*ShortenRequest))
+		return srv.(ShortlinkServiceServer).CancelBatchJob(ctx, req.(*CancelBatchJobRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ShortlinkService_Redierect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ResolveRequest)
+func _ShortlinkService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShortlinkServiceServer).Redierect(ctx, in)
+		return srv.(ShortlinkServiceServer).CreateWebhook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ShortlinkService_Redierect_FullMethodName,
+		FullMethod: ShortlinkService_CreateWebhook_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShortlinkServiceServer).Redierect(ctx, req.(*ResolveRequest))
+		return srv.(ShortlinkServiceServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ShortlinkService_GetTopLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TopRequest)
+func _ShortlinkService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShortlinkServiceServer).GetTopLinks(ctx, in)
+		return srv.(ShortlinkServiceServer).ListWebhooks(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ShortlinkService_GetTopLinks_FullMethodName,
+		FullMethod: ShortlinkService_ListWebhooks_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShortlinkServiceServer).GetTopLinks(ctx, req.(*TopRequest))
+		return srv.(ShortlinkServiceServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ShortlinkService_BatchShortenURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BatchShortenRequest)
+func _ShortlinkService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShortlinkServiceServer).BatchShortenURLs(ctx, in)
+		return srv.(ShortlinkServiceServer).DeleteWebhook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ShortlinkService_BatchShortenURLs_FullMethodName,
+		FullMethod: ShortlinkService_DeleteWebhook_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShortlinkServiceServer).BatchShortenURLs(ctx, req.(*BatchShortenRequest))
+		return srv.(ShortlinkServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ShortlinkService_DeleteUserURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteUserURLsRequest)
+func _ShortlinkService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShortlinkServiceServer).DeleteUserURLs(ctx, in)
+		return srv.(ShortlinkServiceServer).ListWebhookDeliveries(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ShortlinkService_DeleteUserURLs_FullMethodName,
+		FullMethod: ShortlinkService_ListWebhookDeliveries_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShortlinkServiceServer).DeleteUserURLs(ctx, req.(*DeleteUserURLsRequest))
+		return srv.(ShortlinkServiceServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortlinkService_ReplayWebhookDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayWebhookDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortlinkServiceServer).ReplayWebhookDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortlinkService_ReplayWebhookDelivery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortlinkServiceServer).ReplayWebhookDelivery(ctx, req.(*ReplayWebhookDeliveryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -277,7 +1469,142 @@ var ShortlinkService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteUserURLs",
 			Handler:    _ShortlinkService_DeleteUserURLs_Handler,
 		},
+		{
+			MethodName: "ListTrash",
+			Handler:    _ShortlinkService_ListTrash_Handler,
+		},
+		{
+			MethodName: "RestoreLinks",
+			Handler:    _ShortlinkService_RestoreLinks_Handler,
+		},
+		{
+			MethodName: "UpdateLinkRules",
+			Handler:    _ShortlinkService_UpdateLinkRules_Handler,
+		},
+		{
+			MethodName: "UpdateLinkVariants",
+			Handler:    _ShortlinkService_UpdateLinkVariants_Handler,
+		},
+		{
+			MethodName: "GetVariantStats",
+			Handler:    _ShortlinkService_GetVariantStats_Handler,
+		},
+		{
+			MethodName: "GetLinkStats",
+			Handler:    _ShortlinkService_GetLinkStats_Handler,
+		},
+		{
+			MethodName: "GetLinkBreakdown",
+			Handler:    _ShortlinkService_GetLinkBreakdown_Handler,
+		},
+		{
+			MethodName: "ListBotSignatures",
+			Handler:    _ShortlinkService_ListBotSignatures_Handler,
+		},
+		{
+			MethodName: "UpdateBotSignatures",
+			Handler:    _ShortlinkService_UpdateBotSignatures_Handler,
+		},
+		{
+			MethodName: "UpdateLink",
+			Handler:    _ShortlinkService_UpdateLink_Handler,
+		},
+		{
+			MethodName: "ListLinks",
+			Handler:    _ShortlinkService_ListLinks_Handler,
+		},
+		{
+			MethodName: "GetLink",
+			Handler:    _ShortlinkService_GetLink_Handler,
+		},
+		{
+			MethodName: "GetLinkPreview",
+			Handler:    _ShortlinkService_GetLinkPreview_Handler,
+		},
+		{
+			MethodName: "AddDomain",
+			Handler:    _ShortlinkService_AddDomain_Handler,
+		},
+		{
+			MethodName: "VerifyDomain",
+			Handler:    _ShortlinkService_VerifyDomain_Handler,
+		},
+		{
+			MethodName: "ListDomains",
+			Handler:    _ShortlinkService_ListDomains_Handler,
+		},
+		{
+			MethodName: "SetDefaultDomain",
+			Handler:    _ShortlinkService_SetDefaultDomain_Handler,
+		},
+		{
+			MethodName: "DeleteDomain",
+			Handler:    _ShortlinkService_DeleteDomain_Handler,
+		},
+		{
+			MethodName: "TagLinks",
+			Handler:    _ShortlinkService_TagLinks_Handler,
+		},
+		{
+			MethodName: "UntagLinks",
+			Handler:    _ShortlinkService_UntagLinks_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _ShortlinkService_ListTags_Handler,
+		},
+		{
+			MethodName: "ListLinksByTag",
+			Handler:    _ShortlinkService_ListLinksByTag_Handler,
+		},
+		{
+			MethodName: "GetTagClicks",
+			Handler:    _ShortlinkService_GetTagClicks_Handler,
+		},
+		{
+			MethodName: "SubmitBatchJob",
+			Handler:    _ShortlinkService_SubmitBatchJob_Handler,
+		},
+		{
+			MethodName: "GetBatchJob",
+			Handler:    _ShortlinkService_GetBatchJob_Handler,
+		},
+		{
+			MethodName: "CancelBatchJob",
+			Handler:    _ShortlinkService_CancelBatchJob_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _ShortlinkService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _ShortlinkService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _ShortlinkService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _ShortlinkService_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "ReplayWebhookDelivery",
+			Handler:    _ShortlinkService_ReplayWebhookDelivery_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BatchShortenURLsStream",
+			Handler:       _ShortlinkService_BatchShortenURLsStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportLinks",
+			Handler:       _ShortlinkService_ExportLinks_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/shortlinkpb/shortlink.proto",
 }
@@ -135,6 +135,137 @@ func (x *CheckPermissionResponse) GetMessage() string {
 	return ""
 }
 
+// 工作区权限检查请求
+type CheckWorkspacePermissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WorkspaceId   uint32                 `protobuf:"varint,2,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	Resource      string                 `protobuf:"bytes,3,opt,name=resource,proto3" json:"resource,omitempty"`
+	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckWorkspacePermissionRequest) Reset() {
+	*x = CheckWorkspacePermissionRequest{}
+	mi := &file_proto_rbac_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckWorkspacePermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckWorkspacePermissionRequest) ProtoMessage() {}
+
+func (x *CheckWorkspacePermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rbac_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckWorkspacePermissionRequest.ProtoReflect.Descriptor instead.
+func (*CheckWorkspacePermissionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_rbac_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CheckWorkspacePermissionRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CheckWorkspacePermissionRequest) GetWorkspaceId() uint32 {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return 0
+}
+
+func (x *CheckWorkspacePermissionRequest) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *CheckWorkspacePermissionRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+// 工作区权限检查响应
+type CheckWorkspacePermissionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HasPermission bool                   `protobuf:"varint,1,opt,name=has_permission,json=hasPermission,proto3" json:"has_permission,omitempty"`
+	// 用户在工作区中的角色，不是成员时为空
+	Role          string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckWorkspacePermissionResponse) Reset() {
+	*x = CheckWorkspacePermissionResponse{}
+	mi := &file_proto_rbac_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckWorkspacePermissionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckWorkspacePermissionResponse) ProtoMessage() {}
+
+func (x *CheckWorkspacePermissionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_rbac_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckWorkspacePermissionResponse.ProtoReflect.Descriptor instead.
+func (*CheckWorkspacePermissionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_rbac_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CheckWorkspacePermissionResponse) GetHasPermission() bool {
+	if x != nil {
+		return x.HasPermission
+	}
+	return false
+}
+
+func (x *CheckWorkspacePermissionResponse) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *CheckWorkspacePermissionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 // 角色信息
 type RoleInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -147,7 +278,7 @@ type RoleInfo struct {
 
 func (x *RoleInfo) Reset() {
 	*x = RoleInfo{}
-	mi := &file_proto_rbac_proto_msgTypes[2]
+	mi := &file_proto_rbac_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -159,7 +290,7 @@ func (x *RoleInfo) String() string {
 func (*RoleInfo) ProtoMessage() {}
 
 func (x *RoleInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_rbac_proto_msgTypes[2]
+	mi := &file_proto_rbac_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -172,7 +303,7 @@ func (x *RoleInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RoleInfo.ProtoReflect.Descriptor instead.
 func (*RoleInfo) Descriptor() ([]byte, []int) {
-	return file_proto_rbac_proto_rawDescGZIP(), []int{2}
+	return file_proto_rbac_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *RoleInfo) GetId() uint32 {
@@ -210,7 +341,7 @@ type PermissionInfo struct {
 
 func (x *PermissionInfo) Reset() {
 	*x = PermissionInfo{}
-	mi := &file_proto_rbac_proto_msgTypes[3]
+	mi := &file_proto_rbac_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -222,7 +353,7 @@ func (x *PermissionInfo) String() string {
 func (*PermissionInfo) ProtoMessage() {}
 
 func (x *PermissionInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_rbac_proto_msgTypes[3]
+	mi := &file_proto_rbac_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -235,7 +366,7 @@ func (x *PermissionInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PermissionInfo.ProtoReflect.Descriptor instead.
 func (*PermissionInfo) Descriptor() ([]byte, []int) {
-	return file_proto_rbac_proto_rawDescGZIP(), []int{3}
+	return file_proto_rbac_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *PermissionInfo) GetId() uint32 {
@@ -283,7 +414,7 @@ type GetUserRolesRequest struct {
 
 func (x *GetUserRolesRequest) Reset() {
 	*x = GetUserRolesRequest{}
-	mi := &file_proto_rbac_proto_msgTypes[4]
+	mi := &file_proto_rbac_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -295,7 +426,7 @@ func (x *GetUserRolesRequest) String() string {
 func (*GetUserRolesRequest) ProtoMessage() {}
 
 func (x *GetUserRolesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_rbac_proto_msgTypes[4]
+	mi := &file_proto_rbac_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -308,7 +439,7 @@ func (x *GetUserRolesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserRolesRequest.ProtoReflect.Descriptor instead.
 func (*GetUserRolesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_rbac_proto_rawDescGZIP(), []int{4}
+	return file_proto_rbac_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetUserRolesRequest) GetUserId() uint32 {
@@ -328,7 +459,7 @@ type GetUserRolesResponse struct {
 
 func (x *GetUserRolesResponse) Reset() {
 	*x = GetUserRolesResponse{}
-	mi := &file_proto_rbac_proto_msgTypes[5]
+	mi := &file_proto_rbac_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -340,7 +471,7 @@ func (x *GetUserRolesResponse) String() string {
 func (*GetUserRolesResponse) ProtoMessage() {}
 
 func (x *GetUserRolesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_rbac_proto_msgTypes[5]
+	mi := &file_proto_rbac_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -353,7 +484,7 @@ func (x *GetUserRolesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserRolesResponse.ProtoReflect.Descriptor instead.
 func (*GetUserRolesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_rbac_proto_rawDescGZIP(), []int{5}
+	return file_proto_rbac_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetUserRolesResponse) GetRoles() []*RoleInfo {
@@ -373,7 +504,7 @@ type GetRolePermissionsRequest struct {
 
 func (x *GetRolePermissionsRequest) Reset() {
 	*x = GetRolePermissionsRequest{}
-	mi := &file_proto_rbac_proto_msgTypes[6]
+	mi := &file_proto_rbac_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -385,7 +516,7 @@ func (x *GetRolePermissionsRequest) String() string {
 func (*GetRolePermissionsRequest) ProtoMessage() {}
 
 func (x *GetRolePermissionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_rbac_proto_msgTypes[6]
+	mi := &file_proto_rbac_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -398,7 +529,7 @@ func (x *GetRolePermissionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRolePermissionsRequest.ProtoReflect.Descriptor instead.
 func (*GetRolePermissionsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_rbac_proto_rawDescGZIP(), []int{6}
+	return file_proto_rbac_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetRolePermissionsRequest) GetRoleId() uint32 {
@@ -418,7 +549,7 @@ type GetRolePermissionsResponse struct {
 
 func (x *GetRolePermissionsResponse) Reset() {
 	*x = GetRolePermissionsResponse{}
-	mi := &file_proto_rbac_proto_msgTypes[7]
+	mi := &file_proto_rbac_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -430,7 +561,7 @@ func (x *GetRolePermissionsResponse) String() string {
 func (*GetRolePermissionsResponse) ProtoMessage() {}
 
 func (x *GetRolePermissionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_rbac_proto_msgTypes[7]
+	mi := &file_proto_rbac_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -443,7 +574,7 @@ func (x *GetRolePermissionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRolePermissionsResponse.ProtoReflect.Descriptor instead.
 func (*GetRolePermissionsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_rbac_proto_rawDescGZIP(), []int{7}
+	return file_proto_rbac_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetRolePermissionsResponse) GetPermissions() []*PermissionInfo {
@@ -464,7 +595,7 @@ type CreateRoleRequest struct {
 
 func (x *CreateRoleRequest) Reset() {
 	*x = CreateRoleRequest{}
-	mi := &file_proto_rbac_proto_msgTypes[8]
+	mi := &file_proto_rbac_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -476,7 +607,7 @@ func (x *CreateRoleRequest) String() string {
 func (*CreateRoleRequest) ProtoMessage() {}
 
 func (x *CreateRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_rbac_proto_msgTypes[8]
+	mi := &file_proto_rbac_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -489,7 +620,7 @@ func (x *CreateRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateRoleRequest.ProtoReflect.Descriptor instead.
 func (*CreateRoleRequest) Descriptor() ([]byte, []int) {
-	return file_proto_rbac_proto_rawDescGZIP(), []int{8}
+	return file_proto_rbac_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CreateRoleRequest) GetName() string {
@@ -517,7 +648,7 @@ type CreateRoleResponse struct {
 
 func (x *CreateRoleResponse) Reset() {
 	*x = CreateRoleResponse{}
-	mi := &file_proto_rbac_proto_msgTypes[9]
+	mi := &file_proto_rbac_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -529,7 +660,7 @@ func (x *CreateRoleResponse) String() string {
 func (*CreateRoleResponse) ProtoMessage() {}
 
 func (x *CreateRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_rbac_proto_msgTypes[9]
+	mi := &file_proto_rbac_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -542,7 +673,7 @@ func (x *CreateRoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateRoleResponse.ProtoReflect.Descriptor instead.
 func (*CreateRoleResponse) Descriptor() ([]byte, []int) {
-	return file_proto_rbac_proto_rawDescGZIP(), []int{9}
+	return file_proto_rbac_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CreateRoleResponse) GetRole() *RoleInfo {
@@ -572,7 +703,7 @@ type CreatePermissionRequest struct {
 
 func (x *CreatePermissionRequest) Reset() {
 	*x = CreatePermissionRequest{}
-	mi := &file_proto_rbac_proto_msgTypes[10]
+	mi := &file_proto_rbac_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -584,7 +715,7 @@ func (x *CreatePermissionRequest) String() string {
 func (*CreatePermissionRequest) ProtoMessage() {}
 
 func (x *CreatePermissionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_rbac_proto_msgTypes[10]
+	mi := &file_proto_rbac_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -597,7 +728,7 @@ func (x *CreatePermissionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePermissionRequest.ProtoReflect.Descriptor instead.
 func (*CreatePermissionRequest) Descriptor() ([]byte, []int) {
-	return file_proto_rbac_proto_rawDescGZIP(), []int{10}
+	return file_proto_rbac_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *CreatePermissionRequest) GetName() string {
@@ -639,7 +770,7 @@ type CreatePermissionResponse struct {
 
 func (x *CreatePermissionResponse) Reset() {
 	*x = CreatePermissionResponse{}
-	mi := &file_proto_rbac_proto_msgTypes[11]
+	mi := &file_proto_rbac_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -651,7 +782,7 @@ func (x *CreatePermissionResponse) String() string {
 func (*CreatePermissionResponse) ProtoMessage() {}
 
 func (x *CreatePermissionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_rbac_proto_msgTypes[11]
+	mi := &file_proto_rbac_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -664,7 +795,7 @@ func (x *CreatePermissionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePermissionResponse.ProtoReflect.Descriptor instead.
 func (*CreatePermissionResponse) Descriptor() ([]byte, []int) {
-	return file_proto_rbac_proto_rawDescGZIP(), []int{11}
+	return file_proto_rbac_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *CreatePermissionResponse) GetPermission() *PermissionInfo {
@@ -692,7 +823,7 @@ type AssignRoleToUserRequest struct {
 
 func (x *AssignRoleToUserRequest) Reset() {
 	*x = AssignRoleToUserRequest{}
-	mi := &file_proto_rbac_proto_msgTypes[12]
+	mi := &file_proto_rbac_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -704,7 +835,7 @@ func (x *AssignRoleToUserRequest) String() string {
 func (*AssignRoleToUserRequest) ProtoMessage() {}
 
 func (x *AssignRoleToUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_rbac_proto_msgTypes[12]
+	mi := &file_proto_rbac_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -717,7 +848,7 @@ func (x *AssignRoleToUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignRoleToUserRequest.ProtoReflect.Descriptor instead.
 func (*AssignRoleToUserRequest) Descriptor() ([]byte, []int) {
-	return file_proto_rbac_proto_rawDescGZIP(), []int{12}
+	return file_proto_rbac_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *AssignRoleToUserRequest) GetUserId() uint32 {
@@ -744,7 +875,7 @@ type AssignRoleToUserResponse struct {
 
 func (x *AssignRoleToUserResponse) Reset() {
 	*x = AssignRoleToUserResponse{}
-	mi := &file_proto_rbac_proto_msgTypes[13]
+	mi := &file_proto_rbac_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -756,7 +887,7 @@ func (x *AssignRoleToUserResponse) String() string {
 func (*AssignRoleToUserResponse) ProtoMessage() {}
 
 func (x *AssignRoleToUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_rbac_proto_msgTypes[13]
+	mi := &file_proto_rbac_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -769,7 +900,7 @@ func (x *AssignRoleToUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignRoleToUserResponse.ProtoReflect.Descriptor instead.
 func (*AssignRoleToUserResponse) Descriptor() ([]byte, []int) {
-	return file_proto_rbac_proto_rawDescGZIP(), []int{13}
+	return file_proto_rbac_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *AssignRoleToUserResponse) GetMessage() string {
@@ -790,7 +921,7 @@ type AssignPermissionToRoleRequest struct {
 
 func (x *AssignPermissionToRoleRequest) Reset() {
 	*x = AssignPermissionToRoleRequest{}
-	mi := &file_proto_rbac_proto_msgTypes[14]
+	mi := &file_proto_rbac_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -802,7 +933,7 @@ func (x *AssignPermissionToRoleRequest) String() string {
 func (*AssignPermissionToRoleRequest) ProtoMessage() {}
 
 func (x *AssignPermissionToRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_rbac_proto_msgTypes[14]
+	mi := &file_proto_rbac_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -815,7 +946,7 @@ func (x *AssignPermissionToRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignPermissionToRoleRequest.ProtoReflect.Descriptor instead.
 func (*AssignPermissionToRoleRequest) Descriptor() ([]byte, []int) {
-	return file_proto_rbac_proto_rawDescGZIP(), []int{14}
+	return file_proto_rbac_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *AssignPermissionToRoleRequest) GetRoleId() uint32 {
@@ -842,7 +973,7 @@ type AssignPermissionToRoleResponse struct {
 
 func (x *AssignPermissionToRoleResponse) Reset() {
 	*x = AssignPermissionToRoleResponse{}
-	mi := &file_proto_rbac_proto_msgTypes[15]
+	mi := &file_proto_rbac_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -854,7 +985,7 @@ func (x *AssignPermissionToRoleResponse) String() string {
 func (*AssignPermissionToRoleResponse) ProtoMessage() {}
 
 func (x *AssignPermissionToRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_rbac_proto_msgTypes[15]
+	mi := &file_proto_rbac_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -867,7 +998,7 @@ func (x *AssignPermissionToRoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignPermissionToRoleResponse.ProtoReflect.Descriptor instead.
 func (*AssignPermissionToRoleResponse) Descriptor() ([]byte, []int) {
-	return file_proto_rbac_proto_rawDescGZIP(), []int{15}
+	return file_proto_rbac_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *AssignPermissionToRoleResponse) GetMessage() string {
@@ -888,7 +1019,16 @@ const file_proto_rbac_proto_rawDesc = "" +
 	"\x06action\x18\x03 \x01(\tR\x06action\"Z\n" +
 	"\x17CheckPermissionResponse\x12%\n" +
 	"\x0ehas_permission\x18\x01 \x01(\bR\rhasPermission\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"P\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x91\x01\n" +
+	"\x1fCheckWorkspacePermissionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\rR\x06userId\x12!\n" +
+	"\fworkspace_id\x18\x02 \x01(\rR\vworkspaceId\x12\x1a\n" +
+	"\bresource\x18\x03 \x01(\tR\bresource\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\"w\n" +
+	" CheckWorkspacePermissionResponse\x12%\n" +
+	"\x0ehas_permission\x18\x01 \x01(\bR\rhasPermission\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"P\n" +
 	"\bRoleInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -932,9 +1072,10 @@ const file_proto_rbac_proto_rawDesc = "" +
 	"\arole_id\x18\x01 \x01(\rR\x06roleId\x12#\n" +
 	"\rpermission_id\x18\x02 \x01(\rR\fpermissionId\":\n" +
 	"\x1eAssignPermissionToRoleResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage2\xc9\x04\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage2\xb4\x05\n" +
 	"\vRBACService\x12N\n" +
-	"\x0fCheckPermission\x12\x1c.user.CheckPermissionRequest\x1a\x1d.user.CheckPermissionResponse\x12E\n" +
+	"\x0fCheckPermission\x12\x1c.user.CheckPermissionRequest\x1a\x1d.user.CheckPermissionResponse\x12i\n" +
+	"\x18CheckWorkspacePermission\x12%.user.CheckWorkspacePermissionRequest\x1a&.user.CheckWorkspacePermissionResponse\x12E\n" +
 	"\fGetUserRoles\x12\x19.user.GetUserRolesRequest\x1a\x1a.user.GetUserRolesResponse\x12W\n" +
 	"\x12GetRolePermissions\x12\x1f.user.GetRolePermissionsRequest\x1a .user.GetRolePermissionsResponse\x12?\n" +
 	"\n" +
@@ -955,46 +1096,50 @@ func file_proto_rbac_proto_rawDescGZIP() []byte {
 	return file_proto_rbac_proto_rawDescData
 }
 
-var file_proto_rbac_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_proto_rbac_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_proto_rbac_proto_goTypes = []any{
-	(*CheckPermissionRequest)(nil),         // 0: user.CheckPermissionRequest
-	(*CheckPermissionResponse)(nil),        // 1: user.CheckPermissionResponse
-	(*RoleInfo)(nil),                       // 2: user.RoleInfo
-	(*PermissionInfo)(nil),                 // 3: user.PermissionInfo
-	(*GetUserRolesRequest)(nil),            // 4: user.GetUserRolesRequest
-	(*GetUserRolesResponse)(nil),           // 5: user.GetUserRolesResponse
-	(*GetRolePermissionsRequest)(nil),      // 6: user.GetRolePermissionsRequest
-	(*GetRolePermissionsResponse)(nil),     // 7: user.GetRolePermissionsResponse
-	(*CreateRoleRequest)(nil),              // 8: user.CreateRoleRequest
-	(*CreateRoleResponse)(nil),             // 9: user.CreateRoleResponse
-	(*CreatePermissionRequest)(nil),        // 10: user.CreatePermissionRequest
-	(*CreatePermissionResponse)(nil),       // 11: user.CreatePermissionResponse
-	(*AssignRoleToUserRequest)(nil),        // 12: user.AssignRoleToUserRequest
-	(*AssignRoleToUserResponse)(nil),       // 13: user.AssignRoleToUserResponse
-	(*AssignPermissionToRoleRequest)(nil),  // 14: user.AssignPermissionToRoleRequest
-	(*AssignPermissionToRoleResponse)(nil), // 15: user.AssignPermissionToRoleResponse
+	(*CheckPermissionRequest)(nil),           // 0: user.CheckPermissionRequest
+	(*CheckPermissionResponse)(nil),          // 1: user.CheckPermissionResponse
+	(*CheckWorkspacePermissionRequest)(nil),  // 2: user.CheckWorkspacePermissionRequest
+	(*CheckWorkspacePermissionResponse)(nil), // 3: user.CheckWorkspacePermissionResponse
+	(*RoleInfo)(nil),                         // 4: user.RoleInfo
+	(*PermissionInfo)(nil),                   // 5: user.PermissionInfo
+	(*GetUserRolesRequest)(nil),              // 6: user.GetUserRolesRequest
+	(*GetUserRolesResponse)(nil),             // 7: user.GetUserRolesResponse
+	(*GetRolePermissionsRequest)(nil),        // 8: user.GetRolePermissionsRequest
+	(*GetRolePermissionsResponse)(nil),       // 9: user.GetRolePermissionsResponse
+	(*CreateRoleRequest)(nil),                // 10: user.CreateRoleRequest
+	(*CreateRoleResponse)(nil),               // 11: user.CreateRoleResponse
+	(*CreatePermissionRequest)(nil),          // 12: user.CreatePermissionRequest
+	(*CreatePermissionResponse)(nil),         // 13: user.CreatePermissionResponse
+	(*AssignRoleToUserRequest)(nil),          // 14: user.AssignRoleToUserRequest
+	(*AssignRoleToUserResponse)(nil),         // 15: user.AssignRoleToUserResponse
+	(*AssignPermissionToRoleRequest)(nil),    // 16: user.AssignPermissionToRoleRequest
+	(*AssignPermissionToRoleResponse)(nil),   // 17: user.AssignPermissionToRoleResponse
 }
 var file_proto_rbac_proto_depIdxs = []int32{
-	2,  // 0: user.GetUserRolesResponse.roles:type_name -> user.RoleInfo
-	3,  // 1: user.GetRolePermissionsResponse.permissions:type_name -> user.PermissionInfo
-	2,  // 2: user.CreateRoleResponse.role:type_name -> user.RoleInfo
-	3,  // 3: user.CreatePermissionResponse.permission:type_name -> user.PermissionInfo
+	4,  // 0: user.GetUserRolesResponse.roles:type_name -> user.RoleInfo
+	5,  // 1: user.GetRolePermissionsResponse.permissions:type_name -> user.PermissionInfo
+	4,  // 2: user.CreateRoleResponse.role:type_name -> user.RoleInfo
+	5,  // 3: user.CreatePermissionResponse.permission:type_name -> user.PermissionInfo
 	0,  // 4: user.RBACService.CheckPermission:input_type -> user.CheckPermissionRequest
-	4,  // 5: user.RBACService.GetUserRoles:input_type -> user.GetUserRolesRequest
-	6,  // 6: user.RBACService.GetRolePermissions:input_type -> user.GetRolePermissionsRequest
-	8,  // 7: user.RBACService.CreateRole:input_type -> user.CreateRoleRequest
-	10, // 8: user.RBACService.CreatePermission:input_type -> user.CreatePermissionRequest
-	12, // 9: user.RBACService.AssignRoleToUser:input_type -> user.AssignRoleToUserRequest
-	14, // 10: user.RBACService.AssignPermissionToRole:input_type -> user.AssignPermissionToRoleRequest
-	1,  // 11: user.RBACService.CheckPermission:output_type -> user.CheckPermissionResponse
-	5,  // 12: user.RBACService.GetUserRoles:output_type -> user.GetUserRolesResponse
-	7,  // 13: user.RBACService.GetRolePermissions:output_type -> user.GetRolePermissionsResponse
-	9,  // 14: user.RBACService.CreateRole:output_type -> user.CreateRoleResponse
-	11, // 15: user.RBACService.CreatePermission:output_type -> user.CreatePermissionResponse
-	13, // 16: user.RBACService.AssignRoleToUser:output_type -> user.AssignRoleToUserResponse
-	15, // 17: user.RBACService.AssignPermissionToRole:output_type -> user.AssignPermissionToRoleResponse
-	11, // [11:18] is the sub-list for method output_type
-	4,  // [4:11] is the sub-list for method input_type
+	2,  // 5: user.RBACService.CheckWorkspacePermission:input_type -> user.CheckWorkspacePermissionRequest
+	6,  // 6: user.RBACService.GetUserRoles:input_type -> user.GetUserRolesRequest
+	8,  // 7: user.RBACService.GetRolePermissions:input_type -> user.GetRolePermissionsRequest
+	10, // 8: user.RBACService.CreateRole:input_type -> user.CreateRoleRequest
+	12, // 9: user.RBACService.CreatePermission:input_type -> user.CreatePermissionRequest
+	14, // 10: user.RBACService.AssignRoleToUser:input_type -> user.AssignRoleToUserRequest
+	16, // 11: user.RBACService.AssignPermissionToRole:input_type -> user.AssignPermissionToRoleRequest
+	1,  // 12: user.RBACService.CheckPermission:output_type -> user.CheckPermissionResponse
+	3,  // 13: user.RBACService.CheckWorkspacePermission:output_type -> user.CheckWorkspacePermissionResponse
+	7,  // 14: user.RBACService.GetUserRoles:output_type -> user.GetUserRolesResponse
+	9,  // 15: user.RBACService.GetRolePermissions:output_type -> user.GetRolePermissionsResponse
+	11, // 16: user.RBACService.CreateRole:output_type -> user.CreateRoleResponse
+	13, // 17: user.RBACService.CreatePermission:output_type -> user.CreatePermissionResponse
+	15, // 18: user.RBACService.AssignRoleToUser:output_type -> user.AssignRoleToUserResponse
+	17, // 19: user.RBACService.AssignPermissionToRole:output_type -> user.AssignPermissionToRoleResponse
+	12, // [12:20] is the sub-list for method output_type
+	4,  // [4:12] is the sub-list for method input_type
 	4,  // [4:4] is the sub-list for extension type_name
 	4,  // [4:4] is the sub-list for extension extendee
 	0,  // [0:4] is the sub-list for field type_name
@@ -1011,7 +1156,7 @@ func file_proto_rbac_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_rbac_proto_rawDesc), len(file_proto_rbac_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,13 +19,14 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	RBACService_CheckPermission_FullMethodName        = "/user.RBACService/CheckPermission"
-	RBACService_GetUserRoles_FullMethodName           = "/user.RBACService/GetUserRoles"
-	RBACService_GetRolePermissions_FullMethodName     = "/user.RBACService/GetRolePermissions"
-	RBACService_CreateRole_FullMethodName             = "/user.RBACService/CreateRole"
-	RBACService_CreatePermission_FullMethodName       = "/user.RBACService/CreatePermission"
-	RBACService_AssignRoleToUser_FullMethodName       = "/user.RBACService/AssignRoleToUser"
-	RBACService_AssignPermissionToRole_FullMethodName = "/user.RBACService/AssignPermissionToRole"
+	RBACService_CheckPermission_FullMethodName          = "/user.RBACService/CheckPermission"
+	RBACService_CheckWorkspacePermission_FullMethodName = "/user.RBACService/CheckWorkspacePermission"
+	RBACService_GetUserRoles_FullMethodName             = "/user.RBACService/GetUserRoles"
+	RBACService_GetRolePermissions_FullMethodName       = "/user.RBACService/GetRolePermissions"
+	RBACService_CreateRole_FullMethodName               = "/user.RBACService/CreateRole"
+	RBACService_CreatePermission_FullMethodName         = "/user.RBACService/CreatePermission"
+	RBACService_AssignRoleToUser_FullMethodName         = "/user.RBACService/AssignRoleToUser"
+	RBACService_AssignPermissionToRole_FullMethodName   = "/user.RBACService/AssignPermissionToRole"
 )
 
 // RBACServiceClient is the client API for RBACService service.
@@ -36,6 +37,8 @@ const (
 type RBACServiceClient interface {
 	// 权限检查
 	CheckPermission(ctx context.Context, in *CheckPermissionRequest, opts ...grpc.CallOption) (*CheckPermissionResponse, error)
+	// 工作区权限检查，按用户在工作区中的角色判断
+	CheckWorkspacePermission(ctx context.Context, in *CheckWorkspacePermissionRequest, opts ...grpc.CallOption) (*CheckWorkspacePermissionResponse, error)
 	// 获取用户角色
 	GetUserRoles(ctx context.Context, in *GetUserRolesRequest, opts ...grpc.CallOption) (*GetUserRolesResponse, error)
 	// 获取角色权限
@@ -68,6 +71,16 @@ func (c *rBACServiceClient) CheckPermission(ctx context.Context, in *CheckPermis
 	return out, nil
 }
 
+func (c *rBACServiceClient) CheckWorkspacePermission(ctx context.Context, in *CheckWorkspacePermissionRequest, opts ...grpc.CallOption) (*CheckWorkspacePermissionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckWorkspacePermissionResponse)
+	err := c.cc.Invoke(ctx, RBACService_CheckWorkspacePermission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *rBACServiceClient) GetUserRoles(ctx context.Context, in *GetUserRolesRequest, opts ...grpc.CallOption) (*GetUserRolesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetUserRolesResponse)
@@ -136,6 +149,8 @@ func (c *rBACServiceClient) AssignPermissionToRole(ctx context.Context, in *Assi
 type RBACServiceServer interface {
 	// 权限检查
 	CheckPermission(context.Context, *CheckPermissionRequest) (*CheckPermissionResponse, error)
+	// 工作区权限检查，按用户在工作区中的角色判断
+	CheckWorkspacePermission(context.Context, *CheckWorkspacePermissionRequest) (*CheckWorkspacePermissionResponse, error)
 	// 获取用户角色
 	GetUserRoles(context.Context, *GetUserRolesRequest) (*GetUserRolesResponse, error)
 	// 获取角色权限
@@ -161,6 +176,9 @@ type UnimplementedRBACServiceServer struct{}
 func (UnimplementedRBACServiceServer) CheckPermission(context.Context, *CheckPermissionRequest) (*CheckPermissionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckPermission not implemented")
 }
+func (UnimplementedRBACServiceServer) CheckWorkspacePermission(context.Context, *CheckWorkspacePermissionRequest) (*CheckWorkspacePermissionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckWorkspacePermission not implemented")
+}
 func (UnimplementedRBACServiceServer) GetUserRoles(context.Context, *GetUserRolesRequest) (*GetUserRolesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserRoles not implemented")
 }
@@ -218,6 +236,24 @@ func _RBACService_CheckPermission_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RBACService_CheckWorkspacePermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckWorkspacePermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RBACServiceServer).CheckWorkspacePermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RBACService_CheckWorkspacePermission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RBACServiceServer).CheckWorkspacePermission(ctx, req.(*CheckWorkspacePermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RBACService_GetUserRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetUserRolesRequest)
 	if err := dec(in); err != nil {
@@ -337,6 +373,10 @@ var RBACService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CheckPermission",
 			Handler:    _RBACService_CheckPermission_Handler,
 		},
+		{
+			MethodName: "CheckWorkspacePermission",
+			Handler:    _RBACService_CheckWorkspacePermission_Handler,
+		},
 		{
 			MethodName: "GetUserRoles",
 			Handler:    _RBACService_GetUserRoles_Handler,
@@ -0,0 +1,49 @@
+package cache
+
+import (
+	"time"
+
+	"shortLink/shortlinkcore/logger"
+
+	"go.uber.org/zap"
+)
+
+// 自定义域名是否已验证的缓存，解析短链接时每次都要判断，避免频繁查库
+const (
+	domainKeyPrefix = "domain:verified:"
+	domainTTL       = time.Minute * 10
+)
+
+// GetDomainVerified 读取域名验证状态缓存
+// 返回：
+//   - verified: 是否已验证
+//   - ok: 是否命中缓存
+func GetDomainVerified(host string) (verified bool, ok bool) {
+	if rdb == nil {
+		return false, false
+	}
+	val, err := rdb.Get(ctx, domainKeyPrefix+host).Result()
+	if err != nil {
+		return false, false
+	}
+	return val == "1", true
+}
+
+// SetDomainVerified 缓存域名验证状态，未登记的域名也会缓存，防止穿透
+func SetDomainVerified(host string, verified bool) {
+	if rdb == nil {
+		return
+	}
+	val := "0"
+	if verified {
+		val = "1"
+	}
+	if err := rdb.Set(ctx, domainKeyPrefix+host, val, domainTTL).Err(); err != nil {
+		logger.Log.Warn("设置域名缓存失败", zap.String("host", host), zap.Error(err))
+	}
+}
+
+// DelDomainVerified 删除域名验证状态缓存
+func DelDomainVerified(host string) {
+	Del(domainKeyPrefix + host)
+}
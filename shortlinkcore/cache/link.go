@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"encoding/json"
+	"time"
+
+	"shortLink/shortlinkcore/logger"
+	"shortLink/shortlinkcore/model"
+
+	"go.uber.org/zap"
+)
+
+// 短链接详情缓存（原始链接 + 跳转规则等），解析时一次读取
+const linkKeyPrefix = "link:"
+
+// LinkKey 返回短链接详情缓存的key
+// 参数 key 为短链接的唯一标识（见 model.LinkKey），默认域名下即短链接本身
+func LinkKey(key string) string {
+	return linkKeyPrefix + key
+}
+
+// SetLink 缓存短链接详情
+func SetLink(mapping *model.URLMapping) {
+	if rdb == nil {
+		logger.Log.Warn("Redis未初始化")
+		return
+	}
+	data, err := json.Marshal(mapping)
+	if err != nil {
+		logger.Log.Error("序列化短链接缓存失败", zap.Error(err))
+		return
+	}
+	if err := rdb.Set(ctx, LinkKey(mapping.Key()), data, time.Hour*24).Err(); err != nil {
+		logger.Log.Error("设置短链接缓存失败", zap.Error(err), zap.String("shortUrl", mapping.ShortURL))
+	}
+}
+
+// GetLink 读取短链接详情缓存，未命中返回nil
+func GetLink(key string) *model.URLMapping {
+	if rdb == nil {
+		logger.Log.Warn("Redis未初始化")
+		return nil
+	}
+	data, err := rdb.Get(ctx, LinkKey(key)).Bytes()
+	if err != nil {
+		logger.Log.Debug("获取短链接缓存失败", zap.Error(err), zap.String("shortUrl", key))
+		return nil
+	}
+	var mapping model.URLMapping
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		logger.Log.Warn("短链接缓存格式错误", zap.Error(err), zap.String("shortUrl", key))
+		return nil
+	}
+	return &mapping
+}
+
+// DelLink 删除短链接详情缓存
+func DelLink(key string) {
+	Del(LinkKey(key))
+}
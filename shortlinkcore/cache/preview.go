@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"encoding/json"
+	"time"
+
+	"shortLink/shortlinkcore/logger"
+	"shortLink/shortlinkcore/model"
+
+	"go.uber.org/zap"
+)
+
+// 链接预览缓存，预览很少变化，过期时间比短链接详情更长
+const (
+	previewKeyPrefix = "preview:"
+	previewTTL       = time.Hour * 24 * 7
+)
+
+// PreviewKey 返回链接预览缓存的key，参数为短链接的唯一标识
+func PreviewKey(key string) string {
+	return previewKeyPrefix + key
+}
+
+// SetPreview 缓存链接预览
+func SetPreview(p *model.LinkPreview) {
+	if rdb == nil {
+		logger.Log.Warn("Redis未初始化")
+		return
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		logger.Log.Error("序列化预览缓存失败", zap.Error(err))
+		return
+	}
+	if err := rdb.Set(ctx, PreviewKey(p.Key()), data, previewTTL).Err(); err != nil {
+		logger.Log.Error("设置预览缓存失败", zap.Error(err), zap.String("shortUrl", p.ShortURL))
+	}
+}
+
+// GetPreview 读取链接预览缓存，未命中返回nil
+func GetPreview(key string) *model.LinkPreview {
+	if rdb == nil {
+		logger.Log.Warn("Redis未初始化")
+		return nil
+	}
+	data, err := rdb.Get(ctx, PreviewKey(key)).Bytes()
+	if err != nil {
+		logger.Log.Debug("获取预览缓存失败", zap.Error(err), zap.String("shortUrl", key))
+		return nil
+	}
+	var p model.LinkPreview
+	if err := json.Unmarshal(data, &p); err != nil {
+		logger.Log.Warn("预览缓存格式错误", zap.Error(err), zap.String("shortUrl", key))
+		return nil
+	}
+	return &p
+}
+
+// DelPreview 删除链接预览缓存
+func DelPreview(key string) {
+	Del(PreviewKey(key))
+}
@@ -15,6 +15,9 @@ type Config struct {
 	Logger LoggerConfig
 	App    AppConfig
 	Nacos  NacosConfig
+	GeoIP  GeoIPConfig
+	// 链接预览摘要
+	Summary SummaryConfig
 }
 
 type MySQLConfig struct {
@@ -46,6 +49,8 @@ type KafkaConfig struct {
 	Port    int
 	Brokers []string
 	Topic   string
+	// 点击事件的 Topic，默认 shortlink-click
+	ClickTopic string `mapstructure:"click_topic"`
 }
 
 type LoggerConfig struct {
@@ -60,6 +65,22 @@ type AppConfig struct {
 	JWTExpire    int
 	Base62Length int
 	MaxRetries   int `mapstructure:"max_retries"`
+	// 系统默认短链接域名（如 s.example.com），所有用户都可以使用
+	DefaultDomain string `mapstructure:"default_domain"`
+	// 短链接协议，默认 https
+	DomainScheme string `mapstructure:"domain_scheme"`
+	// 回收站保留天数，过期后彻底删除，默认30天
+	TrashRetentionDays int `mapstructure:"trash_retention_days"`
+	// 触发 link.click_threshold 事件的点击量，默认 100、1000、1万、10万、100万
+	WebhookClickThresholds []int64 `mapstructure:"webhook_click_thresholds"`
+	// 点击事件中访问者IP哈希的盐，修改后同一访问者的哈希会变化
+	AnalyticsSalt string `mapstructure:"analytics_salt"`
+	// 热度排行的半衰期（小时），点击每过一个半衰期权重减半，默认6小时
+	TrendingHalfLifeHours float64 `mapstructure:"trending_half_life_hours"`
+	// 视为机器人的 IP 段（CIDR 或单个 IP），如可用性监控和内部探测的出口
+	BotIPRanges []string `mapstructure:"bot_ip_ranges"`
+	// 同一 IP 每分钟访问同一短链接超过这个次数后视为机器人，默认20
+	BotRepeatThreshold int64 `mapstructure:"bot_repeat_threshold"`
 }
 
 type NacosConfig struct {
@@ -73,6 +94,21 @@ type NacosConfig struct {
 	Metadata    map[string]string
 }
 
+type GeoIPConfig struct {
+	Path string // 本地 .mmdb 数据库路径，为空则不启用地域规则
+}
+
+// SummaryConfig 链接预览摘要配置，修改 Nacos 配置后立即生效
+type SummaryConfig struct {
+	Provider  string  // openai：调用 OpenAI 兼容接口；extractive：本地抽取式摘要；为空不生成摘要
+	BaseURL   string  `mapstructure:"base_url"` // OpenAI 兼容接口地址
+	APIKey    string  `mapstructure:"api_key"`  // 接口密钥
+	Model     string  // 模型名称
+	Prompt    string  // 系统提示词，为空使用默认值
+	Timeout   int     // 请求超时时间（秒）
+	RateLimit float64 `mapstructure:"rate_limit"` // 每秒最多生成的摘要数，默认1
+}
+
 var GlobalConfig Config
 
 // InitConfig 初始化配置
@@ -12,9 +12,11 @@ import (
 	"shortLink/shortlinkcore/model"
 	"shortLink/shortlinkcore/mq"
 	"shortLink/shortlinkcore/pkg/discovery"
+	"shortLink/shortlinkcore/pkg/geoip"
 	"shortLink/shortlinkcore/service"
 	"syscall"
 
+	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
 )
@@ -25,6 +27,10 @@ func main() {
 	if err != nil {
 		log.Fatalf("❌ 初始化配置失败: %v", err)
 	}
+	// 点击事件和独立访客都使用加盐哈希，没有盐时 IP 可以被枚举还原
+	if config.GlobalConfig.App.AnalyticsSalt == "" {
+		log.Fatalf("❌ 未配置 app.analytics_salt，无法对访问者IP加盐哈希")
+	}
 
 	// 初始化mq
 	mq.InitKafka(config.GlobalConfig.Kafka.Brokers)
@@ -43,10 +49,35 @@ func main() {
 	// 预热布隆过滤器
 	cache.WarmUpBloomFromDB()
 
+	// 初始化GeoIP数据库（本地文件，变更后自动重新加载）
+	if path := config.GlobalConfig.GeoIP.Path; path != "" {
+		if err := geoip.Init(path); err != nil {
+			logger.Log.Error("初始化GeoIP数据库失败，地域规则不生效", zap.String("path", path), zap.Error(err))
+		}
+	}
+
 	// 创建 gRPC 服务器并注册服务
 	grpcServer := grpc.NewServer()
 	shortlinkpb.RegisterShortlinkServiceServer(grpcServer, service.NewShortlinkService())
 
+	// 启动异步批量任务的处理协程，会继续处理上次退出前未完成的任务
+	service.StartBatchJobWorkers()
+
+	// 启动 Webhook 事件的投递协程，继续投递上次退出前未完成的事件
+	service.StartWebhookWorkers()
+
+	// 定时彻底删除超过保留期的回收站短链接
+	service.StartTrashPurge()
+
+	// 加载机器人识别的 IP 段和特征，定期重新加载管理员更新的特征
+	service.StartBotClassifier()
+
+	// 定期把 Redis 中的点击量写入 MySQL，Redis 数据丢失时先用 MySQL 重建
+	service.StartClickFlusher()
+
+	// 用全站排行榜补齐用户和工作区的排行榜，只执行一次
+	service.StartOwnerRankBackfill()
+
 	lis, err := net.Listen("tcp", ":8082")
 	if err != nil {
 		log.Fatalf("❌ 监听端口失败: %v", err)
@@ -75,6 +106,15 @@ func main() {
 			log.Printf("注销服务失败: %v", err)
 		}
 
+		// 停止处理批量任务，未完成的任务放回等待队列
+		service.StopBatchJobWorkers()
+
+		// 停止投递 Webhook 事件，正在投递的事件放回队列
+		service.StopWebhookWorkers()
+
+		// 停止持久化点击量，退出前写入剩余的增量
+		service.StopClickFlusher()
+
 		// 停止gRPC服务器
 		grpcServer.GracefulStop()
 	}()
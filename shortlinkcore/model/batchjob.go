@@ -0,0 +1,192 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 批量任务状态
+const (
+	BatchJobPending   = "pending"
+	BatchJobRunning   = "running"
+	BatchJobCompleted = "completed"
+	BatchJobCancelled = "cancelled"
+)
+
+// 批量任务中单项的状态
+const (
+	BatchItemPending = "pending"
+	BatchItemSuccess = "success"
+	BatchItemFailed  = "failed"
+)
+
+// BatchJob 异步批量生成短链接的任务
+// 运行中的任务每处理完一段就刷新 UpdateTime，长时间未刷新说明处理它的实例已经退出，可以被其他实例接手
+type BatchJob struct {
+	ID           string `gorm:"primaryKey;size:36"`
+	UserID       string `gorm:"size:64;index"`
+	Domain       string `gorm:"size:191"`           // 提交时确定的短链接域名，为空表示系统默认域名
+	WorkspaceID  string `gorm:"size:64;default:''"` // 生成的短链接所属的工作区，为空表示个人短链接
+	Concurrency  int
+	Status       string `gorm:"size:16;index"`
+	Owner        string `gorm:"size:36"` // 每次接手时生成，防止被接手后原来的实例继续写入
+	Total        int
+	Processed    int
+	SuccessCount int
+	FailedCount  int
+	CreateTime   time.Time `gorm:"autoCreateTime"`
+	UpdateTime   time.Time `gorm:"autoUpdateTime"`
+	FinishTime   *time.Time
+}
+
+func (BatchJob) TableName() string {
+	return "batch_job"
+}
+
+// BatchJobItem 批量任务中的单个短链接，参数与结果都保存在这里
+type BatchJobItem struct {
+	JobID        string `gorm:"primaryKey;size:36"`
+	Index        int    `gorm:"primaryKey;autoIncrement:false;column:item_index"` // 在提交请求中的位置
+	OriginalURL  string `gorm:"type:text"`
+	Alias        string `gorm:"size:64"`
+	Tags         string `gorm:"type:text"` // 标签（JSON 数组）
+	ExpireAt     *time.Time
+	RedirectType int
+	Status       string `gorm:"size:16"`
+	ShortURL     string `gorm:"size:64"`
+	Error        string `gorm:"type:text"`
+	Duplicate    bool
+}
+
+func (BatchJobItem) TableName() string {
+	return "batch_job_item"
+}
+
+// CreateBatchJob 保存任务及其所有待处理项
+func CreateBatchJob(job *BatchJob, items []BatchJobItem) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(job).Error; err != nil {
+			return err
+		}
+		return tx.CreateInBatches(items, 500).Error
+	})
+}
+
+// GetBatchJob 查询用户的任务
+func GetBatchJob(userID, jobID string) (*BatchJob, error) {
+	var job BatchJob
+	if err := db.First(&job, "id = ? AND user_id = ?", jobID, userID).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ListClaimableBatchJobs 查询可以接手的任务：等待中的，以及 staleBefore 之后没有刷新过的运行中任务
+func ListClaimableBatchJobs(staleBefore time.Time, limit int) ([]BatchJob, error) {
+	var jobs []BatchJob
+	err := db.Where("status = ? OR (status = ? AND update_time < ?)", BatchJobPending, BatchJobRunning, staleBefore).
+		Order("create_time").Limit(limit).Find(&jobs).Error
+	return jobs, err
+}
+
+// ClaimBatchJob 接手任务，条件与 ListClaimableBatchJobs 一致，多个实例同时接手时只有一个成功
+func ClaimBatchJob(jobID, owner string, staleBefore time.Time) (bool, error) {
+	result := db.Model(&BatchJob{}).
+		Where("id = ? AND (status = ? OR (status = ? AND update_time < ?))", jobID, BatchJobPending, BatchJobRunning, staleBefore).
+		Updates(map[string]any{"status": BatchJobRunning, "owner": owner, "update_time": time.Now()})
+	return result.RowsAffected == 1, result.Error
+}
+
+// ReleaseBatchJob 服务退出时把运行中的任务放回等待队列，重启后立即继续
+func ReleaseBatchJob(jobID, owner string) error {
+	return db.Model(&BatchJob{}).Where("id = ? AND status = ? AND owner = ?", jobID, BatchJobRunning, owner).
+		Update("status", BatchJobPending).Error
+}
+
+// CancelBatchJob 取消未结束的任务，已处理的结果保留
+func CancelBatchJob(userID, jobID string) (bool, error) {
+	now := time.Now()
+	result := db.Model(&BatchJob{}).
+		Where("id = ? AND user_id = ? AND status IN ?", jobID, userID, []string{BatchJobPending, BatchJobRunning}).
+		Updates(map[string]any{"status": BatchJobCancelled, "finish_time": &now})
+	return result.RowsAffected == 1, result.Error
+}
+
+// ListPendingBatchItems 按顺序查询任务中尚未处理的项
+func ListPendingBatchItems(jobID string, limit int) ([]BatchJobItem, error) {
+	var items []BatchJobItem
+	err := db.Where("job_id = ? AND status = ?", jobID, BatchItemPending).
+		Order("item_index").Limit(limit).Find(&items).Error
+	return items, err
+}
+
+// SaveBatchItemResult 保存单项的处理结果
+func SaveBatchItemResult(item *BatchJobItem) error {
+	return db.Model(&BatchJobItem{}).Where("job_id = ? AND item_index = ?", item.JobID, item.Index).
+		Updates(map[string]any{
+			"status":    item.Status,
+			"short_url": item.ShortURL,
+			"error":     item.Error,
+			"duplicate": item.Duplicate,
+		}).Error
+}
+
+// RefreshBatchJobProgress 按单项状态重新统计进度并刷新 UpdateTime，统计结果不受中途退出的影响
+// 返回任务的最新状态；任务已被其他实例接手时返回空字符串
+func RefreshBatchJobProgress(jobID, owner string) (string, error) {
+	var rows []struct {
+		Status string
+		Count  int
+	}
+	err := db.Model(&BatchJobItem{}).Select("status, COUNT(*) AS count").
+		Where("job_id = ?", jobID).Group("status").Scan(&rows).Error
+	if err != nil {
+		return "", err
+	}
+	counts := make(map[string]int, len(rows))
+	for _, r := range rows {
+		counts[r.Status] = r.Count
+	}
+	// update_time 随之自动刷新
+	err = db.Model(&BatchJob{}).Where("id = ? AND owner = ?", jobID, owner).Updates(map[string]any{
+		"processed":     counts[BatchItemSuccess] + counts[BatchItemFailed],
+		"success_count": counts[BatchItemSuccess],
+		"failed_count":  counts[BatchItemFailed],
+	}).Error
+	if err != nil {
+		return "", err
+	}
+	var job BatchJob
+	if err := db.Select("status", "owner").First(&job, "id = ?", jobID).Error; err != nil {
+		return "", err
+	}
+	if job.Owner != owner {
+		return "", nil
+	}
+	return job.Status, nil
+}
+
+// FinishBatchJob 标记任务完成，已被取消的任务保持取消状态
+func FinishBatchJob(jobID, owner string) error {
+	now := time.Now()
+	return db.Model(&BatchJob{}).Where("id = ? AND owner = ? AND status = ?", jobID, owner, BatchJobRunning).
+		Updates(map[string]any{"status": BatchJobCompleted, "finish_time": &now}).Error
+}
+
+// ListBatchItemResults 分页查询已处理的项，status 为空时返回成功和失败的项
+func ListBatchItemResults(jobID, status string, offset, limit int) ([]BatchJobItem, int64, error) {
+	query := db.Model(&BatchJobItem{}).Where("job_id = ?", jobID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	} else {
+		query = query.Where("status <> ?", BatchItemPending)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var items []BatchJobItem
+	err := query.Order("item_index").Offset(offset).Limit(limit).Find(&items).Error
+	return items, total, err
+}
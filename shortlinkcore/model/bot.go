@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BotSignature 管理员维护的机器人 User-Agent 特征，在内置特征的基础上增加或停用
+type BotSignature struct {
+	Pattern    string    `gorm:"primaryKey;size:128"` // 小写，包含即命中
+	Disabled   bool      // 为 true 时停用同名的内置特征
+	UpdatedBy  string    `gorm:"size:64"`
+	UpdateTime time.Time `gorm:"autoUpdateTime"`
+}
+
+func (BotSignature) TableName() string {
+	return "bot_signature"
+}
+
+// ListBotSignatures 获取管理员增加和停用的特征
+func ListBotSignatures() ([]BotSignature, error) {
+	var signatures []BotSignature
+	err := db.Order("pattern").Find(&signatures).Error
+	return signatures, err
+}
+
+// UpdateBotSignatures 在一个事务中保存增加、停用的特征，并删除 remove 中的自定义特征
+func UpdateBotSignatures(upsert []BotSignature, remove []string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if len(remove) > 0 {
+			if err := tx.Where("pattern IN ?", remove).Delete(&BotSignature{}).Error; err != nil {
+				return err
+			}
+		}
+		if len(upsert) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.AssignmentColumns([]string{"disabled", "updated_by", "update_time"}),
+		}).Create(&upsert).Error
+	})
+}
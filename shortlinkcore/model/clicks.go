@@ -0,0 +1,122 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LinkClicks 短链接的累计点击量，由各实例定期把 Redis 中的增量写入，Redis 数据丢失时据此重建
+type LinkClicks struct {
+	ShortURL   string    `gorm:"primaryKey;size:191"`
+	Domain     string    `gorm:"primaryKey;size:191;default:''"`
+	Clicks     int64     `gorm:"not null;default:0"`
+	UpdateTime time.Time `gorm:"autoUpdateTime"`
+}
+
+func (LinkClicks) TableName() string {
+	return "link_clicks"
+}
+
+// ClickFlushBatch 已写入的点击增量批次，同一批次重复写入时据此跳过，保证写入幂等
+type ClickFlushBatch struct {
+	ID         string    `gorm:"primaryKey;size:36"`
+	Links      int       // 批次中的短链接数量
+	CreateTime time.Time `gorm:"autoCreateTime;index"`
+}
+
+func (ClickFlushBatch) TableName() string {
+	return "click_flush_batch"
+}
+
+// addLinkClicks 已存在的行累加点击量
+var addLinkClicks = clause.OnConflict{
+	DoUpdates: clause.Assignments(map[string]any{
+		"clicks":      gorm.Expr("clicks + VALUES(clicks)"),
+		"update_time": gorm.Expr("VALUES(update_time)"),
+	}),
+}
+
+// linkClicksRows 将按 LinkKey 索引的点击量转换为表中的行
+func linkClicksRows(clicks map[string]int64) []LinkClicks {
+	rows := make([]LinkClicks, 0, len(clicks))
+	for key, n := range clicks {
+		domain, shortURL := SplitLinkKey(key)
+		rows = append(rows, LinkClicks{ShortURL: shortURL, Domain: domain, Clicks: n})
+	}
+	return rows
+}
+
+// ApplyClickDeltas 在一个事务中累加一批点击增量（按 LinkKey 索引）并记录批次
+// 批次已经写入过时不做任何操作，返回 false
+func ApplyClickDeltas(batchID string, deltas map[string]int64) (bool, error) {
+	applied := false
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&ClickFlushBatch{ID: batchID, Links: len(deltas)})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		if rows := linkClicksRows(deltas); len(rows) > 0 {
+			if err := tx.Clauses(addLinkClicks).CreateInBatches(rows, 500).Error; err != nil {
+				return err
+			}
+		}
+		applied = true
+		return nil
+	})
+	return applied, err
+}
+
+// DeleteClickFlushBatches 删除早于 before 的批次记录，这些批次不会再被重复写入
+func DeleteClickFlushBatches(before time.Time) error {
+	return db.Where("create_time < ?", before).Delete(&ClickFlushBatch{}).Error
+}
+
+// HasLinkClicks 是否已经保存过点击量
+func HasLinkClicks() (bool, error) {
+	var row LinkClicks
+	err := db.Select("short_url").Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// SeedLinkClicks 首次启用时用 Redis 中已有的点击量初始化（按 LinkKey 索引）
+func SeedLinkClicks(clicks map[string]int64) error {
+	rows := linkClicksRows(clicks)
+	if len(rows) == 0 {
+		return nil
+	}
+	return db.Clauses(addLinkClicks).CreateInBatches(rows, 500).Error
+}
+
+// SavedClicks 保存的点击量和对应的短链接，用于重建 Redis
+type SavedClicks struct {
+	ShortURL    string
+	Domain      string
+	Clicks      int64
+	OriginalURL string
+	UserID      string
+	WorkspaceID string
+	Trashed     bool // 在回收站中，不计入排行榜
+}
+
+// Key 返回短链接的 LinkKey
+func (c SavedClicks) Key() string {
+	return LinkKey(c.Domain, c.ShortURL)
+}
+
+// ListSavedClicks 按主键顺序分页读取保存的点击量，从 (afterShortURL, afterDomain) 之后开始
+// 已彻底删除的短链接不返回
+func ListSavedClicks(afterShortURL, afterDomain string, limit int) ([]SavedClicks, error) {
+	var rows []SavedClicks
+	err := db.Raw(`SELECT c.short_url, c.domain, c.clicks, m.original_url, m.user_id, m.workspace_id,
+			m.deleted_at IS NOT NULL AS trashed
+		FROM link_clicks c JOIN url_mapping m ON m.short_url = c.short_url AND m.domain = c.domain
+		WHERE c.clicks > 0 AND (c.short_url, c.domain) > (?, ?)
+		ORDER BY c.short_url, c.domain LIMIT ?`, afterShortURL, afterDomain, limit).Scan(&rows).Error
+	return rows, err
+}
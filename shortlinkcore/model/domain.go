@@ -0,0 +1,118 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 域名验证方式
+const (
+	VerifyDNS  = "dns"  // 添加 TXT 记录
+	VerifyHTTP = "http" // 在网站根目录放置验证文件
+)
+
+// ErrDomainTaken 域名已被其他用户验证
+var ErrDomainTaken = errors.New("域名已被其他用户验证")
+
+// Domain 用户的自定义短链接域名，验证通过后才能用于生成短链接
+// 同一域名可以有多个用户登记，先验证通过的成为归属者，其他未验证的登记随之删除
+type Domain struct {
+	ID           uint    `gorm:"primaryKey"`
+	Host         string  `gorm:"size:191;uniqueIndex:idx_domain_host_user"` // 小写、不含端口
+	UserID       string  `gorm:"size:64;uniqueIndex:idx_domain_host_user;index"`
+	VerifiedHost *string `gorm:"size:191;uniqueIndex"` // 验证通过后等于 Host，未验证时为 NULL，保证同一域名只有一个归属者
+	IsDefault    bool    // 是否为该用户创建短链接时的默认域名
+	VerifyMethod string  `gorm:"size:16"`
+	VerifyToken  string  `gorm:"size:64"`
+	Verified     bool
+	VerifiedAt   *time.Time
+	CreateTime   time.Time `gorm:"autoCreateTime"`
+}
+
+func (Domain) TableName() string {
+	return "domain"
+}
+
+// CreateDomain 登记新域名
+func CreateDomain(d *Domain) error {
+	return db.Create(d).Error
+}
+
+// GetVerifiedDomain 查询验证通过的域名，没有时返回 gorm.ErrRecordNotFound
+func GetVerifiedDomain(host string) (*Domain, error) {
+	var d Domain
+	if err := db.First(&d, "verified_host = ?", host).Error; err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// GetUserDomain 查询用户登记的域名，包括未验证的
+func GetUserDomain(userID, host string) (*Domain, error) {
+	var d Domain
+	if err := db.First(&d, "host = ? AND user_id = ?", host, userID).Error; err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// ListUserDomains 获取用户登记的所有域名
+func ListUserDomains(userID string) ([]Domain, error) {
+	var domains []Domain
+	err := db.Where("user_id = ?", userID).Order("id").Find(&domains).Error
+	return domains, err
+}
+
+// GetUserDefaultDomain 获取用户的默认域名，没有设置时返回 gorm.ErrRecordNotFound
+func GetUserDefaultDomain(userID string) (*Domain, error) {
+	var d Domain
+	if err := db.First(&d, "user_id = ? AND is_default = ? AND verified = ?", userID, true, true).Error; err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// MarkDomainVerified 标记域名验证通过，同时删除其他用户对该域名未验证的登记
+// 域名已被其他用户验证时返回 ErrDomainTaken
+func MarkDomainVerified(d *Domain) error {
+	now := time.Now()
+	return db.Transaction(func(tx *gorm.DB) error {
+		var taken int64
+		if err := tx.Model(&Domain{}).Where("verified_host = ? AND id <> ?", d.Host, d.ID).Count(&taken).Error; err != nil {
+			return err
+		}
+		if taken > 0 {
+			return ErrDomainTaken
+		}
+		err := tx.Model(&Domain{}).Where("id = ?", d.ID).
+			Updates(map[string]any{"verified": true, "verified_at": &now, "verified_host": d.Host}).Error
+		if err != nil {
+			// 并发验证时由唯一索引保证只有一个用户成功，失败的一方在事务外确认是否已被占用
+			if _, getErr := GetVerifiedDomain(d.Host); getErr == nil {
+				return ErrDomainTaken
+			}
+			return err
+		}
+		return tx.Where("host = ? AND id <> ? AND verified = ?", d.Host, d.ID, false).Delete(&Domain{}).Error
+	})
+}
+
+// SetDefaultDomain 将域名设为用户的默认域名，host 为空表示恢复使用系统默认域名
+func SetDefaultDomain(userID, host string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Domain{}).Where("user_id = ?", userID).Update("is_default", false).Error; err != nil {
+			return err
+		}
+		if host == "" {
+			return nil
+		}
+		return tx.Model(&Domain{}).Where("user_id = ? AND host = ?", userID, host).Update("is_default", true).Error
+	})
+}
+
+// DeleteDomain 删除域名
+func DeleteDomain(id uint) error {
+	return db.Delete(&Domain{}, id).Error
+}
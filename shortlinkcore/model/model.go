@@ -1,7 +1,9 @@
 package model
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"gorm.io/driver/mysql"
@@ -17,38 +19,136 @@ func GetDB() *gorm.DB {
 func InitDB(dataSource string) error {
 	var err error
 	db, err = gorm.Open(mysql.Open(dataSource), &gorm.Config{})
+	if err != nil {
+		return err
+	}
 	// 自动建表
-	_ = db.AutoMigrate(&URLMapping{})
-	return err
+	_ = db.AutoMigrate(&URLMapping{}, &LinkPreview{}, &Domain{}, &Tag{}, &LinkTag{}, &BatchJob{}, &BatchJobItem{}, &Webhook{}, &WebhookDelivery{}, &LinkClicks{}, &ClickFlushBatch{}, &BotSignature{}, &PurgedLink{})
+	// 旧表的主键只有 short_url，升级为 (short_url, domain)
+	if err := migratePrimaryKey(URLMapping{}.TableName(), "short_url", "domain"); err != nil {
+		return err
+	}
+	return migratePrimaryKey(LinkPreview{}.TableName(), "short_url", "domain")
+}
+
+// migratePrimaryKey 将表的主键调整为指定的列，已经一致时不做任何操作
+func migratePrimaryKey(table string, columns ...string) error {
+	var current []string
+	err := db.Raw(`SELECT column_name FROM information_schema.key_column_usage
+		WHERE table_schema = DATABASE() AND table_name = ? AND constraint_name = 'PRIMARY'
+		ORDER BY ordinal_position`, table).Scan(&current).Error
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(strings.Join(current, ","), strings.Join(columns, ",")) {
+		return nil
+	}
+	sql := fmt.Sprintf("ALTER TABLE `%s` DROP PRIMARY KEY, ADD PRIMARY KEY (`%s`)", table, strings.Join(columns, "`, `"))
+	return db.Exec(sql).Error
 }
 
+// LinkKey 返回短链接在缓存、布隆过滤器和点击统计中的唯一标识
+// 默认域名（domain 为空）下就是短链接本身，与多域名之前的数据保持兼容
+func LinkKey(domain, shortURL string) string {
+	if domain == "" {
+		return shortURL
+	}
+	return domain + "/" + shortURL
+}
+
+// SplitLinkKey 将 LinkKey 拆回域名和短链接，短链接中不包含 "/"
+func SplitLinkKey(key string) (domain, shortURL string) {
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		return key[:i], key[i+1:]
+	}
+	return "", key
+}
+
+// URLMapping 短链接映射，同一个短链接在不同域名下互不影响
 type URLMapping struct {
-	ShortURL    string `gorm:"primaryKey"`
-	OriginalURL string `gorm:"not null"`
-	UserID      string
-	Status      string    // pending / active / blocked
-	BlockReason string    // 可选字段，如 "Phishing"
-	CreateTime  time.Time `gorm:"autoCreateTime"`
+	ShortURL     string `gorm:"primaryKey"`
+	Domain       string `gorm:"primaryKey;default:''"` // 短链接域名，为空表示系统默认域名
+	OriginalURL  string `gorm:"not null"`
+	UserID       string
+	WorkspaceID  string     `gorm:"size:64;index;default:''"` // 所属工作区，为空表示个人短链接
+	Status       string     // pending / active / blocked
+	BlockReason  string     // 可选字段，如 "Phishing"
+	Rules        string     `gorm:"type:text"` // 跳转规则（JSON），按顺序匹配
+	Variants     string     `gorm:"type:text"` // A/B 分流目标（JSON），按权重选择
+	ExpireAt     *time.Time // 过期时间，为空表示永不过期
+	RedirectType int        // 跳转状态码：301 / 302 / 307 / 308，0 表示默认 302
+	CreateTime   time.Time  `gorm:"autoCreateTime"`
+	// 移入回收站的时间，回收站中的短链接不能访问，也不会被重新分配，保留期过后彻底删除
+	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
 
 func (URLMapping) TableName() string {
 	return "url_mapping" // 显式指定表名
 }
 
-func IsOriginalURLExist(originalURL string) string {
+// Key 返回短链接的唯一标识
+func (m *URLMapping) Key() string {
+	return LinkKey(m.Domain, m.ShortURL)
+}
 
+// Expired 短链接是否已过期
+func (m *URLMapping) Expired(now time.Time) bool {
+	return m.ExpireAt != nil && !now.Before(*m.ExpireAt)
+}
+
+// linkWhere 按 (域名, 短链接) 定位单个短链接
+func linkWhere(domain, shortURL string) *gorm.DB {
+	return db.Model(&URLMapping{}).Where("short_url = ? AND domain = ?", shortURL, domain)
+}
+
+// Owner 短链接的归属范围：指定了工作区时为工作区的所有短链接，否则为用户的个人短链接
+type Owner struct {
+	UserID      string
+	WorkspaceID string
+}
+
+// scope 将查询限定在归属范围内
+func (o Owner) scope(query *gorm.DB) *gorm.DB {
+	if o.WorkspaceID != "" {
+		return query.Where("workspace_id = ?", o.WorkspaceID)
+	}
+	return query.Where("user_id = ? AND workspace_id = ''", o.UserID)
+}
+
+// IsOriginalURLExist 查找归属范围内、同一域名下已经存在的普通短链接，不复用其他用户或工作区的短链接
+// 带跳转规则、分流、过期时间、自定义跳转状态码或已封禁的短链接不复用，没有时返回空字符串
+func IsOriginalURLExist(owner Owner, domain, originalURL string) (string, error) {
 	var mapping URLMapping
-	fmt.Println(originalURL)
-	db.First(&mapping, "original_url = ?", originalURL)
-	fmt.Println(mapping.ShortURL)
-	return mapping.ShortURL
+	err := owner.scope(db.Select("short_url")).
+		Where("original_url = ? AND domain = ?", originalURL, domain).
+		// 旧数据中后加的列可能为 NULL
+		Where("COALESCE(rules, '') = '' AND COALESCE(variants, '') = '' AND expire_at IS NULL").
+		Where("COALESCE(redirect_type, 0) = 0 AND COALESCE(status, '') <> ?", "blocked").
+		Take(&mapping).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return mapping.ShortURL, nil
 }
 
+// GetAllShortUrls 获取所有短链接的唯一标识（见 LinkKey），用于预热布隆过滤器
+// 包括回收站中和已彻底删除的短链接，生成短链接时不会重新分配它们
 func GetAllShortUrls() []string {
-	var urls []string
-	// 获取所有短链接
-	db.Model(&URLMapping{}).Pluck("short_url", &urls) // pluck是gorm的函数，用于获取单个字段的所有数据
-	return urls
+	var mappings []URLMapping
+	db.Unscoped().Select("short_url", "domain").Find(&mappings)
+	var purged []PurgedLink
+	db.Select("short_url", "domain").Find(&purged)
+	keys := make([]string, 0, len(mappings)+len(purged))
+	for i := range mappings {
+		keys = append(keys, mappings[i].Key())
+	}
+	for i := range purged {
+		keys = append(keys, LinkKey(purged[i].Domain, purged[i].ShortURL))
+	}
+	return keys
 }
 
 // SaveURLMapping 保存短链接与原始URL的映射关系
@@ -79,10 +179,14 @@ func SaveURLMappingWithUserID(shortURL, originalURL, userID string) error {
 	return result.Error
 }
 
+// SaveMapping 保存完整的短链接映射
+func SaveMapping(mapping *URLMapping) error {
+	return db.Create(mapping).Error
+}
+
 // 更新短链接状态（status）和描述（blockReason）
-func UpdateStatus(shortURL, status, blockReason string) error {
-	result := db.Model(&URLMapping{}).
-		Where("short_url = ?", shortURL).
+func UpdateStatus(domain, shortURL, status, blockReason string) error {
+	result := linkWhere(domain, shortURL).
 		Updates(map[string]any{
 			"status":       status,
 			"block_reason": blockReason,
@@ -108,8 +212,96 @@ func GetOriginalURL(shortURL string) (string, error) {
 	return mapping.OriginalURL, nil
 }
 
+// GetMapping 获取短链接的完整映射
+func GetMapping(domain, shortURL string) (*URLMapping, error) {
+	var mapping URLMapping
+	if err := linkWhere(domain, shortURL).First(&mapping).Error; err != nil {
+		return nil, err
+	}
+	return &mapping, nil
+}
+
+// IsCodeTaken 短链接是否已被占用，回收站中和已彻底删除的短链接也算占用
+func IsCodeTaken(domain, shortURL string) (bool, error) {
+	var count int64
+	err := db.Unscoped().Model(&URLMapping{}).Where("short_url = ? AND domain = ?", shortURL, domain).Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+	err = db.Model(&PurgedLink{}).Where("short_url = ? AND domain = ?", shortURL, domain).Count(&count).Error
+	return count > 0, err
+}
+
+// GetOwnedMapping 获取归属范围内的短链接，用于修改前的归属校验
+func GetOwnedMapping(owner Owner, domain, shortURL string) (*URLMapping, error) {
+	var mapping URLMapping
+	if err := owner.scope(linkWhere(domain, shortURL)).First(&mapping).Error; err != nil {
+		return nil, err
+	}
+	return &mapping, nil
+}
+
+// FindMappings 按 (short_url, domain) 批量查询短链接，不存在的忽略
+func FindMappings(mappings []URLMapping) ([]URLMapping, error) {
+	if len(mappings) == 0 {
+		return nil, nil
+	}
+	var result []URLMapping
+	err := db.Where("(short_url, domain) IN ?", linkPairs(mappings)).Find(&result).Error
+	return result, err
+}
+
+// UpdateRules 更新短链接的跳转规则
+func UpdateRules(domain, shortURL, rules string) error {
+	return linkWhere(domain, shortURL).Update("rules", rules).Error
+}
+
+// UpdateVariants 更新短链接的 A/B 分流配置
+func UpdateVariants(domain, shortURL, variants string) error {
+	return linkWhere(domain, shortURL).Update("variants", variants).Error
+}
+
+// UpdateOriginalURL 修改短链接的目标地址
+func UpdateOriginalURL(domain, shortURL, originalURL string) error {
+	return linkWhere(domain, shortURL).Update("original_url", originalURL).Error
+}
+
+// ListLinks 分页查询归属范围内的短链接，最新创建的在前
+func ListLinks(owner Owner, offset, limit int) ([]URLMapping, int64, error) {
+	var total int64
+	if err := owner.scope(db.Model(&URLMapping{})).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var mappings []URLMapping
+	err := owner.scope(db.Model(&URLMapping{})).
+		Order("create_time DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&mappings).Error
+	return mappings, total, err
+}
+
+// ListLinksAfter 按 (short_url, domain) 顺序分页读取归属范围内的短链接，从游标之后开始
+// 使用游标而不是 OFFSET，导出大量数据时每页的查询代价不变
+func ListLinksAfter(owner Owner, afterShortURL, afterDomain string, limit int) ([]URLMapping, error) {
+	var mappings []URLMapping
+	err := owner.scope(db.Model(&URLMapping{})).
+		Where("(short_url, domain) > (?, ?)", afterShortURL, afterDomain).
+		Order("short_url, domain").
+		Limit(limit).
+		Find(&mappings).Error
+	return mappings, err
+}
+
+// CountDomainLinks 统计域名下的短链接数量，包括回收站中的短链接
+func CountDomainLinks(domain string) (int64, error) {
+	var count int64
+	err := db.Unscoped().Model(&URLMapping{}).Where("domain = ?", domain).Count(&count).Error
+	return count, err
+}
+
 // 删除用户的所有短链
-// DeleteUserURLs 删除指定用户的所有短链接
+// DeleteUserURLs 将指定用户的所有短链接移入回收站
 // 参数：
 //   - userID: 用户ID
 //
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// LinkPreview 短链接目标页面的预览信息
+type LinkPreview struct {
+	ShortURL     string `gorm:"primaryKey"`
+	Domain       string `gorm:"primaryKey;default:''"`
+	Title        string `gorm:"size:255"`
+	Description  string `gorm:"type:text"`
+	Image        string `gorm:"type:text"`
+	CanonicalURL string `gorm:"type:text"`
+	Summary      string `gorm:"type:text"` // 摘要，异步生成，可能为空
+	SourceURL    string `gorm:"type:text"` // 抓取时的目标地址，目标修改后用于判断预览是否过期
+	FetchedAt    time.Time
+}
+
+func (LinkPreview) TableName() string {
+	return "link_preview"
+}
+
+// SavePreview 保存预览信息，已存在时覆盖
+func SavePreview(p *LinkPreview) error {
+	return db.Clauses(clause.OnConflict{UpdateAll: true}).Create(p).Error
+}
+
+// Key 返回预览所属短链接的唯一标识
+func (p *LinkPreview) Key() string {
+	return LinkKey(p.Domain, p.ShortURL)
+}
+
+// GetPreview 获取短链接的预览信息
+func GetPreview(domain, shortURL string) (*LinkPreview, error) {
+	var p LinkPreview
+	if err := db.First(&p, "short_url = ? AND domain = ?", shortURL, domain).Error; err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// UpdatePreviewSummary 保存摘要
+// 只更新同一目标地址的预览，避免目标修改后旧页面的摘要覆盖新预览
+func UpdatePreviewSummary(domain, shortURL, sourceURL, summary string) error {
+	return db.Model(&LinkPreview{}).
+		Where("short_url = ? AND domain = ? AND source_url = ?", shortURL, domain, sourceURL).
+		Update("summary", summary).Error
+}
+
+// DeletePreviews 删除一批短链接的预览信息
+func DeletePreviews(mappings []URLMapping) error {
+	if len(mappings) == 0 {
+		return nil
+	}
+	return db.Where("(short_url, domain) IN ?", linkPairs(mappings)).Delete(&LinkPreview{}).Error
+}
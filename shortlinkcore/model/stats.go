@@ -0,0 +1,129 @@
+package model
+
+import (
+	"time"
+)
+
+// LinkClickHourly 短链接每小时的点击量，由 analyticsservice 消费点击事件后写入，这里只读取
+type LinkClickHourly struct {
+	LinkKey   string
+	Hour      time.Time
+	Clicks    int64
+	BotClicks int64 // 机器人点击量，不计入 Clicks
+}
+
+func (LinkClickHourly) TableName() string {
+	return "link_click_hourly"
+}
+
+// LinkClickDaily 短链接每天的点击量，由 analyticsservice 写入
+type LinkClickDaily struct {
+	LinkKey   string
+	Day       time.Time
+	Clicks    int64
+	BotClicks int64 // 机器人点击量，不计入 Clicks
+}
+
+func (LinkClickDaily) TableName() string {
+	return "link_click_daily"
+}
+
+// GetHourlyClicks 获取 [from, to) 内每小时的点击量，按小时开始时间（Unix 秒）索引
+func GetHourlyClicks(linkKey string, from, to time.Time) (map[int64]int64, error) {
+	var rows []LinkClickHourly
+	err := db.Where("link_key = ? AND hour >= ? AND hour < ?", linkKey, from, to).Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int64]int64, len(rows))
+	for _, r := range rows {
+		result[r.Hour.Unix()] = r.Clicks
+	}
+	return result, nil
+}
+
+// GetHourlyBotClicks 获取 [from, to) 内按小时统计的机器人点击量之和
+func GetHourlyBotClicks(linkKey string, from, to time.Time) (int64, error) {
+	var total int64
+	err := db.Model(&LinkClickHourly{}).
+		Select("COALESCE(SUM(bot_clicks), 0)").
+		Where("link_key = ? AND hour >= ? AND hour < ?", linkKey, from, to).
+		Scan(&total).Error
+	return total, err
+}
+
+// GetDailyBotClicks 获取 [from, to) 内按天统计的机器人点击量之和
+func GetDailyBotClicks(linkKey string, from, to time.Time) (int64, error) {
+	var total int64
+	err := db.Model(&LinkClickDaily{}).
+		Select("COALESCE(SUM(bot_clicks), 0)").
+		Where("link_key = ? AND day >= ? AND day < ?", linkKey, from, to).
+		Scan(&total).Error
+	return total, err
+}
+
+// GetDailyClicks 获取 [from, to) 内每天的点击量，按当天零点（Unix 秒）索引
+func GetDailyClicks(linkKey string, from, to time.Time) (map[int64]int64, error) {
+	var rows []LinkClickDaily
+	err := db.Where("link_key = ? AND day >= ? AND day < ?", linkKey, from, to).Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int64]int64, len(rows))
+	for _, r := range rows {
+		result[r.Day.Unix()] = r.Clicks
+	}
+	return result, nil
+}
+
+// 点击分布的维度，与 analyticsservice 写入的取值一致
+const (
+	DimensionReferer = "referer"
+	DimensionBrowser = "browser"
+	DimensionOS      = "os"
+	DimensionDevice  = "device"
+	DimensionCountry = "country"
+)
+
+// DimensionOther analyticsservice 在取值数量超出上限后合并的取值，查询时计入其他
+const DimensionOther = "(other)"
+
+// LinkClickDimension 短链接每天按维度（国家、设备、来源、浏览器、操作系统）的点击量，由 analyticsservice 写入
+type LinkClickDimension struct {
+	LinkKey   string
+	Day       time.Time
+	Dimension string
+	Value     string
+	Clicks    int64
+}
+
+func (LinkClickDimension) TableName() string {
+	return "link_click_dimension"
+}
+
+// DimensionCount 某个维度取值的点击量
+type DimensionCount struct {
+	Value  string
+	Clicks int64
+}
+
+// GetTopDimensionValues 获取 [from, to) 内某个维度点击量最高的 limit 个取值，同时返回该维度的总点击量
+// 合并后的 DimensionOther 不作为取值返回，只计入总点击量
+func GetTopDimensionValues(linkKey, dimension string, from, to time.Time, limit int) ([]DimensionCount, int64, error) {
+	query := db.Model(&LinkClickDimension{}).
+		Where("link_key = ? AND dimension = ? AND day >= ? AND day < ?", linkKey, dimension, from, to)
+	var total int64
+	if err := query.Select("COALESCE(SUM(clicks), 0)").Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var items []DimensionCount
+	err := db.Model(&LinkClickDimension{}).
+		Select("value, SUM(clicks) AS clicks").
+		Where("link_key = ? AND dimension = ? AND day >= ? AND day < ?", linkKey, dimension, from, to).
+		Where("value <> ?", DimensionOther).
+		Group("value").
+		Order("clicks DESC, value").
+		Limit(limit).
+		Scan(&items).Error
+	return items, total, err
+}
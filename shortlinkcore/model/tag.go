@@ -0,0 +1,204 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 标签类型：普通标签用于分类，活动用于投放效果统计
+const (
+	TagKindTag      = "tag"
+	TagKindCampaign = "campaign"
+)
+
+// Tag 用户的标签或活动，同一用户下同类型名称唯一
+type Tag struct {
+	ID         uint      `gorm:"primaryKey"`
+	UserID     string    `gorm:"size:64;uniqueIndex:idx_tag_user_kind_name"`
+	Kind       string    `gorm:"size:16;uniqueIndex:idx_tag_user_kind_name"`
+	Name       string    `gorm:"size:64;uniqueIndex:idx_tag_user_kind_name"`
+	CreateTime time.Time `gorm:"autoCreateTime"`
+}
+
+func (Tag) TableName() string {
+	return "tag"
+}
+
+// LinkTag 短链接与标签的多对多关系
+type LinkTag struct {
+	TagID      uint      `gorm:"primaryKey"`
+	ShortURL   string    `gorm:"primaryKey;size:64;index:idx_link_tag_link"`
+	Domain     string    `gorm:"primaryKey;size:191;default:'';index:idx_link_tag_link"`
+	CreateTime time.Time `gorm:"autoCreateTime"`
+}
+
+func (LinkTag) TableName() string {
+	return "link_tag"
+}
+
+// TagWithCount 标签及其下的短链接数量
+type TagWithCount struct {
+	Tag
+	LinkCount int64
+}
+
+// linkPairs 将短链接转换为 (short_url, domain) 列表，用于 IN 查询
+func linkPairs(mappings []URLMapping) [][]any {
+	pairs := make([][]any, 0, len(mappings))
+	for _, m := range mappings {
+		pairs = append(pairs, []any{m.ShortURL, m.Domain})
+	}
+	return pairs
+}
+
+// GetOrCreateTags 获取用户的标签，不存在的自动创建
+func GetOrCreateTags(userID, kind string, names []string) ([]Tag, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	tags := make([]Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, Tag{UserID: userID, Kind: kind, Name: name})
+	}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error; err != nil {
+		return nil, err
+	}
+	// 已存在的标签插入时被忽略，重新查询拿到ID
+	var result []Tag
+	err := db.Where("user_id = ? AND kind = ? AND name IN ?", userID, kind, names).Find(&result).Error
+	return result, err
+}
+
+// GetTag 按名称获取用户的标签
+func GetTag(userID, kind, name string) (*Tag, error) {
+	var tag Tag
+	if err := db.First(&tag, "user_id = ? AND kind = ? AND name = ?", userID, kind, name).Error; err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// ListUserTags 查询用户的标签及各标签下的短链接数量，kind 为空时返回所有类型
+func ListUserTags(userID, kind string) ([]TagWithCount, error) {
+	query := db.Model(&Tag{}).
+		Select("tag.*, COUNT(url_mapping.short_url) AS link_count").
+		Joins("LEFT JOIN link_tag ON link_tag.tag_id = tag.id").
+		// 不统计回收站中的短链接
+		Joins("LEFT JOIN url_mapping ON url_mapping.short_url = link_tag.short_url AND url_mapping.domain = link_tag.domain AND url_mapping.deleted_at IS NULL").
+		Where("tag.user_id = ?", userID).
+		Group("tag.id").
+		Order("tag.kind, tag.name")
+	if kind != "" {
+		query = query.Where("tag.kind = ?", kind)
+	}
+	var tags []TagWithCount
+	err := query.Scan(&tags).Error
+	return tags, err
+}
+
+// GetOwnedMappings 从给定的短链接中筛选出归属范围内的短链接
+func GetOwnedMappings(owner Owner, mappings []URLMapping) ([]URLMapping, error) {
+	if len(mappings) == 0 {
+		return nil, nil
+	}
+	var result []URLMapping
+	err := owner.scope(db.Model(&URLMapping{})).
+		Where("(short_url, domain) IN ?", linkPairs(mappings)).
+		Find(&result).Error
+	return result, err
+}
+
+// TagLinks 为一批短链接打上一批标签，已存在的关系忽略，返回新增的关系数量
+func TagLinks(tags []Tag, mappings []URLMapping) (int64, error) {
+	if len(tags) == 0 || len(mappings) == 0 {
+		return 0, nil
+	}
+	rows := make([]LinkTag, 0, len(tags)*len(mappings))
+	for _, t := range tags {
+		for _, m := range mappings {
+			rows = append(rows, LinkTag{TagID: t.ID, ShortURL: m.ShortURL, Domain: m.Domain})
+		}
+	}
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&rows, 500)
+	return result.RowsAffected, result.Error
+}
+
+// UntagLinks 移除一批短链接上的一批标签，返回删除的关系数量
+func UntagLinks(tags []Tag, mappings []URLMapping) (int64, error) {
+	if len(tags) == 0 || len(mappings) == 0 {
+		return 0, nil
+	}
+	tagIDs := make([]uint, 0, len(tags))
+	for _, t := range tags {
+		tagIDs = append(tagIDs, t.ID)
+	}
+	result := db.Where("tag_id IN ? AND (short_url, domain) IN ?", tagIDs, linkPairs(mappings)).
+		Delete(&LinkTag{})
+	return result.RowsAffected, result.Error
+}
+
+// tagLinksQuery 标签下的短链接
+func tagLinksQuery(owner Owner, tagID uint) *gorm.DB {
+	return owner.scope(db.Model(&URLMapping{})).
+		Joins("JOIN link_tag ON link_tag.short_url = url_mapping.short_url AND link_tag.domain = url_mapping.domain").
+		Where("link_tag.tag_id = ?", tagID)
+}
+
+// ListTagLinks 分页查询标签下归属范围内的短链接，按打标签时间倒序
+func ListTagLinks(owner Owner, tagID uint, offset, limit int) ([]URLMapping, int64, error) {
+	var total int64
+	if err := tagLinksQuery(owner, tagID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var mappings []URLMapping
+	err := tagLinksQuery(owner, tagID).
+		Select("url_mapping.*").
+		Order("link_tag.create_time DESC").
+		Offset(offset).Limit(limit).
+		Find(&mappings).Error
+	return mappings, total, err
+}
+
+// GetTagMappings 查询标签下归属范围内的所有短链接
+func GetTagMappings(owner Owner, tagID uint) ([]URLMapping, error) {
+	var mappings []URLMapping
+	err := tagLinksQuery(owner, tagID).Select("url_mapping.*").Find(&mappings).Error
+	return mappings, err
+}
+
+// DeleteLinkTags 删除一批短链接的所有标签关系
+func DeleteLinkTags(mappings []URLMapping) error {
+	if len(mappings) == 0 {
+		return nil
+	}
+	return db.Where("(short_url, domain) IN ?", linkPairs(mappings)).Delete(&LinkTag{}).Error
+}
+
+// GetLinkTagNames 查询一批短链接的普通标签名称，key 为短链接的唯一标识（见 LinkKey）
+func GetLinkTagNames(mappings []URLMapping) (map[string][]string, error) {
+	if len(mappings) == 0 {
+		return nil, nil
+	}
+	var rows []struct {
+		ShortURL string
+		Domain   string
+		Name     string
+	}
+	err := db.Model(&LinkTag{}).
+		Select("link_tag.short_url, link_tag.domain, tag.name").
+		Joins("JOIN tag ON tag.id = link_tag.tag_id").
+		Where("tag.kind = ? AND (link_tag.short_url, link_tag.domain) IN ?", TagKindTag, linkPairs(mappings)).
+		Order("tag.name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string][]string)
+	for _, r := range rows {
+		key := LinkKey(r.Domain, r.ShortURL)
+		result[key] = append(result[key], r.Name)
+	}
+	return result, nil
+}
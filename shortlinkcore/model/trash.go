@@ -0,0 +1,119 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PurgedLink 已彻底删除的短链接，永久占用短链接不再分配
+// 点击趋势、独立访客和分析服务的汇总数据都按 LinkKey 记录，重新分配会让新短链接继承旧数据
+type PurgedLink struct {
+	ShortURL  string    `gorm:"primaryKey;size:191"`
+	Domain    string    `gorm:"primaryKey;size:191;default:''"`
+	PurgeTime time.Time `gorm:"autoCreateTime"`
+}
+
+func (PurgedLink) TableName() string {
+	return "purged_link"
+}
+
+// trashQuery 用户回收站中的个人短链接
+func trashQuery(userID string) *gorm.DB {
+	return Owner{UserID: userID}.scope(db.Unscoped().Model(&URLMapping{})).Where("deleted_at IS NOT NULL")
+}
+
+// TrashUserLinks 将用户的所有个人短链接移入回收站，返回被移入的短链接
+// 用户在工作区中创建的短链接属于工作区，不受影响
+func TrashUserLinks(userID string) ([]URLMapping, error) {
+	var mappings []URLMapping
+	personal := Owner{UserID: userID}
+	if err := personal.scope(db.Model(&URLMapping{})).Find(&mappings).Error; err != nil {
+		return nil, err
+	}
+	if len(mappings) == 0 {
+		return nil, nil
+	}
+	err := db.Where("(short_url, domain) IN ?", linkPairs(mappings)).Delete(&URLMapping{}).Error
+	return mappings, err
+}
+
+// ListTrash 分页查询回收站中的短链接，最近删除的在前
+func ListTrash(userID string, offset, limit int) ([]URLMapping, int64, error) {
+	var total int64
+	if err := trashQuery(userID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var mappings []URLMapping
+	err := trashQuery(userID).Order("deleted_at DESC").Offset(offset).Limit(limit).Find(&mappings).Error
+	return mappings, total, err
+}
+
+// GetTrashedMappings 从给定的短链接中筛选出用户回收站中的短链接，mappings 为空时返回整个回收站
+func GetTrashedMappings(userID string, mappings []URLMapping) ([]URLMapping, error) {
+	query := trashQuery(userID)
+	if len(mappings) > 0 {
+		query = query.Where("(short_url, domain) IN ?", linkPairs(mappings))
+	}
+	var result []URLMapping
+	err := query.Find(&result).Error
+	return result, err
+}
+
+// RestoreMappings 将短链接移出回收站
+func RestoreMappings(mappings []URLMapping) (int64, error) {
+	if len(mappings) == 0 {
+		return 0, nil
+	}
+	result := db.Unscoped().Model(&URLMapping{}).
+		Where("deleted_at IS NOT NULL AND (short_url, domain) IN ?", linkPairs(mappings)).
+		Update("deleted_at", nil)
+	return result.RowsAffected, result.Error
+}
+
+// ListExpiredTrash 查询在 before 之前移入回收站的短链接
+func ListExpiredTrash(before time.Time, limit int) ([]URLMapping, error) {
+	var mappings []URLMapping
+	err := db.Unscoped().Where("deleted_at < ?", before).Limit(limit).Find(&mappings).Error
+	return mappings, err
+}
+
+// PurgeMappings 彻底删除回收站中的短链接及其标签、预览和保存的点击量，并记录为已删除，返回被删除的短链接
+// 在事务中重新确认仍在回收站中，期间被恢复的短链接不受影响
+func PurgeMappings(mappings []URLMapping) ([]URLMapping, error) {
+	if len(mappings) == 0 {
+		return nil, nil
+	}
+	var purged []URLMapping
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("deleted_at IS NOT NULL AND (short_url, domain) IN ?", linkPairs(mappings)).
+			Find(&purged).Error
+		if err != nil || len(purged) == 0 {
+			return err
+		}
+		pairs := linkPairs(purged)
+		if err := tx.Unscoped().Where("(short_url, domain) IN ?", pairs).Delete(&URLMapping{}).Error; err != nil {
+			return err
+		}
+		tombstones := make([]PurgedLink, 0, len(purged))
+		for i := range purged {
+			tombstones = append(tombstones, PurgedLink{ShortURL: purged[i].ShortURL, Domain: purged[i].Domain})
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tombstones).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("(short_url, domain) IN ?", pairs).Delete(&LinkTag{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("(short_url, domain) IN ?", pairs).Delete(&LinkClicks{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("(short_url, domain) IN ?", pairs).Delete(&LinkPreview{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return purged, nil
+}
@@ -0,0 +1,172 @@
+package model
+
+import (
+	"time"
+)
+
+// Webhook 投递状态
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed" // 重试次数用完或 Webhook 已删除
+)
+
+// Webhook 用户登记的事件回调地址
+type Webhook struct {
+	ID         string    `gorm:"primaryKey;size:36"`
+	UserID     string    `gorm:"size:64;index"`
+	URL        string    `gorm:"size:2048;not null"`
+	Secret     string    `gorm:"size:64;not null"` // 签名密钥，只在创建时返回
+	Events     string    `gorm:"size:255"`         // 订阅的事件，逗号分隔
+	CreateTime time.Time `gorm:"autoCreateTime"`
+}
+
+func (Webhook) TableName() string {
+	return "webhook"
+}
+
+// WebhookDelivery 一次事件投递，同时作为待投递队列和投递日志
+// 后台协程按 next_attempt_at 接手到期的投递，接手时写入 owner 和 locked_until，
+// 处理它的实例退出后，锁定时间过期即可被其他实例重新接手
+type WebhookDelivery struct {
+	ID            string     `gorm:"primaryKey;size:36"`
+	WebhookID     string     `gorm:"size:36;index"`
+	EventID       string     `gorm:"size:36"` // 同一事件投递给多个 Webhook 时相同
+	Event         string     `gorm:"size:64"`
+	Payload       string     `gorm:"type:mediumtext"`
+	Status        string     `gorm:"size:16;index:idx_webhook_delivery_due,priority:1"`
+	Attempts      int        // 已投递的次数
+	NextAttemptAt time.Time  `gorm:"index:idx_webhook_delivery_due,priority:2"`
+	Owner         string     `gorm:"size:36"`
+	LockedUntil   *time.Time // 接手后的锁定时间
+	ResponseCode  int        // 最近一次投递的响应状态码，0 表示请求未完成
+	LastError     string     `gorm:"size:1024"`
+	ReplayOf      string     `gorm:"size:36"` // 手动重放时为原投递的ID
+	CreateTime    time.Time  `gorm:"autoCreateTime"`
+	UpdateTime    time.Time  `gorm:"autoUpdateTime"`
+	DeliveredAt   *time.Time // 投递成功的时间
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_delivery"
+}
+
+// CreateWebhook 保存 Webhook
+func CreateWebhook(webhook *Webhook) error {
+	return db.Create(webhook).Error
+}
+
+// CountWebhooks 统计用户的 Webhook 数量
+func CountWebhooks(userID string) (int64, error) {
+	var count int64
+	err := db.Model(&Webhook{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// ListWebhooks 获取用户的所有 Webhook
+func ListWebhooks(userID string) ([]Webhook, error) {
+	var webhooks []Webhook
+	err := db.Where("user_id = ?", userID).Order("create_time").Find(&webhooks).Error
+	return webhooks, err
+}
+
+// GetWebhook 获取用户的 Webhook
+func GetWebhook(userID, id string) (*Webhook, error) {
+	var webhook Webhook
+	if err := db.Where("id = ? AND user_id = ?", id, userID).First(&webhook).Error; err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// GetWebhookByID 按ID获取 Webhook，用于投递
+func GetWebhookByID(id string) (*Webhook, error) {
+	var webhook Webhook
+	if err := db.Where("id = ?", id).First(&webhook).Error; err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// DeleteWebhook 删除用户的 Webhook，投递日志保留，尚未投递的不再投递
+func DeleteWebhook(userID, id string) (bool, error) {
+	result := db.Where("id = ? AND user_id = ?", id, userID).Delete(&Webhook{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ListSubscribedWebhooks 获取用户订阅了指定事件的 Webhook
+func ListSubscribedWebhooks(userID, event string) ([]Webhook, error) {
+	var webhooks []Webhook
+	err := db.Where("user_id = ? AND FIND_IN_SET(?, events) > 0", userID, event).Find(&webhooks).Error
+	return webhooks, err
+}
+
+// CreateWebhookDeliveries 保存待投递的事件
+func CreateWebhookDeliveries(deliveries []WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return db.CreateInBatches(deliveries, 500).Error
+}
+
+// ClaimDueWebhookDeliveries 接手到期的投递，锁定到 lockedUntil
+// 先按条件写入 owner 再读出，多个实例同时接手时每条投递只会被一个实例拿到
+func ClaimDueWebhookDeliveries(owner string, now, lockedUntil time.Time, limit int) ([]WebhookDelivery, error) {
+	err := db.Exec(`UPDATE webhook_delivery SET owner = ?, locked_until = ?
+		WHERE status = ? AND next_attempt_at <= ? AND (locked_until IS NULL OR locked_until < ?)
+		ORDER BY next_attempt_at LIMIT ?`,
+		owner, lockedUntil, WebhookDeliveryPending, now, now, limit).Error
+	if err != nil {
+		return nil, err
+	}
+	var deliveries []WebhookDelivery
+	err = db.Where("owner = ? AND status = ?", owner, WebhookDeliveryPending).Find(&deliveries).Error
+	return deliveries, err
+}
+
+// SaveWebhookAttempt 保存一次投递的结果并解除锁定，已被其他实例接手时不写入
+func SaveWebhookAttempt(d *WebhookDelivery, owner string) error {
+	return db.Model(&WebhookDelivery{}).
+		Where("id = ? AND owner = ?", d.ID, owner).
+		Updates(map[string]any{
+			"status":          d.Status,
+			"attempts":        d.Attempts,
+			"next_attempt_at": d.NextAttemptAt,
+			"response_code":   d.ResponseCode,
+			"last_error":      d.LastError,
+			"delivered_at":    d.DeliveredAt,
+			"owner":           "",
+			"locked_until":    nil,
+		}).Error
+}
+
+// ReleaseWebhookDeliveries 服务退出时放回尚未投递的项，其他实例可以立即接手
+func ReleaseWebhookDeliveries(owner string) error {
+	return db.Model(&WebhookDelivery{}).
+		Where("owner = ? AND status = ?", owner, WebhookDeliveryPending).
+		Updates(map[string]any{"owner": "", "locked_until": nil}).Error
+}
+
+// ListWebhookDeliveries 分页查询 Webhook 的投递日志，最新的在前，status 为空时返回所有状态
+func ListWebhookDeliveries(webhookID, status string, offset, limit int) ([]WebhookDelivery, int64, error) {
+	query := db.Model(&WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var deliveries []WebhookDelivery
+	err := query.Order("create_time DESC, id").Offset(offset).Limit(limit).Find(&deliveries).Error
+	return deliveries, total, err
+}
+
+// GetWebhookDelivery 获取 Webhook 的一次投递
+func GetWebhookDelivery(webhookID, id string) (*WebhookDelivery, error) {
+	var delivery WebhookDelivery
+	if err := db.Where("id = ? AND webhook_id = ?", id, webhookID).First(&delivery).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
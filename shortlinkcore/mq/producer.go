@@ -36,3 +36,21 @@ func SendLog(topic, message string) {
 		Value: sarama.StringEncoder(message),
 	}
 }
+
+// SendMessage 异步发送消息，key 相同的消息进入同一个分区
+// Kafka 未初始化或发送队列已满时丢弃并返回 false，不阻塞调用方
+func SendMessage(topic, key string, value []byte) bool {
+	if producer == nil {
+		return false
+	}
+	select {
+	case producer.Input() <- &sarama.ProducerMessage{
+		Topic: topic,
+		Key:   sarama.StringEncoder(key),
+		Value: sarama.ByteEncoder(value),
+	}:
+		return true
+	default:
+		return false
+	}
+}
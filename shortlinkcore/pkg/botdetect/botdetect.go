@@ -0,0 +1,143 @@
+// 机器人流量识别模块
+package botdetect
+
+import (
+	"net"
+	"strings"
+	"sync"
+)
+
+// 识别为机器人的原因
+const (
+	ReasonUserAgent      = "user_agent"       // User-Agent 命中特征
+	ReasonEmptyUserAgent = "empty_user_agent" // 没有 User-Agent，通常是脚本
+	ReasonIPRange        = "ip_range"         // 来自配置的 IP 段（如监控服务、内部探测）
+	ReasonRepeated       = "repeated"         // 同一 IP 短时间内反复访问同一短链接
+)
+
+// DefaultSignatures 内置的机器人 User-Agent 特征，不区分大小写，包含即命中
+// 管理员可以在此基础上增加特征或停用其中的特征
+var DefaultSignatures = []string{
+	// 链接预览
+	"slackbot", "slack-imgproxy", "twitterbot", "facebookexternalhit", "facebookcatalog",
+	"linkedinbot", "discordbot", "telegrambot", "whatsapp", "skypeuripreview",
+	"pinterestbot", "redditbot", "embedly", "iframely", "applebot", "vkshare",
+	// 搜索引擎和爬虫
+	"googlebot", "bingbot", "baiduspider", "yandexbot", "duckduckbot", "360spider",
+	"bytespider", "petalbot", "semrushbot", "ahrefsbot", "mj12bot", "dotbot", "gptbot",
+	"crawler", "spider", "bot/", "bot;", "+http",
+	// 可用性监控
+	"uptimerobot", "pingdom", "statuscake", "site24x7", "newrelicpinger", "datadog",
+	"better uptime", "uptime-kuma",
+	// 命令行和 HTTP 库
+	"curl/", "wget/", "python-requests", "python-urllib", "go-http-client", "okhttp",
+	"java/", "libwww-perl", "httpclient", "axios/", "node-fetch", "headlesschrome", "phantomjs",
+}
+
+// Normalize 统一特征的格式：去掉首尾空白并转为小写
+func Normalize(signature string) string {
+	return strings.ToLower(strings.TrimSpace(signature))
+}
+
+// Effective 返回生效的特征：内置特征去掉停用的，再加上自定义的，去重后保持顺序
+func Effective(builtin, custom, disabled []string) []string {
+	skip := make(map[string]bool, len(disabled))
+	for _, s := range disabled {
+		skip[Normalize(s)] = true
+	}
+	result := make([]string, 0, len(builtin)+len(custom))
+	for _, list := range [][]string{builtin, custom} {
+		for _, s := range list {
+			s = Normalize(s)
+			if s == "" || skip[s] {
+				continue
+			}
+			skip[s] = true
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// ParseRanges 解析 CIDR 或单个 IP，单个 IP 按 /32 或 /128 处理
+func ParseRanges(ranges []string) ([]*net.IPNet, error) {
+	result := make([]*net.IPNet, 0, len(ranges))
+	for _, r := range ranges {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+		if !strings.Contains(r, "/") {
+			ip := net.ParseIP(r)
+			if ip == nil {
+				return nil, &net.ParseError{Type: "IP address", Text: r}
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			result = append(result, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(r)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, ipNet)
+	}
+	return result, nil
+}
+
+// Classifier 根据 User-Agent 特征和 IP 段识别机器人，特征列表可以在运行中更新
+type Classifier struct {
+	mu         sync.RWMutex
+	signatures []string
+	ranges     []*net.IPNet
+}
+
+// NewClassifier 创建识别器，signatures 需要已经是 Normalize 后的格式
+func NewClassifier(signatures []string, ranges []*net.IPNet) *Classifier {
+	return &Classifier{signatures: signatures, ranges: ranges}
+}
+
+// SetSignatures 替换 User-Agent 特征列表
+func (c *Classifier) SetSignatures(signatures []string) {
+	c.mu.Lock()
+	c.signatures = signatures
+	c.mu.Unlock()
+}
+
+// SetRanges 替换 IP 段
+func (c *Classifier) SetRanges(ranges []*net.IPNet) {
+	c.mu.Lock()
+	c.ranges = ranges
+	c.mu.Unlock()
+}
+
+// Classify 判断一次访问是否来自机器人
+// 返回：
+//   - string: 识别为机器人的原因，正常访问时为空
+func (c *Classifier) Classify(userAgent, ip string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if strings.TrimSpace(userAgent) == "" {
+		return ReasonEmptyUserAgent
+	}
+	lower := strings.ToLower(userAgent)
+	for _, s := range c.signatures {
+		if strings.Contains(lower, s) {
+			return ReasonUserAgent
+		}
+	}
+	if len(c.ranges) > 0 {
+		if parsed := net.ParseIP(ip); parsed != nil {
+			for _, r := range c.ranges {
+				if r.Contains(parsed) {
+					return ReasonIPRange
+				}
+			}
+		}
+	}
+	return ""
+}
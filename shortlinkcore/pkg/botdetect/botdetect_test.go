@@ -0,0 +1,59 @@
+package botdetect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifier_Classify(t *testing.T) {
+	ranges, err := ParseRanges([]string{"10.0.0.0/8", "203.0.113.7"})
+	require.NoError(t, err)
+	c := NewClassifier(Effective(DefaultSignatures, nil, nil), ranges)
+
+	tests := []struct {
+		name string
+		ua   string
+		ip   string
+		want string
+	}{
+		{"Slack 链接预览", "Slackbot-LinkExpanding 1.0 (+https://api.slack.com/robots)", "1.2.3.4", ReasonUserAgent},
+		{"Twitter 链接预览", "Twitterbot/1.0", "1.2.3.4", ReasonUserAgent},
+		{"Facebook 链接预览", "facebookexternalhit/1.1 (+http://www.facebook.com/externalhit_uatext.php)", "1.2.3.4", ReasonUserAgent},
+		{"可用性监控", "Mozilla/5.0+(compatible; UptimeRobot/2.0; http://www.uptimerobot.com/)", "1.2.3.4", ReasonUserAgent},
+		{"搜索引擎", "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", "1.2.3.4", ReasonUserAgent},
+		{"curl", "curl/8.4.0", "1.2.3.4", ReasonUserAgent},
+		{"没有 User-Agent", "", "1.2.3.4", ReasonEmptyUserAgent},
+		{"IP 段", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) Chrome/120.0", "10.1.2.3", ReasonIPRange},
+		{"单个 IP", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) Chrome/120.0", "203.0.113.7", ReasonIPRange},
+		{"桌面浏览器", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36", "1.2.3.4", ""},
+		{"微信内置浏览器", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 MicroMessenger/8.0.40", "1.2.3.4", ""},
+		{"搜狗浏览器", "Mozilla/5.0 (Linux; Android 12) AppleWebKit/537.36 Chrome/99.0 Mobile Safari/537.36 SogouMobileBrowser/11.0", "1.2.3.4", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, c.Classify(tt.ua, tt.ip))
+		})
+	}
+
+	t.Run("更新特征列表", func(t *testing.T) {
+		c.SetSignatures(Effective(DefaultSignatures, []string{" MyMonitor "}, []string{"curl/"}))
+		assert.Equal(t, ReasonUserAgent, c.Classify("mymonitor/1.0", "1.2.3.4"))
+		assert.Equal(t, "", c.Classify("curl/8.4.0", "1.2.3.4"))
+	})
+}
+
+func TestEffective(t *testing.T) {
+	got := Effective([]string{"a", "b", "c"}, []string{"D", "a", ""}, []string{"B"})
+	assert.Equal(t, []string{"a", "c", "d"}, got)
+}
+
+func TestParseRanges(t *testing.T) {
+	_, err := ParseRanges([]string{"not-an-ip"})
+	assert.Error(t, err)
+
+	ranges, err := ParseRanges([]string{"2001:db8::/32", " ", "::1"})
+	require.NoError(t, err)
+	assert.Len(t, ranges, 2)
+}
@@ -0,0 +1,105 @@
+// 域名验证模块：确认用户对自定义短链接域名拥有控制权
+// 支持两种方式：
+//   - DNS：在 _shortlink-verify.<域名> 添加 TXT 记录 shortlink-verify=<token>
+//   - HTTP：在 http://<域名>/.well-known/shortlink-verify.txt 返回 <token>
+package domainverify
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+const (
+	TXTPrefix = "_shortlink-verify."
+	TXTValue  = "shortlink-verify="
+	HTTPPath  = "/.well-known/shortlink-verify.txt"
+)
+
+var ErrNotVerified = errors.New("未找到验证记录")
+
+// TXTResolver 查询 TXT 记录，*net.Resolver 满足该接口
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// HTTPGetter 发送 GET 请求，*safehttp.Client 满足该接口
+type HTTPGetter interface {
+	Get(ctx context.Context, url string) (*http.Response, error)
+}
+
+// Verifier 域名验证器
+type Verifier struct {
+	Resolver TXTResolver
+	Client   HTTPGetter
+}
+
+// NewToken 生成随机验证 token
+func NewToken() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Instructions 返回给用户的验证说明
+func Instructions(method, host, token string) string {
+	if method == "http" {
+		return fmt.Sprintf("请确保 http://%s%s 返回内容 %s", host, HTTPPath, token)
+	}
+	return fmt.Sprintf("请为 %s%s 添加 TXT 记录，值为 %s%s", TXTPrefix, host, TXTValue, token)
+}
+
+// Verify 按指定方式验证域名
+func (v *Verifier) Verify(ctx context.Context, method, host, token string) error {
+	switch method {
+	case "dns":
+		return v.VerifyDNS(ctx, host, token)
+	case "http":
+		return v.VerifyHTTP(ctx, host, token)
+	}
+	return fmt.Errorf("不支持的验证方式: %s", method)
+}
+
+// VerifyDNS 检查 TXT 记录
+func (v *Verifier) VerifyDNS(ctx context.Context, host, token string) error {
+	records, err := v.Resolver.LookupTXT(ctx, TXTPrefix+host)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return ErrNotVerified
+		}
+		return err
+	}
+	for _, r := range records {
+		if strings.TrimSpace(r) == TXTValue+token {
+			return nil
+		}
+	}
+	return ErrNotVerified
+}
+
+// VerifyHTTP 检查验证文件
+func (v *Verifier) VerifyHTTP(ctx context.Context, host, token string) error {
+	resp, err := v.Client.Get(ctx, "http://"+host+HTTPPath)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return ErrNotVerified
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(string(body)) != token {
+		return ErrNotVerified
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package domainverify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mapResolver 本地替身：从内存中返回 TXT 记录
+type mapResolver map[string][]string
+
+func (m mapResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	records, ok := m[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return records, nil
+}
+
+// plainGetter 测试服务器在本机，使用普通客户端
+type plainGetter struct{}
+
+func (plainGetter) Get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return http.DefaultClient.Do(req)
+}
+
+func TestVerifyDNS(t *testing.T) {
+	v := &Verifier{Resolver: mapResolver{
+		"_shortlink-verify.go.example.com": {"v=spf1 -all", "shortlink-verify=abc123"},
+	}}
+	ctx := context.Background()
+
+	assert.NoError(t, v.Verify(ctx, "dns", "go.example.com", "abc123"))
+	assert.True(t, errors.Is(v.Verify(ctx, "dns", "go.example.com", "other"), ErrNotVerified))
+	assert.True(t, errors.Is(v.Verify(ctx, "dns", "missing.example.com", "abc123"), ErrNotVerified))
+}
+
+func TestVerifyHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != HTTPPath {
+			http.NotFound(w, r)
+			return
+		}
+		io.WriteString(w, "abc123\n")
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+	v := &Verifier{Client: plainGetter{}}
+	ctx := context.Background()
+
+	assert.NoError(t, v.Verify(ctx, "http", host, "abc123"))
+	assert.True(t, errors.Is(v.Verify(ctx, "http", host, "other"), ErrNotVerified))
+	assert.Error(t, v.Verify(ctx, "ftp", host, "abc123"))
+}
@@ -0,0 +1,186 @@
+// GeoIP 模块：读取本地 MaxMind 格式（.mmdb）数据库，按 IP 查询国家和地区，不依赖网络
+package geoip
+
+import (
+	"errors"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"shortLink/shortlinkcore/logger"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/oschwald/maxminddb-golang"
+	"go.uber.org/zap"
+)
+
+// 文件变更后等待写入完成再重新加载
+const reloadDelay = 500 * time.Millisecond
+
+// Location IP 对应的地理位置
+type Location struct {
+	Country string // ISO 3166-1 国家代码，如 US
+	Region  string // ISO 3166-2 地区代码，如 US-CA
+}
+
+// mmdb 中的记录结构，兼容 GeoIP2/GeoLite2 的 Country 和 City 库
+type record struct {
+	Country struct {
+		IsoCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+	Subdivisions []struct {
+		IsoCode string `maxminddb:"iso_code"`
+	} `maxminddb:"subdivisions"`
+}
+
+// Reader 支持热更新的 GeoIP 读取器
+type Reader struct {
+	path    string
+	mu      sync.RWMutex
+	db      *maxminddb.Reader
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+}
+
+// Open 打开 GeoIP 数据库并监听文件变化
+// 参数：
+//   - path: .mmdb 文件路径
+//
+// 返回：
+//   - *Reader: 读取器
+//   - error: 错误信息
+func Open(path string) (*Reader, error) {
+	db, err := maxminddb.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// 监听所在目录而不是文件本身，兼容 geoipupdate 等工具“写临时文件再重命名”的更新方式
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		db.Close()
+		return nil, err
+	}
+
+	r := &Reader{
+		path:    path,
+		db:      db,
+		watcher: watcher,
+		done:    make(chan struct{}),
+	}
+	go r.watch()
+	return r, nil
+}
+
+// watch 监听文件变更并重新加载
+func (r *Reader) watch() {
+	var timer *time.Timer
+	for {
+		select {
+		case <-r.done:
+			return
+		case event, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != filepath.Clean(r.path) {
+				continue
+			}
+			if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Rename) {
+				continue
+			}
+			// 合并短时间内的多次写入事件
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(reloadDelay, func() {
+				if err := r.Reload(); err != nil {
+					logger.Log.Error("GeoIP数据库重新加载失败", zap.String("path", r.path), zap.Error(err))
+					return
+				}
+				logger.Log.Info("GeoIP数据库已重新加载", zap.String("path", r.path))
+			})
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+			logger.Log.Warn("GeoIP文件监听出错", zap.Error(err))
+		}
+	}
+}
+
+// Reload 重新打开数据库文件，失败时保留旧数据
+func (r *Reader) Reload() error {
+	db, err := maxminddb.Open(r.path)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	old := r.db
+	r.db = db
+	r.mu.Unlock()
+	return old.Close()
+}
+
+// Lookup 查询 IP 的地理位置
+func (r *Reader) Lookup(ip string) (Location, error) {
+	parsed := net.ParseIP(strings.TrimSpace(ip))
+	if parsed == nil {
+		return Location{}, errors.New("IP地址非法")
+	}
+
+	var rec record
+	r.mu.RLock()
+	err := r.db.Lookup(parsed, &rec)
+	r.mu.RUnlock()
+	if err != nil {
+		return Location{}, err
+	}
+
+	loc := Location{Country: rec.Country.IsoCode}
+	if len(rec.Subdivisions) > 0 && rec.Subdivisions[0].IsoCode != "" && loc.Country != "" {
+		loc.Region = loc.Country + "-" + rec.Subdivisions[0].IsoCode
+	}
+	return loc, nil
+}
+
+// Close 关闭读取器
+func (r *Reader) Close() error {
+	close(r.done)
+	r.watcher.Close()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.db.Close()
+}
+
+var defaultReader *Reader
+
+// Init 初始化全局 GeoIP 读取器
+func Init(path string) error {
+	r, err := Open(path)
+	if err != nil {
+		return err
+	}
+	defaultReader = r
+	return nil
+}
+
+// Lookup 使用全局读取器查询 IP，未初始化或查询失败时返回空位置
+func Lookup(ip string) Location {
+	if defaultReader == nil || ip == "" {
+		return Location{}
+	}
+	loc, err := defaultReader.Lookup(ip)
+	if err != nil {
+		logger.Log.Debug("GeoIP查询失败", zap.String("ip", ip), zap.Error(err))
+		return Location{}
+	}
+	return loc
+}
@@ -0,0 +1,78 @@
+package geoip
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"shortLink/shortlinkcore/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func setupTestLogger() {
+	encoder := zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
+	core := zapcore.NewCore(encoder, zapcore.AddSync(io.Discard), zapcore.DebugLevel)
+	logger.Log = zap.New(core)
+}
+
+// copyFile 复制测试数据库到临时目录，避免修改 testdata
+func copyFile(t *testing.T, src, dst string) {
+	data, err := os.ReadFile(src)
+	require.NoError(t, err)
+	tmp := dst + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, data, 0644))
+	require.NoError(t, os.Rename(tmp, dst))
+}
+
+func TestLookup(t *testing.T) {
+	setupTestLogger()
+	r, err := Open("testdata/geoip-test.mmdb")
+	require.NoError(t, err)
+	defer r.Close()
+
+	loc, err := r.Lookup("1.2.3.4")
+	assert.NoError(t, err)
+	assert.Equal(t, Location{Country: "US", Region: "US-CA"}, loc)
+
+	loc, err = r.Lookup("81.2.69.160")
+	assert.NoError(t, err)
+	assert.Equal(t, Location{Country: "GB"}, loc)
+
+	loc, err = r.Lookup("2001:db8::1")
+	assert.NoError(t, err)
+	assert.Equal(t, "DE", loc.Country)
+
+	loc, err = r.Lookup("9.9.9.9")
+	assert.NoError(t, err)
+	assert.Empty(t, loc.Country)
+
+	_, err = r.Lookup("not-an-ip")
+	assert.Error(t, err)
+}
+
+func TestHotReload(t *testing.T) {
+	setupTestLogger()
+	path := filepath.Join(t.TempDir(), "geoip.mmdb")
+	copyFile(t, "testdata/geoip-test.mmdb", path)
+
+	r, err := Open(path)
+	require.NoError(t, err)
+	defer r.Close()
+
+	loc, _ := r.Lookup("1.2.3.4")
+	assert.Equal(t, "US", loc.Country)
+
+	// 以“写临时文件再重命名”的方式替换数据库
+	copyFile(t, "testdata/geoip-test-updated.mmdb", path)
+
+	assert.Eventually(t, func() bool {
+		loc, _ := r.Lookup("1.2.3.4")
+		return loc.Country == "JP"
+	}, 5*time.Second, 50*time.Millisecond)
+}
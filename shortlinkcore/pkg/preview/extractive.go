@@ -0,0 +1,157 @@
+package preview
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ExtractiveSummarizer 本地抽取式摘要：从正文中挑选得分最高的句子
+// 不依赖网络，结果只取决于输入，适合未配置大模型的环境和测试
+type ExtractiveSummarizer struct {
+	MaxRunes int // 摘要最大字符数，默认120
+}
+
+// Summarize 生成摘要
+// 句子得分为其中词语在全文出现频次的平均值，按原文顺序输出得分最高的句子
+func (s ExtractiveSummarizer) Summarize(ctx context.Context, info *Info) (string, error) {
+	maxRunes := s.MaxRunes
+	if maxRunes <= 0 {
+		maxRunes = 120
+	}
+
+	sentences := splitSentences(info.Text)
+	if len(sentences) == 0 {
+		return fallbackSummary(info, maxRunes)
+	}
+
+	// 1. 统计词频，标题中的词额外加权
+	freq := make(map[string]int)
+	for _, sentence := range sentences {
+		for _, t := range tokenize(sentence) {
+			freq[t]++
+		}
+	}
+	for _, t := range tokenize(info.Title) {
+		freq[t] += 2
+	}
+
+	// 2. 计算每个句子的得分
+	type scored struct {
+		index int
+		score float64
+	}
+	scores := make([]scored, 0, len(sentences))
+	for i, sentence := range sentences {
+		tokens := tokenize(sentence)
+		if len(tokens) == 0 {
+			continue
+		}
+		total := 0
+		for _, t := range tokens {
+			total += freq[t]
+		}
+		scores = append(scores, scored{index: i, score: float64(total) / float64(len(tokens))})
+	}
+	if len(scores) == 0 {
+		return fallbackSummary(info, maxRunes)
+	}
+	// 得分相同时靠前的句子优先，保证结果稳定
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].score > scores[j].score
+	})
+	avg := 0.0
+	for _, sc := range scores {
+		avg += sc.score
+	}
+	avg /= float64(len(scores))
+
+	// 3. 按得分挑选高于平均分的句子直到达到长度上限，再按原文顺序输出
+	var picked []int
+	length := 0
+	for _, sc := range scores {
+		n := utf8.RuneCountInString(sentences[sc.index])
+		if length > 0 && (sc.score < avg || length+n > maxRunes) {
+			continue
+		}
+		picked = append(picked, sc.index)
+		length += n
+		if length >= maxRunes {
+			break
+		}
+	}
+	sort.Ints(picked)
+
+	var b strings.Builder
+	for _, i := range picked {
+		b.WriteString(sentences[i])
+	}
+	return truncate(b.String(), maxRunes), nil
+}
+
+// fallbackSummary 没有可用正文时退化为页面描述
+func fallbackSummary(info *Info, maxRunes int) (string, error) {
+	if info.Description == "" {
+		return "", errors.New("页面没有可用于摘要的内容")
+	}
+	return truncate(info.Description, maxRunes), nil
+}
+
+// splitSentences 按中英文句末标点和换行切分句子，保留句末标点
+func splitSentences(text string) []string {
+	var sentences []string
+	var b strings.Builder
+	flush := func() {
+		if sentence := strings.TrimSpace(b.String()); utf8.RuneCountInString(sentence) >= 8 {
+			sentences = append(sentences, sentence)
+		}
+		b.Reset()
+	}
+	for _, r := range text {
+		if r == '\n' {
+			flush()
+			continue
+		}
+		b.WriteRune(r)
+		switch r {
+		case '。', '！', '？', '!', '?', '.', ';', '；':
+			flush()
+		}
+	}
+	flush()
+	return sentences
+}
+
+// tokenize 英文按单词切分，中文按相邻两字切分
+func tokenize(text string) []string {
+	var tokens []string
+	var word []rune
+	var prevHan rune
+	flushWord := func() {
+		if len(word) >= 3 {
+			tokens = append(tokens, strings.ToLower(string(word)))
+		}
+		word = word[:0]
+	}
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			flushWord()
+			if prevHan != 0 {
+				tokens = append(tokens, string([]rune{prevHan, r}))
+			}
+			prevHan = r
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			prevHan = 0
+			word = append(word, r)
+		default:
+			prevHan = 0
+			flushWord()
+		}
+	}
+	flushWord()
+	return tokens
+}
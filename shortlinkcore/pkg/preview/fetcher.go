@@ -0,0 +1,166 @@
+// 链接预览模块：抓取目标网页并提取标题、描述、图片和规范地址
+package preview
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"shortLink/shortlinkcore/pkg/safehttp"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	fetchTimeout   = 5 * time.Second
+	maxBodySize    = 2 << 20 // 只读取前2MB，<head> 中的元信息足够了
+	maxTitleLen    = 200
+	maxDescLen     = 500
+	maxTextLen     = 4000 // 正文只用于生成摘要，不需要全文
+	userAgentValue = "Mozilla/5.0 (compatible; ShortLinkPreview/1.0)"
+)
+
+// Info 网页预览信息
+type Info struct {
+	Title        string
+	Description  string
+	Image        string
+	CanonicalURL string
+	Text         string // 页面正文节选，用于生成摘要，不持久化
+}
+
+// 目标地址由用户提交，必须使用防 SSRF 的客户端
+var client = safehttp.New(safehttp.Options{
+	Timeout:      fetchTimeout,
+	MaxBodySize:  maxBodySize,
+	ContentTypes: []string{"text/html", "application/xhtml+xml"},
+})
+
+// Fetch 抓取网页并提取预览信息
+// 参数：
+//   - ctx: 上下文，用于取消抓取
+//   - targetURL: 目标网页地址
+//
+// 返回：
+//   - *Info: 预览信息
+//   - error: 请求失败、非HTML页面等情况返回错误
+func Fetch(ctx context.Context, targetURL string) (*Info, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, targetURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgentValue)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("请求失败: %s", resp.Status)
+	}
+
+	// 超长页面只解析前 maxBodySize 字节，以重定向后的最终地址为基准解析相对路径
+	return Parse(io.LimitReader(resp.Body, maxBodySize), resp.Request.URL)
+}
+
+// Parse 从HTML中提取预览信息
+// 优先使用 Open Graph 标签，其次是 Twitter Card 和普通 meta 标签
+func Parse(r io.Reader, pageURL *url.URL) (*Info, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := make(map[string]string)
+	doc.Find("meta").Each(func(i int, s *goquery.Selection) {
+		key, ok := s.Attr("property")
+		if !ok {
+			key, _ = s.Attr("name")
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		content, _ := s.Attr("content")
+		content = strings.TrimSpace(content)
+		// 同名标签以第一个为准
+		if key != "" && content != "" && meta[key] == "" {
+			meta[key] = content
+		}
+	})
+
+	canonical, _ := doc.Find("link[rel='canonical']").First().Attr("href")
+
+	info := &Info{
+		Title:        firstNonEmpty(meta["og:title"], meta["twitter:title"], strings.TrimSpace(doc.Find("title").First().Text())),
+		Description:  firstNonEmpty(meta["og:description"], meta["twitter:description"], meta["description"]),
+		Image:        resolve(pageURL, firstNonEmpty(meta["og:image"], meta["og:image:url"], meta["twitter:image"])),
+		CanonicalURL: resolve(pageURL, firstNonEmpty(strings.TrimSpace(canonical), meta["og:url"])),
+	}
+	info.Text = extractText(doc)
+	if info.CanonicalURL == "" && pageURL != nil {
+		info.CanonicalURL = pageURL.String()
+	}
+	info.Title = truncate(info.Title, maxTitleLen)
+	info.Description = truncate(info.Description, maxDescLen)
+	return info, nil
+}
+
+// extractText 提取正文段落，优先 <article> 内的内容
+func extractText(doc *goquery.Document) string {
+	root := doc.Find("article").First()
+	if root.Length() == 0 {
+		root = doc.Find("body")
+	}
+	var b strings.Builder
+	root.Find("h1, h2, h3, p, li").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		text := strings.Join(strings.Fields(s.Text()), " ")
+		if text == "" {
+			return true
+		}
+		b.WriteString(text)
+		b.WriteString("\n")
+		return utf8.RuneCountInString(b.String()) < maxTextLen
+	})
+	return truncate(strings.TrimSpace(b.String()), maxTextLen)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// resolve 将相对地址转换为绝对地址，只保留 http/https
+func resolve(base *url.URL, ref string) string {
+	if ref == "" {
+		return ""
+	}
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ""
+	}
+	return u.String()
+}
+
+// truncate 按字符截断，避免截断多字节字符
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
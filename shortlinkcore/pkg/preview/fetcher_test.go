@@ -0,0 +1,45 @@
+package preview
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	page, _ := url.Parse("https://example.com/posts/1?utm=x")
+
+	t.Run("优先使用OG标签并补全相对地址", func(t *testing.T) {
+		html := `<html><head>
+			<title>页面标题</title>
+			<meta property="og:title" content="OG 标题">
+			<meta name="description" content="普通描述">
+			<meta property="og:image" content="/img/cover.png">
+			<link rel="canonical" href="https://example.com/posts/1">
+		</head></html>`
+		info, err := Parse(strings.NewReader(html), page)
+		require.NoError(t, err)
+		assert.Equal(t, "OG 标题", info.Title)
+		assert.Equal(t, "普通描述", info.Description)
+		assert.Equal(t, "https://example.com/img/cover.png", info.Image)
+		assert.Equal(t, "https://example.com/posts/1", info.CanonicalURL)
+	})
+
+	t.Run("没有元信息时退化为页面标题和访问地址", func(t *testing.T) {
+		info, err := Parse(strings.NewReader(`<title> Hello </title>`), page)
+		require.NoError(t, err)
+		assert.Equal(t, "Hello", info.Title)
+		assert.Empty(t, info.Image)
+		assert.Equal(t, page.String(), info.CanonicalURL)
+	})
+
+	t.Run("忽略非网页地址的图片", func(t *testing.T) {
+		html := `<meta property="og:image" content="javascript:alert(1)">`
+		info, err := Parse(strings.NewReader(html), page)
+		require.NoError(t, err)
+		assert.Empty(t, info.Image)
+	})
+}
@@ -0,0 +1,139 @@
+package preview
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// 默认的摘要提示词
+const defaultPrompt = "请用一句话（五十个字以内）概括下面网页的内容，让读者快速了解链接指向什么。只输出摘要本身。"
+
+// Summarizer 摘要生成器
+type Summarizer interface {
+	// Summarize 根据网页预览信息生成摘要
+	Summarize(ctx context.Context, info *Info) (string, error)
+}
+
+// OpenAIConfig OpenAI 兼容接口的配置
+type OpenAIConfig struct {
+	BaseURL string        // 接口地址，如 https://api.openai.com/v1
+	APIKey  string        // 接口密钥
+	Model   string        // 模型名称
+	Prompt  string        // 系统提示词，为空使用默认值
+	Timeout time.Duration // 请求超时时间
+}
+
+// OpenAISummarizer 调用 OpenAI 兼容的 chat/completions 接口生成摘要
+// 讯飞星火、通义千问、DeepSeek 等都提供兼容接口，只需修改配置
+type OpenAISummarizer struct {
+	cfg    OpenAIConfig
+	client *http.Client
+}
+
+// NewOpenAISummarizer 创建 OpenAI 兼容的摘要生成器
+func NewOpenAISummarizer(cfg OpenAIConfig) *OpenAISummarizer {
+	if cfg.Prompt == "" {
+		cfg.Prompt = defaultPrompt
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 15 * time.Second
+	}
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &OpenAISummarizer{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+	Stream   bool          `json:"stream"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Summarize 生成摘要
+func (s *OpenAISummarizer) Summarize(ctx context.Context, info *Info) (string, error) {
+	body, err := json.Marshal(chatRequest{
+		Model: s.cfg.Model,
+		Messages: []chatMessage{
+			{Role: "system", Content: s.cfg.Prompt},
+			{Role: "user", Content: documentText(info)},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.BaseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.cfg.APIKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+	var result chatResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return "", fmt.Errorf("解析摘要响应失败（状态码 %d）: %w", resp.StatusCode, err)
+	}
+	if result.Error != nil {
+		return "", fmt.Errorf("摘要接口返回错误: %s", result.Error.Message)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("摘要接口请求失败: %s", resp.Status)
+	}
+	if len(result.Choices) == 0 {
+		return "", errors.New("摘要接口没有返回结果")
+	}
+
+	summary := strings.TrimSpace(result.Choices[0].Message.Content)
+	if summary == "" {
+		return "", errors.New("摘要接口返回空内容")
+	}
+	return truncate(summary, maxDescLen), nil
+}
+
+// documentText 拼接发送给模型的网页内容
+func documentText(info *Info) string {
+	var b strings.Builder
+	if info.Title != "" {
+		b.WriteString("标题：" + info.Title + "\n")
+	}
+	if info.Description != "" {
+		b.WriteString("描述：" + info.Description + "\n")
+	}
+	if info.Text != "" {
+		b.WriteString("正文：\n" + info.Text)
+	}
+	return b.String()
+}
@@ -0,0 +1,75 @@
+package preview
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractiveSummarizer(t *testing.T) {
+	info := &Info{
+		Title: "Go 协程池",
+		Text: "今天天气不错，适合出门散步。\n" +
+			"协程池可以限制并发的协程数量，避免协程无限增长。\n" +
+			"使用协程池处理异步任务时，需要注意任务中不要再向同一个协程池提交任务。\n" +
+			"文章最后附上了完整的示例代码。",
+	}
+	s := ExtractiveSummarizer{MaxRunes: 40}
+
+	first, err := s.Summarize(context.Background(), info)
+	require.NoError(t, err)
+	assert.Contains(t, first, "协程池")
+	assert.NotContains(t, first, "天气")
+	assert.LessOrEqual(t, len([]rune(first)), 40)
+
+	// 相同输入得到相同结果
+	second, _ := s.Summarize(context.Background(), info)
+	assert.Equal(t, first, second)
+
+	t.Run("没有正文时使用描述", func(t *testing.T) {
+		summary, err := s.Summarize(context.Background(), &Info{Description: "页面描述"})
+		require.NoError(t, err)
+		assert.Equal(t, "页面描述", summary)
+	})
+
+	t.Run("没有内容时返回错误", func(t *testing.T) {
+		_, err := s.Summarize(context.Background(), &Info{})
+		assert.Error(t, err)
+	})
+}
+
+func TestOpenAISummarizer(t *testing.T) {
+	var got chatRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"  一篇介绍协程池的文章  "}}]}`))
+	}))
+	defer server.Close()
+
+	s := NewOpenAISummarizer(OpenAIConfig{BaseURL: server.URL + "/v1/", APIKey: "test-key", Model: "test-model"})
+	summary, err := s.Summarize(context.Background(), &Info{Title: "协程池", Text: "正文"})
+	require.NoError(t, err)
+	assert.Equal(t, "一篇介绍协程池的文章", summary)
+	assert.Equal(t, "test-model", got.Model)
+	require.Len(t, got.Messages, 2)
+	assert.Equal(t, defaultPrompt, got.Messages[0].Content)
+	assert.Contains(t, got.Messages[1].Content, "标题：协程池")
+
+	t.Run("接口返回错误", func(t *testing.T) {
+		errServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":{"message":"invalid api key"}}`))
+		}))
+		defer errServer.Close()
+		_, err := NewOpenAISummarizer(OpenAIConfig{BaseURL: errServer.URL}).Summarize(context.Background(), &Info{Title: "x"})
+		assert.ErrorContains(t, err, "invalid api key")
+	})
+}
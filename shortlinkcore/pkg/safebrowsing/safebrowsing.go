@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"shortLink/shortlinkcore/logger"
+	"shortLink/shortlinkcore/pkg/safehttp"
 
 	"go.uber.org/zap"
 )
@@ -15,6 +17,13 @@ const (
 	apiURL = "https://safebrowsing.googleapis.com/v4/threatMatches:find"
 )
 
+// 检查请求走统一的安全客户端，带超时和响应大小限制
+var client = safehttp.New(safehttp.Options{
+	Timeout:      5 * time.Second,
+	MaxBodySize:  1 << 20,
+	ContentTypes: []string{"application/json"},
+})
+
 type ThreatMatch struct {
 	ThreatType      string `json:"threatType"`
 	PlatformType    string `json:"platformType"`
@@ -71,7 +80,6 @@ func CheckURL(url string) (bool, string, error) {
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
 		logger.Log.Error("请求失败", zap.String("error", err.Error()))
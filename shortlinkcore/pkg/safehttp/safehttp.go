@@ -0,0 +1,250 @@
+// 安全的 HTTP 客户端：用于请求用户提交的任意地址（链接预览、目标地址检查等）
+// 自行解析 DNS 并拒绝内网、回环、链路本地等地址，重定向后的每一跳同样校验，
+// 防止用户借助短链接服务访问内部系统（SSRF）
+package safehttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+)
+
+var (
+	ErrBlockedAddress    = errors.New("目标地址不允许访问")
+	ErrTooManyRedirects  = errors.New("重定向次数过多")
+	ErrBodyTooLarge      = errors.New("响应内容过大")
+	ErrContentType       = errors.New("响应类型不支持")
+	ErrUnsupportedScheme = errors.New("只支持 http/https 地址")
+)
+
+const (
+	defaultTimeout      = 10 * time.Second
+	defaultMaxRedirects = 5
+	defaultMaxBodySize  = 2 << 20
+)
+
+// Options 客户端配置，零值使用默认值
+type Options struct {
+	Timeout      time.Duration // 整个请求（含重定向和读取响应）的超时时间
+	MaxRedirects int           // 最多跟随的重定向次数
+	MaxBodySize  int64         // 响应体最大字节数，超过后读取返回 ErrBodyTooLarge
+	ContentTypes []string      // 允许的响应类型（如 text/html），为空表示不限
+}
+
+// Resolver DNS 解析器，默认使用 net.DefaultResolver
+type Resolver interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+// Client 安全的 HTTP 客户端，可并发使用
+type Client struct {
+	opts     Options
+	http     *http.Client
+	resolver Resolver
+	dialer   *net.Dialer
+	// allow 判断地址是否允许访问，测试中可以替换以放行本地测试服务器
+	allow func(netip.Addr) bool
+}
+
+// New 创建安全的 HTTP 客户端
+func New(opts Options) *Client {
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	if opts.MaxRedirects <= 0 {
+		opts.MaxRedirects = defaultMaxRedirects
+	}
+	if opts.MaxBodySize <= 0 {
+		opts.MaxBodySize = defaultMaxBodySize
+	}
+
+	c := &Client{
+		opts:     opts,
+		resolver: net.DefaultResolver,
+		dialer:   &net.Dialer{Timeout: 5 * time.Second, KeepAlive: 30 * time.Second},
+		allow:    IsPublic,
+	}
+	transport := &http.Transport{
+		// 不使用环境变量中的代理，否则地址校验会被代理绕过
+		Proxy:                 nil,
+		DialContext:           c.dialContext,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: opts.Timeout,
+		MaxIdleConns:          50,
+		IdleConnTimeout:       90 * time.Second,
+	}
+	c.http = &http.Client{
+		Timeout:       opts.Timeout,
+		Transport:     transport,
+		CheckRedirect: c.checkRedirect,
+	}
+	return c
+}
+
+// Do 发送请求
+// 返回的响应体读取超过 MaxBodySize 时返回 ErrBodyTooLarge；
+// 配置了 ContentTypes 时，类型不匹配的响应会被关闭并返回 ErrContentType
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if err := checkScheme(req.URL.Scheme); err != nil {
+		return nil, err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if !c.contentTypeAllowed(resp.Header.Get("Content-Type")) {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %s", ErrContentType, resp.Header.Get("Content-Type"))
+	}
+	resp.Body = &limitedBody{rc: resp.Body, remaining: c.opts.MaxBodySize}
+	return resp, nil
+}
+
+// Get 发送 GET 请求
+func (c *Client) Get(ctx context.Context, rawURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(req)
+}
+
+// dialContext 自行解析域名，所有解析结果都必须是公网地址才建立连接
+// 直接连接校验过的IP，避免 DNS 重绑定（校验和连接时解析出不同地址）
+func (c *Client) dialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	var ips []netip.Addr
+	if ip, err := netip.ParseAddr(host); err == nil {
+		ips = []netip.Addr{ip}
+	} else {
+		ips, err = c.resolver.LookupNetIP(ctx, "ip", host)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("域名没有解析结果: %s", host)
+	}
+	for _, ip := range ips {
+		if !c.allow(ip.Unmap()) {
+			return nil, fmt.Errorf("%w: %s (%s)", ErrBlockedAddress, host, ip)
+		}
+	}
+
+	var lastErr error
+	for _, ip := range ips {
+		conn, err := c.dialer.DialContext(ctx, network, net.JoinHostPort(ip.Unmap().String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// checkRedirect 限制重定向次数和协议，目标地址在建立连接时校验
+func (c *Client) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) > c.opts.MaxRedirects {
+		return ErrTooManyRedirects
+	}
+	return checkScheme(req.URL.Scheme)
+}
+
+func (c *Client) contentTypeAllowed(contentType string) bool {
+	if len(c.opts.ContentTypes) == 0 {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range c.opts.ContentTypes {
+		if strings.EqualFold(mediaType, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkScheme(scheme string) error {
+	if scheme != "http" && scheme != "https" {
+		return ErrUnsupportedScheme
+	}
+	return nil
+}
+
+// 除标准库已能识别的私有/回环/链路本地地址外，额外禁止的保留网段
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),      // 本网络
+	netip.MustParsePrefix("100.64.0.0/10"),  // 运营商级 NAT
+	netip.MustParsePrefix("192.0.0.0/24"),   // IETF 协议分配
+	netip.MustParsePrefix("198.18.0.0/15"),  // 基准测试
+	netip.MustParsePrefix("240.0.0.0/4"),    // 保留
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64，可映射到内网 IPv4
+	netip.MustParsePrefix("64:ff9b:1::/48"), // 本地 NAT64
+	netip.MustParsePrefix("2002::/16"),      // 6to4，可映射到内网 IPv4
+	netip.MustParsePrefix("fec0::/10"),      // 已废弃的站点本地地址
+	netip.MustParsePrefix("100::/64"),       // 丢弃前缀
+	netip.MustParsePrefix("2001:db8::/32"),  // 文档示例
+	netip.MustParsePrefix("255.255.255.255/32"),
+}
+
+// IsPublic 判断地址是否为可以访问的公网地址
+func IsPublic(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsValid() ||
+		ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified() {
+		return false
+	}
+	for _, p := range reservedPrefixes {
+		if p.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// limitedBody 限制响应体大小，超过后返回 ErrBodyTooLarge 而不是静默截断
+type limitedBody struct {
+	rc        io.ReadCloser
+	remaining int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		// 恰好读完时再探测一个字节，区分“刚好等于上限”和“超过上限”
+		var one [1]byte
+		n, err := b.rc.Read(one[:])
+		if n > 0 {
+			return 0, ErrBodyTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.rc.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.rc.Close()
+}
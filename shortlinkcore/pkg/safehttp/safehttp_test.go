@@ -0,0 +1,125 @@
+package safehttp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeResolver 将所有域名解析到固定地址
+type fakeResolver struct {
+	addr netip.Addr
+}
+
+func (r fakeResolver) LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	return []netip.Addr{r.addr}, nil
+}
+
+// newTestClient 创建只放行测试服务器地址（127.0.0.1）的客户端
+func newTestClient(opts Options) *Client {
+	c := New(opts)
+	loopback := netip.MustParseAddr("127.0.0.1")
+	c.allow = func(ip netip.Addr) bool {
+		return ip == loopback || IsPublic(ip)
+	}
+	return c
+}
+
+func TestIsPublic(t *testing.T) {
+	blocked := []string{
+		"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254",
+		"100.64.0.1", "0.0.0.0", "::1", "fe80::1", "fc00::1", "::ffff:127.0.0.1", "::ffff:10.0.0.1",
+	}
+	for _, s := range blocked {
+		assert.False(t, IsPublic(netip.MustParseAddr(s)), s)
+	}
+	for _, s := range []string{"8.8.8.8", "1.1.1.1", "2606:4700:4700::1111"} {
+		assert.True(t, IsPublic(netip.MustParseAddr(s)), s)
+	}
+}
+
+func TestClient(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/html", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		io.WriteString(w, "<title>ok</title>")
+	})
+	mux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, "{}")
+	})
+	mux.HandleFunc("/big", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		io.WriteString(w, strings.Repeat("a", 2048))
+	})
+	mux.HandleFunc("/metadata", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/file", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "file:///etc/passwd", http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	ctx := context.Background()
+
+	t.Run("默认拒绝回环地址", func(t *testing.T) {
+		_, err := New(Options{}).Get(ctx, server.URL+"/html")
+		assert.True(t, errors.Is(err, ErrBlockedAddress), err)
+	})
+
+	t.Run("域名解析到内网地址时拒绝", func(t *testing.T) {
+		c := New(Options{})
+		c.resolver = fakeResolver{addr: netip.MustParseAddr("10.0.0.1")}
+		_, err := c.Get(ctx, "http://internal.example.com/")
+		assert.True(t, errors.Is(err, ErrBlockedAddress), err)
+	})
+
+	t.Run("正常请求", func(t *testing.T) {
+		c := newTestClient(Options{ContentTypes: []string{"text/html"}})
+		resp, err := c.Get(ctx, server.URL+"/html")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "<title>ok</title>", string(body))
+	})
+
+	t.Run("重定向到内网地址时拒绝", func(t *testing.T) {
+		_, err := newTestClient(Options{}).Get(ctx, server.URL+"/metadata")
+		assert.True(t, errors.Is(err, ErrBlockedAddress), err)
+	})
+
+	t.Run("重定向次数受限", func(t *testing.T) {
+		_, err := newTestClient(Options{MaxRedirects: 3}).Get(ctx, server.URL+"/loop")
+		assert.True(t, errors.Is(err, ErrTooManyRedirects), err)
+	})
+
+	t.Run("拒绝非http协议的重定向", func(t *testing.T) {
+		_, err := newTestClient(Options{}).Get(ctx, server.URL+"/file")
+		assert.True(t, errors.Is(err, ErrUnsupportedScheme), err)
+	})
+
+	t.Run("响应类型不匹配", func(t *testing.T) {
+		_, err := newTestClient(Options{ContentTypes: []string{"text/html"}}).Get(ctx, server.URL+"/json")
+		assert.True(t, errors.Is(err, ErrContentType), err)
+	})
+
+	t.Run("响应体超过上限", func(t *testing.T) {
+		resp, err := newTestClient(Options{MaxBodySize: 1024}).Get(ctx, server.URL+"/big")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		_, err = io.ReadAll(resp.Body)
+		assert.True(t, errors.Is(err, ErrBodyTooLarge), err)
+	})
+}
@@ -0,0 +1,142 @@
+// User-Agent 解析模块
+package useragent
+
+import "strings"
+
+// 访问平台
+const (
+	PlatformIOS     = "ios"
+	PlatformAndroid = "android"
+	PlatformDesktop = "desktop"
+)
+
+// DetectPlatform 根据 User-Agent 判断访问平台
+// 参数：
+//   - ua: 客户端的 User-Agent
+//
+// 返回：
+//   - string: ios / android / desktop，无法识别时按 desktop 处理
+func DetectPlatform(ua string) string {
+	lower := strings.ToLower(ua)
+	switch {
+	// iPadOS 13 之后默认伪装成 Mac，需要结合 Mobile 关键字判断
+	case strings.Contains(lower, "iphone"),
+		strings.Contains(lower, "ipad"),
+		strings.Contains(lower, "ipod"),
+		strings.Contains(lower, "macintosh") && strings.Contains(lower, "mobile/"):
+		return PlatformIOS
+	case strings.Contains(lower, "android"):
+		return PlatformAndroid
+	default:
+		return PlatformDesktop
+	}
+}
+
+// 设备类型
+const (
+	DeviceMobile  = "mobile"
+	DeviceTablet  = "tablet"
+	DeviceDesktop = "desktop"
+)
+
+// DetectDevice 根据 User-Agent 判断设备类型
+// 参数：
+//   - ua: 客户端的 User-Agent
+//
+// 返回：
+//   - string: mobile / tablet / desktop，无法识别时按 desktop 处理
+func DetectDevice(ua string) string {
+	lower := strings.ToLower(ua)
+	switch {
+	// Android 平板的 User-Agent 不含 Mobile 关键字
+	case strings.Contains(lower, "ipad"),
+		strings.Contains(lower, "tablet"),
+		strings.Contains(lower, "macintosh") && strings.Contains(lower, "mobile/"),
+		strings.Contains(lower, "android") && !strings.Contains(lower, "mobile"):
+		return DeviceTablet
+	case strings.Contains(lower, "mobile"),
+		strings.Contains(lower, "iphone"),
+		strings.Contains(lower, "ipod"),
+		strings.Contains(lower, "android"):
+		return DeviceMobile
+	default:
+		return DeviceDesktop
+	}
+}
+
+// 无法识别时的取值
+const Unknown = "other"
+
+// Info User-Agent 解析结果，用于点击来源统计
+type Info struct {
+	Browser string // 浏览器，如 Chrome、Safari、WeChat
+	OS      string // 操作系统，如 Windows、iOS、Android
+	Device  string // mobile / tablet / desktop
+}
+
+// browserRules 按顺序匹配，基于 Chromium 的浏览器和内置浏览器都带有 Chrome/Safari 标识，需要排在前面
+var browserRules = []struct {
+	name     string
+	keywords []string
+}{
+	{"WeChat", []string{"micromessenger"}},
+	{"QQ", []string{"qqbrowser", " qq/"}},
+	{"Edge", []string{"edg/", "edga/", "edgios/", "edge/"}},
+	{"Opera", []string{"opr/", "opera"}},
+	{"Samsung Internet", []string{"samsungbrowser"}},
+	{"UC Browser", []string{"ucbrowser"}},
+	{"Firefox", []string{"firefox/", "fxios/"}},
+	{"Chrome", []string{"chrome/", "crios/"}},
+	{"Safari", []string{"safari/"}},
+	{"Internet Explorer", []string{"msie ", "trident/"}},
+}
+
+// osRules 按顺序匹配，iPadOS 伪装成 Mac 的情况由 DetectPlatform 处理
+var osRules = []struct {
+	name     string
+	keywords []string
+}{
+	{"Windows", []string{"windows"}},
+	{"iOS", []string{"iphone", "ipad", "ipod"}},
+	{"Android", []string{"android"}},
+	{"ChromeOS", []string{"cros"}},
+	{"macOS", []string{"macintosh", "mac os x"}},
+	{"Linux", []string{"linux"}},
+}
+
+// Parse 解析 User-Agent 中的浏览器、操作系统和设备类型
+// 参数：
+//   - ua: 客户端的 User-Agent
+//
+// 返回：
+//   - Info: 解析结果，无法识别的字段为 other
+func Parse(ua string) Info {
+	lower := strings.ToLower(ua)
+	info := Info{Browser: Unknown, OS: Unknown, Device: DetectDevice(ua)}
+	for _, r := range browserRules {
+		if containsAny(lower, r.keywords) {
+			info.Browser = r.name
+			break
+		}
+	}
+	if DetectPlatform(ua) == PlatformIOS {
+		info.OS = "iOS"
+	} else {
+		for _, r := range osRules {
+			if containsAny(lower, r.keywords) {
+				info.OS = r.name
+				break
+			}
+		}
+	}
+	return info
+}
+
+func containsAny(s string, keywords []string) bool {
+	for _, k := range keywords {
+		if strings.Contains(s, k) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package useragent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		ua   string
+		want Info
+	}{
+		{"Windows Chrome",
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			Info{Browser: "Chrome", OS: "Windows", Device: DeviceDesktop}},
+		{"Windows Edge",
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.0.0",
+			Info{Browser: "Edge", OS: "Windows", Device: DeviceDesktop}},
+		{"iPhone Safari",
+			"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1",
+			Info{Browser: "Safari", OS: "iOS", Device: DeviceMobile}},
+		{"iPadOS 伪装成 Mac",
+			"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1",
+			Info{Browser: "Safari", OS: "iOS", Device: DeviceTablet}},
+		{"Android 微信",
+			"Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/116.0.0.0 Mobile Safari/537.36 MicroMessenger/8.0.40",
+			Info{Browser: "WeChat", OS: "Android", Device: DeviceMobile}},
+		{"Android 平板 Firefox",
+			"Mozilla/5.0 (Android 13; Tablet; rv:120.0) Gecko/120.0 Firefox/120.0",
+			Info{Browser: "Firefox", OS: "Android", Device: DeviceTablet}},
+		{"macOS Firefox",
+			"Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:120.0) Gecko/20100101 Firefox/120.0",
+			Info{Browser: "Firefox", OS: "macOS", Device: DeviceDesktop}},
+		{"无法识别", "curl/8.4.0", Info{Browser: Unknown, OS: Unknown, Device: DeviceDesktop}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Parse(tt.ua))
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Package webhook Webhook 请求签名和重试间隔
+// 签名为 HMAC-SHA256(secret, "<timestamp>.<body>")，接收方用相同的方式计算后比较，
+// 并拒绝时间戳过旧的请求以防重放
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// 请求头
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+const (
+	signaturePrefix = "sha256="
+	baseBackoff     = 30 * time.Second
+	maxBackoff      = 6 * time.Hour
+)
+
+// Sign 计算请求签名，返回 sha256=<hex>
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify 校验请求签名，tolerance 为允许的时间偏差，0 表示不校验时间
+func Verify(secret string, timestamp int64, body []byte, signature string, tolerance time.Duration, now time.Time) bool {
+	if tolerance > 0 {
+		diff := now.Sub(time.Unix(timestamp, 0))
+		if diff < -tolerance || diff > tolerance {
+			return false
+		}
+	}
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// Backoff 第 attempt 次（从1开始）投递失败后，到下一次重试的间隔
+// 从30秒开始指数增长，最长6小时
+func Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	d := baseBackoff
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return d
+}
@@ -0,0 +1,42 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"event":"link.created"}`)
+	sig := Sign("whsec_test", 1700000000, body)
+
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, sig)
+	assert.Equal(t, sig, Sign("whsec_test", 1700000000, body))
+	assert.NotEqual(t, sig, Sign("whsec_other", 1700000000, body))
+	assert.NotEqual(t, sig, Sign("whsec_test", 1700000001, body))
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"event":"link.created"}`)
+	now := time.Unix(1700000000, 0)
+	sig := Sign("whsec_test", now.Unix(), body)
+
+	t.Run("签名正确", func(t *testing.T) {
+		assert.True(t, Verify("whsec_test", now.Unix(), body, sig, 5*time.Minute, now.Add(time.Minute)))
+	})
+	t.Run("内容被篡改", func(t *testing.T) {
+		assert.False(t, Verify("whsec_test", now.Unix(), []byte(`{}`), sig, 5*time.Minute, now))
+	})
+	t.Run("时间戳过旧", func(t *testing.T) {
+		assert.False(t, Verify("whsec_test", now.Unix(), body, sig, 5*time.Minute, now.Add(10*time.Minute)))
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, Backoff(1))
+	assert.Equal(t, time.Minute, Backoff(2))
+	assert.Equal(t, 4*time.Minute, Backoff(4))
+	assert.Equal(t, 6*time.Hour, Backoff(20))
+	assert.Equal(t, 30*time.Second, Backoff(0))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,31 +13,74 @@ import (
 	"shortLink/shortlinkcore/model"
 
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 )
 
+// BatchItem 批量生成中的单个短链接及其参数
+type BatchItem struct {
+	OriginalURL string
+	Options     ShortenOptions // Domain 字段由批量请求统一指定
+}
+
+// reusable 不带自定义参数的短链接才能复用已存在的短链接
+func (it BatchItem) reusable() bool {
+	o := it.Options
+	return o.Alias == "" && len(o.Tags) == 0 && o.ExpireAt == nil && o.RedirectType == 0
+}
+
+// key 请求内去重的依据，URL 和参数都相同的项只处理一次
+func (it BatchItem) key() string {
+	o := it.Options
+	var expireAt int64
+	if o.ExpireAt != nil {
+		expireAt = o.ExpireAt.Unix()
+	}
+	return fmt.Sprintf("%s\x00%s\x00%s\x00%d\x00%d",
+		it.OriginalURL, o.Alias, strings.Join(o.Tags, ","), expireAt, o.RedirectType)
+}
+
 // BatchShortenResult 表示批量生成短链接的结果
 type BatchShortenResult struct {
+	Index       int    `json:"index"` // 在请求中的位置
 	OriginalURL string `json:"original_url"`
 	ShortURL    string `json:"short_url"`
+	FullURL     string `json:"full_url,omitempty"`
 	Error       string `json:"error,omitempty"`
+	Duplicate   bool   `json:"duplicate,omitempty"` // 与请求中前面的某一项相同，复用其结果
 }
 
 // BatchShortenURLs 批量生成短链接
 // 参数：
 //   - ctx: 上下文
-//   - urls: 需要转换的原始长URL列表
+//   - items: 需要转换的原始长URL及其参数列表
+//   - domain: 短链接域名，为空表示系统默认域名
 //   - concurrency: 并发处理的数量，默认为10
 //
 // 返回：
-//   - []BatchShortenResult: 批量生成结果
+//   - []BatchShortenResult: 批量生成结果，与 items 顺序一致
 //   - error: 错误信息
-func BatchShortenURLs(ctx context.Context, urls []string, userID string, concurrency int) ([]BatchShortenResult, error) {
+func BatchShortenURLs(ctx context.Context, items []BatchItem, userID, domain string, concurrency int) ([]BatchShortenResult, error) {
+	results := make([]BatchShortenResult, len(items))
+	err := StreamShortenURLs(ctx, items, userID, domain, concurrency, func(r BatchShortenResult) error {
+		results[r.Index] = r
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// StreamShortenURLs 批量生成短链接，每完成一项就调用一次 emit（按完成顺序，Index 为在请求中的位置）
+// emit 只在调用方所在的协程中执行，无需加锁；emit 返回错误时取消剩余的生成任务
+// 请求内完全相同的项只生成一次，其余项复用结果并标记为 Duplicate
+func StreamShortenURLs(ctx context.Context, items []BatchItem, userID, domain string, concurrency int, emit func(BatchShortenResult) error) error {
 	logger.Log.Info("收到批量生成短链接请求",
-		zap.Int("urlCount", len(urls)),
+		zap.Int("urlCount", len(items)),
 		zap.Int("concurrency", concurrency))
 
-	if len(urls) == 0 {
-		return nil, errors.New("URL列表为空")
+	if len(items) == 0 {
+		return errors.New("URL列表为空")
 	}
 
 	// 设置默认并发数
@@ -49,32 +93,84 @@ func BatchShortenURLs(ctx context.Context, urls []string, userID string, concurr
 		concurrency = 50
 	}
 
-	// 预检查数据库中是否已存在这些URL
-	results := make([]BatchShortenResult, 0, len(urls))
-	var urlsToProcess []string
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// 1. 请求内去重，记录每个首次出现的项对应的重复项
+	duplicates := make(map[int][]int)
+	first := make(map[string]int, len(items))
+	var unique []int
+	for i, item := range items {
+		k := item.key()
+		if j, ok := first[k]; ok {
+			duplicates[j] = append(duplicates[j], i)
+			continue
+		}
+		first[k] = i
+		unique = append(unique, i)
+	}
+
+	successCount := 0
+	// send 发出结果，并为重复项复制一份
+	send := func(r BatchShortenResult) error {
+		if r.Error == "" {
+			successCount++
+		}
+		if err := emit(r); err != nil {
+			return err
+		}
+		for _, i := range duplicates[r.Index] {
+			dup := r
+			dup.Index = i
+			dup.Duplicate = true
+			if dup.Error == "" {
+				successCount++
+			}
+			if err := emit(dup); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 
-	for _, url := range urls {
-		if shortURL := model.IsOriginalURLExist(url); shortURL != "" {
+	// 2. 预检查数据库中是否已存在这些URL
+	var urlsToProcess []int
+	for _, i := range unique {
+		item := items[i]
+		if !item.reusable() {
+			urlsToProcess = append(urlsToProcess, i)
+			continue
+		}
+		shortURL, err := model.IsOriginalURLExist(model.Owner{UserID: userID, WorkspaceID: item.Options.WorkspaceID}, domain, item.OriginalURL)
+		if err != nil {
+			logger.Log.Warn("查询已存在的短链接失败", zap.String("originalUrl", item.OriginalURL), zap.Error(err))
+		}
+		if shortURL != "" {
 			// URL已存在，直接使用已有的短链接
-			results = append(results, BatchShortenResult{
-				OriginalURL: url,
-				ShortURL:    shortURL,
-			})
 			logger.Log.Debug("使用已存在的短链接",
-				zap.String("originalUrl", url),
+				zap.String("originalUrl", item.OriginalURL),
 				zap.String("shortUrl", shortURL))
+			err := send(BatchShortenResult{
+				Index:       i,
+				OriginalURL: item.OriginalURL,
+				ShortURL:    shortURL,
+				FullURL:     FullURL(domain, shortURL),
+			})
+			if err != nil {
+				return err
+			}
 		} else {
 			// URL不存在，加入待处理列表
-			urlsToProcess = append(urlsToProcess, url)
+			urlsToProcess = append(urlsToProcess, i)
 		}
 	}
 
 	// 如果所有URL都已存在，直接返回结果
 	if len(urlsToProcess) == 0 {
-		return results, nil
+		return nil
 	}
 
-	// 创建结果通道，只处理不存在的URL
+	// 创建结果通道，只处理不存在的URL；容量足够，停止接收后任务也不会阻塞
 	resultChan := make(chan BatchShortenResult, len(urlsToProcess))
 
 	// 创建工作池
@@ -83,9 +179,10 @@ func BatchShortenURLs(ctx context.Context, urls []string, userID string, concurr
 	semaphore := make(chan struct{}, concurrency)
 
 	// 处理需要新生成短链接的URL
-	for i, url := range urlsToProcess {
+	for _, i := range urlsToProcess {
 		wg.Add(1)
-		go func(index int, originalURL string) {
+		go func(index int, item BatchItem) {
+			originalURL := item.OriginalURL
 			defer wg.Done()
 
 			// 获取信号量
@@ -96,6 +193,7 @@ func BatchShortenURLs(ctx context.Context, urls []string, userID string, concurr
 			select {
 			case <-ctx.Done():
 				resultChan <- BatchShortenResult{
+					Index:       index,
 					OriginalURL: originalURL,
 					Error:       "请求已取消",
 				}
@@ -105,8 +203,10 @@ func BatchShortenURLs(ctx context.Context, urls []string, userID string, concurr
 			}
 
 			// 生成短链接
-			shortURL, err := Shorten(originalURL, userID)
-			result := BatchShortenResult{OriginalURL: originalURL}
+			opts := item.Options
+			opts.Domain = domain
+			shortURL, err := ShortenWithOptions(originalURL, userID, opts)
+			result := BatchShortenResult{Index: index, OriginalURL: originalURL}
 
 			if err != nil {
 				result.Error = err.Error()
@@ -115,13 +215,14 @@ func BatchShortenURLs(ctx context.Context, urls []string, userID string, concurr
 					zap.Error(err))
 			} else {
 				result.ShortURL = shortURL
+				result.FullURL = FullURL(domain, shortURL)
 				logger.Log.Debug("批量生成短链接成功",
 					zap.String("originalUrl", originalURL),
 					zap.String("shortUrl", shortURL))
 			}
 
 			resultChan <- result
-		}(i, url)
+		}(i, items[i])
 	}
 
 	// 等待所有goroutine完成
@@ -130,16 +231,19 @@ func BatchShortenURLs(ctx context.Context, urls []string, userID string, concurr
 		close(resultChan)
 	}()
 
-	// 收集新生成的短链接结果
+	// 按完成顺序发出新生成的短链接结果
 	for result := range resultChan {
-		results = append(results, result)
+		if err := send(result); err != nil {
+			return err
+		}
 	}
 
 	logger.Log.Info("批量生成短链接完成",
-		zap.Int("totalCount", len(urls)),
-		zap.Int("successCount", countSuccesses(results)))
+		zap.Int("totalCount", len(items)),
+		zap.Int("uniqueCount", len(unique)),
+		zap.Int("successCount", successCount))
 
-	return results, nil
+	return nil
 }
 
 // countSuccesses 计算成功生成的短链接数量
@@ -173,8 +277,13 @@ func (s *ShortlinkService) BatchShortenURLs(ctx context.Context, req *shortlinkp
 
 	startTime := time.Now()
 
+	domain, err := domainForCreate(req.UserId, req.Domain)
+	if err != nil {
+		return nil, err
+	}
+
 	// 调用批量生成函数
-	results, err := BatchShortenURLs(ctx, req.OriginalUrls, req.UserId, int(req.Concurrency))
+	results, err := BatchShortenURLs(ctx, batchItems(req), req.UserId, domain, int(req.Concurrency))
 	if err != nil {
 		logger.Log.Error("批量生成短链接失败", zap.Error(err))
 		return nil, fmt.Errorf("批量生成短链接失败: %w", err)
@@ -183,12 +292,7 @@ func (s *ShortlinkService) BatchShortenURLs(ctx context.Context, req *shortlinkp
 	// 转换结果为protobuf格式
 	pbResults := make([]*shortlinkpb.BatchShortenResult, 0, len(results))
 	for _, result := range results {
-		pbResult := &shortlinkpb.BatchShortenResult{
-			OriginalUrl: result.OriginalURL,
-			ShortUrl:    result.ShortURL,
-			Error:       result.Error,
-		}
-		pbResults = append(pbResults, pbResult)
+		pbResults = append(pbResults, result.toPB())
 	}
 
 	elapsedTime := time.Since(startTime)
@@ -204,3 +308,68 @@ func (s *ShortlinkService) BatchShortenURLs(ctx context.Context, req *shortlinkp
 		ElapsedTime:  elapsedTime.String(),
 	}, nil
 }
+
+// BatchShortenURLsStream 流式批量生成短链接，每完成一项就发送给客户端
+// 大批量时客户端无需等待全部完成，也不受单次请求超时的限制
+func (s *ShortlinkService) BatchShortenURLsStream(req *shortlinkpb.BatchShortenRequest, stream grpc.ServerStreamingServer[shortlinkpb.BatchShortenResult]) error {
+	logger.Log.Info("收到流式批量生成短链接请求", zap.Int("urlCount", len(req.OriginalUrls)+len(req.Items)))
+
+	domain, err := domainForCreate(req.UserId, req.Domain)
+	if err != nil {
+		return err
+	}
+
+	err = StreamShortenURLs(stream.Context(), batchItems(req), req.UserId, domain, int(req.Concurrency),
+		func(r BatchShortenResult) error {
+			return stream.Send(r.toPB())
+		})
+	if err != nil {
+		logger.Log.Error("流式批量生成短链接失败", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// toPB 转换为 protobuf 格式
+func (r BatchShortenResult) toPB() *shortlinkpb.BatchShortenResult {
+	return &shortlinkpb.BatchShortenResult{
+		OriginalUrl: r.OriginalURL,
+		ShortUrl:    r.ShortURL,
+		FullUrl:     r.FullURL,
+		Error:       r.Error,
+		Index:       int32(r.Index),
+		Duplicate:   r.Duplicate,
+	}
+}
+
+// batchItems 合并请求中的 original_urls 和 items，original_urls 在前，所有项属于请求指定的工作区
+func batchItems(req *shortlinkpb.BatchShortenRequest) []BatchItem {
+	items := make([]BatchItem, 0, len(req.OriginalUrls)+len(req.Items))
+	for _, url := range req.OriginalUrls {
+		items = append(items, BatchItem{OriginalURL: url})
+	}
+	for _, it := range req.Items {
+		items = append(items, batchItemFromPB(it))
+	}
+	for i := range items {
+		items[i].Options.WorkspaceID = req.WorkspaceId
+	}
+	return items
+}
+
+// batchItemFromPB 转换带参数的批量生成项
+func batchItemFromPB(it *shortlinkpb.BatchItem) BatchItem {
+	item := BatchItem{
+		OriginalURL: it.OriginalUrl,
+		Options: ShortenOptions{
+			Alias:        it.Alias,
+			Tags:         it.Tags,
+			RedirectType: int(it.RedirectType),
+		},
+	}
+	if it.ExpireAt > 0 {
+		t := time.Unix(it.ExpireAt, 0)
+		item.Options.ExpireAt = &t
+	}
+	return item
+}
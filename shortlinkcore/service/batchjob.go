@@ -0,0 +1,344 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"shortLink/proto/shortlinkpb"
+	"shortLink/shortlinkcore/logger"
+	"shortLink/shortlinkcore/model"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxBatchJobItems     = 100000           // 单个任务的最大数量
+	batchJobChunkSize    = 100              // 每段处理的数量，每段结束后更新进度并检查是否已取消
+	batchJobWorkers      = 2                // 每个实例同时处理的任务数
+	batchJobStaleAfter   = 5 * time.Minute  // 运行中的任务超过这个时间没有进度，视为处理它的实例已退出
+	batchJobPollInterval = 10 * time.Second // 没有新提交时检查可接手任务的间隔
+)
+
+var (
+	// batchJobWakeup 提交任务后唤醒空闲的处理协程
+	batchJobWakeup = make(chan struct{}, 1)
+	batchJobCancel context.CancelFunc
+	batchJobWG     sync.WaitGroup
+)
+
+// StartBatchJobWorkers 启动后台处理批量任务的协程
+// 启动时会接手等待中的任务，以及上次退出时没有放回的运行中任务（超时后）
+func StartBatchJobWorkers() {
+	var ctx context.Context
+	ctx, batchJobCancel = context.WithCancel(context.Background())
+	for i := 0; i < batchJobWorkers; i++ {
+		batchJobWG.Add(1)
+		go func() {
+			defer batchJobWG.Done()
+			batchJobWorker(ctx)
+		}()
+	}
+	logger.Log.Info("批量任务处理协程已启动", zap.Int("workers", batchJobWorkers))
+}
+
+// StopBatchJobWorkers 停止处理，正在处理的任务放回等待队列，重启后继续
+func StopBatchJobWorkers() {
+	if batchJobCancel == nil {
+		return
+	}
+	batchJobCancel()
+	batchJobWG.Wait()
+}
+
+func batchJobWorker(ctx context.Context) {
+	ticker := time.NewTicker(batchJobPollInterval)
+	defer ticker.Stop()
+	for {
+		// 一直处理到没有可接手的任务
+		for runNextBatchJob(ctx) {
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-batchJobWakeup:
+		case <-ticker.C:
+		}
+	}
+}
+
+// runNextBatchJob 接手并处理一个任务，没有可接手的任务时返回 false
+func runNextBatchJob(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	staleBefore := time.Now().Add(-batchJobStaleAfter)
+	jobs, err := model.ListClaimableBatchJobs(staleBefore, 10)
+	if err != nil {
+		logger.Log.Error("查询待处理的批量任务失败", zap.Error(err))
+		return false
+	}
+	owner := uuid.NewString()
+	for i := range jobs {
+		ok, err := model.ClaimBatchJob(jobs[i].ID, owner, staleBefore)
+		if err != nil {
+			logger.Log.Error("接手批量任务失败", zap.String("jobId", jobs[i].ID), zap.Error(err))
+			continue
+		}
+		if ok {
+			runBatchJob(ctx, &jobs[i], owner)
+			return true
+		}
+	}
+	return false
+}
+
+// runBatchJob 分段处理任务中尚未处理的项，已处理的项不会重复处理
+func runBatchJob(ctx context.Context, job *model.BatchJob, owner string) {
+	logger.Log.Info("开始处理批量任务",
+		zap.String("jobId", job.ID),
+		zap.Int("total", job.Total),
+		zap.Int("processed", job.Processed))
+
+	for {
+		items, err := model.ListPendingBatchItems(job.ID, batchJobChunkSize)
+		if err != nil {
+			// 保持运行中状态，超时后由其他协程重新接手
+			logger.Log.Error("查询批量任务的待处理项失败", zap.String("jobId", job.ID), zap.Error(err))
+			return
+		}
+		if len(items) == 0 {
+			if err := model.FinishBatchJob(job.ID, owner); err != nil {
+				logger.Log.Error("更新批量任务状态失败", zap.String("jobId", job.ID), zap.Error(err))
+			}
+			logger.Log.Info("批量任务处理完成", zap.String("jobId", job.ID))
+			return
+		}
+
+		if err := runBatchChunk(ctx, job, items); err != nil {
+			if ctx.Err() != nil {
+				if err := model.ReleaseBatchJob(job.ID, owner); err != nil {
+					logger.Log.Error("放回批量任务失败", zap.String("jobId", job.ID), zap.Error(err))
+				}
+				logger.Log.Info("服务退出，批量任务放回等待队列", zap.String("jobId", job.ID))
+				return
+			}
+			logger.Log.Error("处理批量任务失败", zap.String("jobId", job.ID), zap.Error(err))
+			return
+		}
+
+		state, err := model.RefreshBatchJobProgress(job.ID, owner)
+		if err != nil {
+			logger.Log.Error("更新批量任务进度失败", zap.String("jobId", job.ID), zap.Error(err))
+			return
+		}
+		if state != model.BatchJobRunning {
+			logger.Log.Info("批量任务已取消或已被其他实例接手，停止处理",
+				zap.String("jobId", job.ID),
+				zap.String("status", state))
+			return
+		}
+	}
+}
+
+// runBatchChunk 处理一段待处理项，每完成一项立即保存结果
+func runBatchChunk(ctx context.Context, job *model.BatchJob, rows []model.BatchJobItem) error {
+	items := make([]BatchItem, 0, len(rows))
+	for i := range rows {
+		item := batchItemFromModel(&rows[i])
+		item.Options.WorkspaceID = job.WorkspaceID
+		items = append(items, item)
+	}
+	return StreamShortenURLs(ctx, items, job.UserID, job.Domain, job.Concurrency, func(r BatchShortenResult) error {
+		// 退出时不保存因取消而失败的项，保持待处理状态
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		row := &rows[r.Index]
+		row.ShortURL, row.Error, row.Duplicate = r.ShortURL, r.Error, r.Duplicate
+		row.Status = model.BatchItemSuccess
+		if r.Error != "" {
+			row.Status = model.BatchItemFailed
+		}
+		return model.SaveBatchItemResult(row)
+	})
+}
+
+// wakeBatchJobWorkers 通知空闲的处理协程有新任务
+func wakeBatchJobWorkers() {
+	select {
+	case batchJobWakeup <- struct{}{}:
+	default:
+	}
+}
+
+// SubmitBatchJob 提交异步批量生成任务，所有项先保存到数据库，再由后台协程处理
+func (s *ShortlinkService) SubmitBatchJob(ctx context.Context, req *shortlinkpb.BatchShortenRequest) (*shortlinkpb.SubmitBatchJobResponse, error) {
+	items := batchItems(req)
+	logger.Log.Info("收到提交批量任务请求", zap.String("userId", req.UserId), zap.Int("urlCount", len(items)))
+
+	if len(items) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "URL列表为空")
+	}
+	if len(items) > maxBatchJobItems {
+		return nil, status.Errorf(codes.InvalidArgument, "单个任务最多%d个URL", maxBatchJobItems)
+	}
+	domain, err := domainForCreate(req.UserId, req.Domain)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	job := &model.BatchJob{
+		ID:          uuid.NewString(),
+		UserID:      req.UserId,
+		Domain:      domain,
+		WorkspaceID: req.WorkspaceId,
+		Concurrency: int(req.Concurrency),
+		Status:      model.BatchJobPending,
+		Total:       len(items),
+	}
+	rows := make([]model.BatchJobItem, 0, len(items))
+	for i, item := range items {
+		row, err := batchJobItem(job.ID, i, item)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	if err := model.CreateBatchJob(job, rows); err != nil {
+		logger.Log.Error("保存批量任务失败", zap.String("userId", req.UserId), zap.Error(err))
+		return nil, fmt.Errorf("保存批量任务失败: %w", err)
+	}
+	wakeBatchJobWorkers()
+
+	logger.Log.Info("批量任务已提交", zap.String("jobId", job.ID), zap.Int("total", job.Total))
+	return &shortlinkpb.SubmitBatchJobResponse{Job: batchJobInfo(job)}, nil
+}
+
+// GetBatchJob 查询任务进度，并分页返回已处理的结果
+func (s *ShortlinkService) GetBatchJob(ctx context.Context, req *shortlinkpb.GetBatchJobRequest) (*shortlinkpb.GetBatchJobResponse, error) {
+	job, err := model.GetBatchJob(req.UserId, req.JobId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "任务不存在")
+	}
+	switch req.Status {
+	case "", model.BatchItemSuccess, model.BatchItemFailed:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "不支持的结果状态: %s", req.Status)
+	}
+
+	offset, limit := int(req.Offset), int(req.Limit)
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		limit = 100
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+	rows, total, err := model.ListBatchItemResults(job.ID, req.Status, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("查询批量任务结果失败: %w", err)
+	}
+
+	resp := &shortlinkpb.GetBatchJobResponse{
+		Job:         batchJobInfo(job),
+		Results:     make([]*shortlinkpb.BatchShortenResult, 0, len(rows)),
+		ResultTotal: total,
+	}
+	for _, row := range rows {
+		r := BatchShortenResult{
+			Index:       row.Index,
+			OriginalURL: row.OriginalURL,
+			ShortURL:    row.ShortURL,
+			Error:       row.Error,
+			Duplicate:   row.Duplicate,
+		}
+		if r.ShortURL != "" {
+			r.FullURL = FullURL(job.Domain, r.ShortURL)
+		}
+		resp.Results = append(resp.Results, r.toPB())
+	}
+	return resp, nil
+}
+
+// CancelBatchJob 取消任务，处理中的任务在当前段结束后停止
+func (s *ShortlinkService) CancelBatchJob(ctx context.Context, req *shortlinkpb.CancelBatchJobRequest) (*shortlinkpb.CancelBatchJobResponse, error) {
+	logger.Log.Info("收到取消批量任务请求", zap.String("userId", req.UserId), zap.String("jobId", req.JobId))
+
+	cancelled, err := model.CancelBatchJob(req.UserId, req.JobId)
+	if err != nil {
+		return nil, fmt.Errorf("取消批量任务失败: %w", err)
+	}
+	job, err := model.GetBatchJob(req.UserId, req.JobId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "任务不存在")
+	}
+	if !cancelled {
+		return nil, status.Errorf(codes.FailedPrecondition, "任务已结束: %s", job.Status)
+	}
+	return &shortlinkpb.CancelBatchJobResponse{Job: batchJobInfo(job)}, nil
+}
+
+// batchJobItem 转换为数据库中的待处理项
+func batchJobItem(jobID string, index int, item BatchItem) (model.BatchJobItem, error) {
+	row := model.BatchJobItem{
+		JobID:        jobID,
+		Index:        index,
+		OriginalURL:  item.OriginalURL,
+		Alias:        item.Options.Alias,
+		ExpireAt:     item.Options.ExpireAt,
+		RedirectType: item.Options.RedirectType,
+		Status:       model.BatchItemPending,
+	}
+	if len(item.Options.Tags) > 0 {
+		tags, err := json.Marshal(item.Options.Tags)
+		if err != nil {
+			return row, err
+		}
+		row.Tags = string(tags)
+	}
+	return row, nil
+}
+
+// batchItemFromModel 从数据库中的待处理项恢复批量生成参数
+func batchItemFromModel(row *model.BatchJobItem) BatchItem {
+	item := BatchItem{
+		OriginalURL: row.OriginalURL,
+		Options: ShortenOptions{
+			Alias:        row.Alias,
+			ExpireAt:     row.ExpireAt,
+			RedirectType: row.RedirectType,
+		},
+	}
+	if row.Tags != "" {
+		if err := json.Unmarshal([]byte(row.Tags), &item.Options.Tags); err != nil {
+			logger.Log.Warn("解析批量任务的标签失败", zap.String("jobId", row.JobID), zap.Error(err))
+		}
+	}
+	return item
+}
+
+// batchJobInfo 转换任务进度
+func batchJobInfo(job *model.BatchJob) *shortlinkpb.BatchJobInfo {
+	info := &shortlinkpb.BatchJobInfo{
+		JobId:        job.ID,
+		Status:       job.Status,
+		Total:        int32(job.Total),
+		Processed:    int32(job.Processed),
+		SuccessCount: int32(job.SuccessCount),
+		FailedCount:  int32(job.FailedCount),
+		CreateTime:   job.CreateTime.Unix(),
+		UpdateTime:   job.UpdateTime.Unix(),
+	}
+	if job.FinishTime != nil {
+		info.FinishTime = job.FinishTime.Unix()
+	}
+	return info
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"shortLink/proto/shortlinkpb"
+	"shortLink/shortlinkcore/config"
+	"shortLink/shortlinkcore/logger"
+	"shortLink/shortlinkcore/model"
+	"shortLink/shortlinkcore/pkg/botdetect"
+	"shortLink/shortlinkcore/service/click"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	botSignatureReload     = 30 * time.Second // 其他实例更新特征后，最迟这么久生效
+	defaultBotRepeatLimit  = 20
+	maxCustomBotSignatures = 500
+	minBotSignatureLength  = 3 // 太短的特征会误伤正常访问
+	maxBotSignatureLength  = 128
+	maxBotSignatureUpdates = 100
+)
+
+// botClassifier 识别机器人访问，启动前只使用内置特征
+var botClassifier = botdetect.NewClassifier(botdetect.Effective(botdetect.DefaultSignatures, nil, nil), nil)
+
+// StartBotClassifier 加载配置的 IP 段和管理员维护的特征，并定期重新加载特征
+func StartBotClassifier() {
+	ranges, err := botdetect.ParseRanges(config.GlobalConfig.App.BotIPRanges)
+	if err != nil {
+		logger.Log.Error("机器人 IP 段配置错误，不按 IP 段识别", zap.Error(err))
+	} else {
+		botClassifier.SetRanges(ranges)
+	}
+	loadBotSignatures()
+	go func() {
+		ticker := time.NewTicker(botSignatureReload)
+		defer ticker.Stop()
+		for range ticker.C {
+			loadBotSignatures()
+		}
+	}()
+}
+
+// loadBotSignatures 合并内置特征和管理员维护的特征，读取失败时保留当前特征
+func loadBotSignatures() {
+	rows, err := model.ListBotSignatures()
+	if err != nil {
+		logger.Log.Error("加载机器人特征失败", zap.Error(err))
+		return
+	}
+	custom, disabled := splitBotSignatures(rows)
+	botClassifier.SetSignatures(botdetect.Effective(botdetect.DefaultSignatures, custom, disabled))
+}
+
+// splitBotSignatures 将管理员维护的特征分为增加的和停用的
+func splitBotSignatures(rows []model.BotSignature) (custom, disabled []string) {
+	for _, r := range rows {
+		if r.Disabled {
+			disabled = append(disabled, r.Pattern)
+		} else {
+			custom = append(custom, r.Pattern)
+		}
+	}
+	return custom, disabled
+}
+
+// classifyClick 判断一次点击是否来自机器人，返回原因，正常访问时为空
+// 先按 User-Agent 特征和 IP 段识别，再检查同一 IP 是否在一分钟内反复访问同一短链接
+func classifyClick(linkKey, userAgent, ip string) string {
+	if reason := botClassifier.Classify(userAgent, ip); reason != "" {
+		return reason
+	}
+	if ip == "" {
+		return ""
+	}
+	n, err := click.CountIPVisit(ip, linkKey, time.Now())
+	if err != nil {
+		logger.Log.Warn("统计 IP 访问次数失败", zap.String("shortUrl", linkKey), zap.Error(err))
+		return ""
+	}
+	limit := config.GlobalConfig.App.BotRepeatThreshold
+	if limit <= 0 {
+		limit = defaultBotRepeatLimit
+	}
+	if n > limit {
+		return botdetect.ReasonRepeated
+	}
+	return ""
+}
+
+// botSignatureList 返回内置特征和管理员增加的特征，内置特征带启用状态
+func botSignatureList(rows []model.BotSignature) []*shortlinkpb.BotSignature {
+	custom, disabled := splitBotSignatures(rows)
+	off := make(map[string]bool, len(disabled))
+	for _, p := range disabled {
+		off[p] = true
+	}
+	builtin := make(map[string]bool, len(botdetect.DefaultSignatures))
+	result := make([]*shortlinkpb.BotSignature, 0, len(botdetect.DefaultSignatures)+len(custom))
+	for _, p := range botdetect.DefaultSignatures {
+		builtin[p] = true
+		result = append(result, &shortlinkpb.BotSignature{Pattern: p, Builtin: true, Enabled: !off[p]})
+	}
+	for _, p := range custom {
+		if !builtin[p] {
+			result = append(result, &shortlinkpb.BotSignature{Pattern: p, Enabled: true})
+		}
+	}
+	return result
+}
+
+// ListBotSignatures 查询机器人 User-Agent 特征
+func (s *ShortlinkService) ListBotSignatures(ctx context.Context, req *shortlinkpb.ListBotSignaturesRequest) (*shortlinkpb.ListBotSignaturesResponse, error) {
+	rows, err := model.ListBotSignatures()
+	if err != nil {
+		logger.Log.Error("查询机器人特征失败", zap.Error(err))
+		return nil, status.Error(codes.Internal, "查询机器人特征失败")
+	}
+	return &shortlinkpb.ListBotSignaturesResponse{Signatures: botSignatureList(rows)}, nil
+}
+
+// UpdateBotSignatures 增加、删除或停用机器人 User-Agent 特征
+// 内置特征不能删除，remove 中的内置特征会被停用，之后可以通过 add 重新启用
+func (s *ShortlinkService) UpdateBotSignatures(ctx context.Context, req *shortlinkpb.UpdateBotSignaturesRequest) (*shortlinkpb.UpdateBotSignaturesResponse, error) {
+	logger.Log.Info("收到更新机器人特征请求",
+		zap.String("userId", req.UserId),
+		zap.Strings("add", req.Add),
+		zap.Strings("remove", req.Remove))
+
+	// 1. 校验参数
+	if len(req.Add)+len(req.Remove) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "没有要更新的特征")
+	}
+	if len(req.Add)+len(req.Remove) > maxBotSignatureUpdates {
+		return nil, status.Errorf(codes.InvalidArgument, "单次最多更新%d个特征", maxBotSignatureUpdates)
+	}
+	add, err := normalizeBotSignatures(req.Add)
+	if err != nil {
+		return nil, err
+	}
+	remove, err := normalizeBotSignatures(req.Remove)
+	if err != nil {
+		return nil, err
+	}
+	for p := range add {
+		if remove[p] {
+			return nil, status.Errorf(codes.InvalidArgument, "特征不能同时增加和删除: %s", p)
+		}
+	}
+
+	// 2. 内置特征通过停用记录控制启用状态，自定义特征直接增删
+	builtin := make(map[string]bool, len(botdetect.DefaultSignatures))
+	for _, p := range botdetect.DefaultSignatures {
+		builtin[p] = true
+	}
+	var upsert []model.BotSignature
+	var deletes []string
+	for p := range add {
+		if builtin[p] {
+			deletes = append(deletes, p)
+		} else {
+			upsert = append(upsert, model.BotSignature{Pattern: p, UpdatedBy: req.UserId})
+		}
+	}
+	for p := range remove {
+		if builtin[p] {
+			upsert = append(upsert, model.BotSignature{Pattern: p, Disabled: true, UpdatedBy: req.UserId})
+		} else {
+			deletes = append(deletes, p)
+		}
+	}
+	rows, err := model.ListBotSignatures()
+	if err != nil {
+		logger.Log.Error("查询机器人特征失败", zap.Error(err))
+		return nil, status.Error(codes.Internal, "更新机器人特征失败")
+	}
+	custom, _ := splitBotSignatures(rows)
+	if countCustomAfter(custom, add, remove, builtin) > maxCustomBotSignatures {
+		return nil, status.Errorf(codes.FailedPrecondition, "自定义特征最多%d个", maxCustomBotSignatures)
+	}
+
+	// 3. 保存后立即在本实例生效，其他实例定期重新加载
+	if err := model.UpdateBotSignatures(upsert, deletes); err != nil {
+		logger.Log.Error("更新机器人特征失败", zap.Error(err))
+		return nil, status.Error(codes.Internal, "更新机器人特征失败")
+	}
+	loadBotSignatures()
+	if rows, err = model.ListBotSignatures(); err != nil {
+		return nil, status.Error(codes.Internal, "查询机器人特征失败")
+	}
+	logger.Log.Info("机器人特征已更新",
+		zap.String("userId", req.UserId),
+		zap.Int("added", len(add)),
+		zap.Int("removed", len(remove)))
+	return &shortlinkpb.UpdateBotSignaturesResponse{Signatures: botSignatureList(rows)}, nil
+}
+
+// normalizeBotSignatures 统一特征格式并校验长度
+func normalizeBotSignatures(patterns []string) (map[string]bool, error) {
+	result := make(map[string]bool, len(patterns))
+	for _, p := range patterns {
+		p = botdetect.Normalize(p)
+		if len([]rune(p)) < minBotSignatureLength || len(p) > maxBotSignatureLength {
+			return nil, status.Errorf(codes.InvalidArgument, "特征长度需要在%d到%d之间: %q", minBotSignatureLength, maxBotSignatureLength, p)
+		}
+		result[p] = true
+	}
+	return result, nil
+}
+
+// countCustomAfter 计算更新后的自定义特征数量
+func countCustomAfter(current []string, add, remove, builtin map[string]bool) int {
+	after := make(map[string]bool, len(current)+len(add))
+	for _, p := range current {
+		after[p] = true
+	}
+	for p := range add {
+		if !builtin[p] {
+			after[p] = true
+		}
+	}
+	for p := range remove {
+		delete(after, p)
+	}
+	return len(after)
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"shortLink/proto/shortlinkpb"
+	"shortLink/shortlinkcore/logger"
+	"shortLink/shortlinkcore/model"
+	"shortLink/shortlinkcore/service/click"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// 点击分布查询的限制
+const (
+	defaultBreakdownDays  = 30
+	maxBreakdownDays      = 366
+	defaultBreakdownLimit = 10
+	maxBreakdownLimit     = 50
+)
+
+// breakdownDimensions 支持查询的维度，未指定维度时按此顺序全部返回
+var breakdownDimensions = []string{
+	model.DimensionReferer,
+	model.DimensionBrowser,
+	model.DimensionOS,
+	model.DimensionDevice,
+	model.DimensionCountry,
+}
+
+// GetLinkBreakdown 按来源域名、浏览器、操作系统、设备和国家查询短链接在一段日期内的点击分布
+// 数据来自 analyticsservice 按天汇总的统计表，每个维度返回点击量最高的若干取值，其余合并为 other_clicks
+func (s *ShortlinkService) GetLinkBreakdown(ctx context.Context, req *shortlinkpb.GetLinkBreakdownRequest) (*shortlinkpb.GetLinkBreakdownResponse, error) {
+	logger.Log.Info("收到查询点击分布请求",
+		zap.String("shortUrl", req.ShortUrl),
+		zap.String("userId", req.UserId),
+		zap.Strings("dimensions", req.Dimensions))
+
+	// 1. 校验参数，起止时间按自然日对齐
+	to := time.Now()
+	if req.To > 0 {
+		to = time.Unix(req.To, 0)
+	}
+	toDay := click.NextBucket(click.GranularityDay, click.BucketStart(click.GranularityDay, to))
+	fromDay := toDay.AddDate(0, 0, -defaultBreakdownDays)
+	if req.From > 0 {
+		fromDay = click.BucketStart(click.GranularityDay, time.Unix(req.From, 0))
+	}
+	if !fromDay.Before(toDay) {
+		return nil, status.Error(codes.InvalidArgument, "起始时间不能晚于结束时间")
+	}
+	if fromDay.AddDate(0, 0, maxBreakdownDays).Before(toDay) {
+		return nil, status.Errorf(codes.InvalidArgument, "单次最多查询%d天", maxBreakdownDays)
+	}
+	dimensions := req.Dimensions
+	if len(dimensions) == 0 {
+		dimensions = breakdownDimensions
+	}
+	for _, d := range dimensions {
+		if !validBreakdownDimension(d) {
+			return nil, status.Errorf(codes.InvalidArgument, "不支持的维度: %s", d)
+		}
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultBreakdownLimit
+	}
+	if limit > maxBreakdownLimit {
+		limit = maxBreakdownLimit
+	}
+
+	// 2. 校验短链接归属
+	mapping, err := model.GetOwnedMapping(model.Owner{UserID: req.UserId, WorkspaceID: req.WorkspaceId}, requestDomain(req.Domain), req.ShortUrl)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Log.Warn("短链接不存在或不属于该用户或工作区",
+			zap.String("shortUrl", req.ShortUrl),
+			zap.String("userId", req.UserId))
+		return nil, status.Error(codes.NotFound, "短链接不存在")
+	}
+	if err != nil {
+		logger.Log.Error("查询短链接失败", zap.String("shortUrl", req.ShortUrl), zap.Error(err))
+		return nil, status.Error(codes.Internal, "查询点击分布失败")
+	}
+
+	// 3. 逐个维度读取排名
+	resp := &shortlinkpb.GetLinkBreakdownResponse{
+		From:       fromDay.Unix(),
+		To:         toDay.Unix(),
+		Breakdowns: make([]*shortlinkpb.Breakdown, 0, len(dimensions)),
+	}
+	for _, d := range dimensions {
+		items, total, err := model.GetTopDimensionValues(mapping.Key(), d, fromDay, toDay, limit)
+		if err != nil {
+			logger.Log.Error("查询点击分布失败",
+				zap.String("shortUrl", req.ShortUrl),
+				zap.String("dimension", d),
+				zap.Error(err))
+			return nil, status.Error(codes.Internal, "查询点击分布失败")
+		}
+		breakdown := &shortlinkpb.Breakdown{
+			Dimension:   d,
+			Items:       make([]*shortlinkpb.BreakdownItem, 0, len(items)),
+			TotalClicks: total,
+		}
+		listed := int64(0)
+		for _, item := range items {
+			breakdown.Items = append(breakdown.Items, &shortlinkpb.BreakdownItem{Value: item.Value, Clicks: item.Clicks})
+			listed += item.Clicks
+		}
+		breakdown.OtherClicks = total - listed
+		resp.Breakdowns = append(resp.Breakdowns, breakdown)
+	}
+	return resp, nil
+}
+
+func validBreakdownDimension(d string) bool {
+	for _, v := range breakdownDimensions {
+		if v == d {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package click
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"shortLink/shortlinkcore/cache"
+	"shortLink/shortlinkcore/logger"
+
+	"go.uber.org/zap"
+)
+
+// IncrBotClick 按分钟、小时、天记录一次机器人点击，机器人点击不计入点击量、排行榜和趋势
+func IncrBotClick(shortUrl, reason string) {
+	if err := incrBuckets(context.Background(), BotStatsKey, shortUrl, time.Now()); err != nil {
+		logger.Log.Error("增加机器人点击计数失败",
+			zap.String("shortUrl", shortUrl),
+			zap.String("reason", reason),
+			zap.Error(err))
+	}
+}
+
+// CountIPVisit 记录 IP 对短链接的一次访问，返回这一分钟内的访问次数，用于识别反复请求
+func CountIPVisit(ip, shortUrl string, at time.Time) (int64, error) {
+	ctx := context.Background()
+	key := fmt.Sprintf("bot:ip:%d:%s:%s", BucketStart(GranularityMinute, at).Unix(), shortUrl, ip)
+	pipe := cache.GetRedis().Pipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, 2*time.Minute)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
@@ -4,22 +4,50 @@ package click
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"time"
 
 	"shortLink/shortlinkcore/cache"
 	"shortLink/shortlinkcore/logger"
 
+	"github.com/go-redis/redis/v8"
 	"go.uber.org/zap"
 )
 
-func IncrClickCount(shortUrl, originalUrl string) {
+// VariantKey 返回短链接各 A/B 分组点击量的 Hash key
+func VariantKey(shortUrl string) string {
+	return "click:variant:" + shortUrl
+}
+
+// IncrClickCount 记录一次点击
+// 参数：
+//   - shortUrl: 短链接
+//   - originalUrl: 原始链接
+//   - variant: 命中的 A/B 分组，未分流时为空
+//   - scope: 短链接归属者的排行榜，见 OwnerRankScope
+//
+// 返回：
+//   - int64: 记录后的总点击量，计数失败时为0
+func IncrClickCount(shortUrl, originalUrl, variant, scope string) int64 {
 	ctx := context.Background()
 
 	logger.Log.Info("记录短链接点击",
 		zap.String("shortUrl", shortUrl),
-		zap.String("originalUrl", originalUrl))
+		zap.String("originalUrl", originalUrl),
+		zap.String("variant", variant))
+
+	// 按分组计数，用于 A/B 实验效果对比
+	if variant != "" {
+		if err := cache.GetRedis().HIncrBy(ctx, VariantKey(shortUrl), variant, 1).Err(); err != nil {
+			logger.Log.Error("增加分组点击计数失败",
+				zap.String("shortUrl", shortUrl),
+				zap.String("variant", variant),
+				zap.Error(err))
+		}
+	}
 
 	// 计数（用于单个点击展示）「记录某个短链总共被点击了多少次」，以便展示或查询，不用于排行。也可以不记录；
-	_, err := cache.GetRedis().Incr(ctx, fmt.Sprintf("click:%s-%s", shortUrl, originalUrl)).Result()
+	clicks, err := cache.GetRedis().Incr(ctx, fmt.Sprintf("click:%s-%s", shortUrl, originalUrl)).Result()
 	if err != nil {
 		logger.Log.Error("增加点击计数失败",
 			zap.String("shortUrl", shortUrl),
@@ -27,51 +55,100 @@ func IncrClickCount(shortUrl, originalUrl string) {
 			zap.Error(err))
 	}
 
-	// ✅ 更新排行榜（ZSet 自增）ZIncrBy 原子操作
-	_, err = cache.GetRedis().ZIncrBy(ctx, "shortlink:rank", 1, fmt.Sprintf("%s-%s", shortUrl, originalUrl)).Result()
-	if err != nil {
-		logger.Log.Error("更新排行榜失败",
-			zap.String("shortUrl", shortUrl),
-			zap.String("originalUrl", originalUrl),
-			zap.Error(err))
-	} else {
-		logger.Log.Debug("更新排行榜成功",
-			zap.String("shortUrl", shortUrl),
-			zap.String("originalUrl", originalUrl))
-	}
+	// 累加尚未持久化的增量，由后台协程定期写入 MySQL
+	recordDelta(ctx, shortUrl)
+
+	// 按分钟、小时、天计数，用于查询最近的点击趋势
+	recordBucketClicks(ctx, shortUrl, time.Now())
+
+	// ✅ 更新全站和归属者的排行榜（ZSet 自增），包括按分钟、小时累加的分时段排行榜
+	recordRank(ctx, Member(shortUrl, originalUrl), scope, time.Now())
 
 	// // 设置点击量 key 的过期（排行榜不需要）
 	// cache.GetRedis().Expire(ctx, fmt.Sprintf("click:%s", shortUrl), 7*24*time.Hour)
+	return clicks
+}
+
+// GetVariantClicks 获取短链接各 A/B 分组的点击量
+func GetVariantClicks(shortUrl string) (map[string]int64, error) {
+	raw, err := cache.GetRedis().HGetAll(context.Background(), VariantKey(shortUrl)).Result()
+	if err != nil {
+		logger.Log.Error("获取分组点击量失败", zap.String("shortUrl", shortUrl), zap.Error(err))
+		return nil, err
+	}
+	result := make(map[string]int64, len(raw))
+	for name, v := range raw {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			continue
+		}
+		result[name] = n
+	}
+	return result, nil
 }
 
-type ShortLinkRank struct {
-	ShortUrl string  `json:"short_url"`
-	Clicks   float64 `json:"clicks"`
+// Member 返回短链接在点击计数和排行榜中的成员名
+func Member(shortUrl, originalUrl string) string {
+	return fmt.Sprintf("%s-%s", shortUrl, originalUrl)
 }
 
-// 获取 Top N 热门短链
-func GetTopShortLinks(n int64) ([]ShortLinkRank, error) {
-	logger.Log.Info("获取热门短链接排行", zap.Int64("count", n))
+// renameMemberScript 把点击计数和各排行榜中旧成员的点击量合并到新成员
+// KEYS[1]、KEYS[2] 为旧、新点击计数，其余为排行榜；ARGV[1]、ARGV[2] 为旧、新成员
+var renameMemberScript = redis.NewScript(`
+local n = redis.call("GET", KEYS[1])
+if n then
+	redis.call("INCRBY", KEYS[2], n)
+	redis.call("DEL", KEYS[1])
+end
+for i = 3, #KEYS do
+	local score = redis.call("ZSCORE", KEYS[i], ARGV[1])
+	if score then
+		redis.call("ZINCRBY", KEYS[i], score, ARGV[2])
+		redis.call("ZREM", KEYS[i], ARGV[1])
+	end
+end
+return 1
+`)
 
-	ctx := context.Background()
-	raw, err := cache.GetRedis().ZRevRangeWithScores(ctx, "shortlink:rank", 0, n-1).Result()
+// RenameMember 短链接修改目标地址后，把点击计数和排行榜中的点击量迁移到新成员，
+// 包括全站和归属者的全部时间排行榜以及仍在保留期内的分时段排行榜
+func RenameMember(shortUrl, oldUrl, newUrl, scope string) error {
+	oldMember, newMember := Member(shortUrl, oldUrl), Member(shortUrl, newUrl)
+	if oldMember == newMember {
+		return nil
+	}
+	keys := []string{"click:" + oldMember, "click:" + newMember}
+	keys = append(keys, rankKeys(scope, time.Now())...)
+	err := renameMemberScript.Run(context.Background(), cache.GetRedis(), keys, oldMember, newMember).Err()
 	if err != nil {
-		logger.Log.Error("获取热门短链接排行失败",
-			zap.Int64("count", n),
+		logger.Log.Error("迁移短链接点击量失败",
+			zap.String("shortUrl", shortUrl),
+			zap.String("oldUrl", oldUrl),
+			zap.String("newUrl", newUrl),
 			zap.Error(err))
-		return nil, err
 	}
+	return err
+}
 
-	result := make([]ShortLinkRank, 0, len(raw))
-	for _, z := range raw {
-		result = append(result, ShortLinkRank{
-			ShortUrl: fmt.Sprintf("%v", z.Member),
-			Clicks:   z.Score,
-		})
+// GetClickCounts 批量获取短链接的点击量，顺序与 members 一致，没有点击记录时为0
+func GetClickCounts(members []string) ([]int64, error) {
+	if len(members) == 0 {
+		return nil, nil
+	}
+	keys := make([]string, 0, len(members))
+	for _, m := range members {
+		keys = append(keys, "click:"+m)
+	}
+	raw, err := cache.GetRedis().MGet(context.Background(), keys...).Result()
+	if err != nil {
+		logger.Log.Error("批量获取点击量失败", zap.Int("count", len(keys)), zap.Error(err))
+		return nil, err
+	}
+	result := make([]int64, len(raw))
+	for i, v := range raw {
+		if s, ok := v.(string); ok {
+			result[i], _ = strconv.ParseInt(s, 10, 64)
+		}
 	}
-
-	logger.Log.Info("获取热门短链接排行成功",
-		zap.Int64("count", n),
-		zap.Int("resultCount", len(result)))
 	return result, nil
 }
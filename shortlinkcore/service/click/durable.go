@@ -0,0 +1,212 @@
+package click
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"shortLink/shortlinkcore/cache"
+	"shortLink/shortlinkcore/logger"
+
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+)
+
+// 点击量持久化用到的 key
+const (
+	deltaKey      = "click:delta"          // 尚未写入 MySQL 的点击增量，Hash：LinkKey -> 增量
+	flushingKey   = "click:delta:flushing" // 正在写入的批次，ZSet：批次ID -> 取出时间
+	durableMarker = "click:durable"        // Redis 中的点击量已与 MySQL 对齐，不存在说明 Redis 数据丢失或首次启用
+	restoreCursor = "click:restore:cursor" // 重建进度，中断后从这里继续，避免重复累加
+)
+
+// batchKey 返回一批取出的点击增量的 key
+func batchKey(batchID string) string {
+	return "click:delta:batch:" + batchID
+}
+
+// recordDelta 累加尚未持久化的点击增量
+func recordDelta(ctx context.Context, shortUrl string) {
+	if err := cache.GetRedis().HIncrBy(ctx, deltaKey, shortUrl, 1).Err(); err != nil {
+		logger.Log.Error("记录点击增量失败", zap.String("shortUrl", shortUrl), zap.Error(err))
+	}
+}
+
+// takeDeltasScript 把当前的增量整体改名为一个批次并登记，之后的点击写入新的增量 Hash
+// 改名是原子的，同一份增量只会被一个实例取出
+var takeDeltasScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+redis.call("RENAME", KEYS[1], KEYS[2])
+redis.call("ZADD", KEYS[3], ARGV[1], ARGV[2])
+return 1
+`)
+
+// TakeDeltas 取出当前的点击增量作为批次 batchID，没有增量时返回 false
+func TakeDeltas(batchID string, now time.Time) (bool, error) {
+	ctx := context.Background()
+	keys := []string{deltaKey, batchKey(batchID), flushingKey}
+	n, err := takeDeltasScript.Run(ctx, cache.GetRedis(), keys, now.Unix(), batchID).Int()
+	if err != nil {
+		logger.Log.Error("取出点击增量失败", zap.Error(err))
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// BatchDeltas 读取一批点击增量，按 LinkKey 索引
+func BatchDeltas(batchID string) (map[string]int64, error) {
+	raw, err := cache.GetRedis().HGetAll(context.Background(), batchKey(batchID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]int64, len(raw))
+	for key, v := range raw {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil && n > 0 {
+			result[key] = n
+		}
+	}
+	return result, nil
+}
+
+// FinishBatch 批次写入 MySQL 后删除
+func FinishBatch(batchID string) error {
+	ctx := context.Background()
+	pipe := cache.GetRedis().TxPipeline()
+	pipe.Del(ctx, batchKey(batchID))
+	pipe.ZRem(ctx, flushingKey, batchID)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// StaleBatches 返回取出时间早于 before 的批次，通常是取出后实例退出没有写完的
+func StaleBatches(before time.Time) ([]string, error) {
+	return cache.GetRedis().ZRangeByScore(context.Background(), flushingKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(before.Unix(), 10),
+	}).Result()
+}
+
+// DurableReady Redis 中的点击量是否已与 MySQL 对齐
+func DurableReady() (bool, error) {
+	n, err := cache.GetRedis().Exists(context.Background(), durableMarker).Result()
+	return n > 0, err
+}
+
+// MarkDurable 标记 Redis 中的点击量已与 MySQL 对齐，同时清除重建进度
+func MarkDurable() error {
+	ctx := context.Background()
+	pipe := cache.GetRedis().TxPipeline()
+	pipe.Set(ctx, durableMarker, time.Now().Unix(), 0)
+	pipe.Del(ctx, restoreCursor)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// RestoreCursor 返回上次中断的重建进度，没有时为空
+func RestoreCursor() (string, error) {
+	cursor, err := cache.GetRedis().Get(context.Background(), restoreCursor).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	return cursor, err
+}
+
+// SnapshotTotals 首次启用持久化时读取全站排行榜中的总点击量，按 LinkKey 合并
+// 这些点击量已经包含尚未写入的增量，所以同时丢弃增量，避免重复计数
+func SnapshotTotals() (map[string]int64, error) {
+	ctx := context.Background()
+	rdb := cache.GetRedis()
+	if err := rdb.Del(ctx, deltaKey).Err(); err != nil {
+		return nil, err
+	}
+	totals := make(map[string]int64)
+	var cursor uint64
+	for {
+		items, next, err := rdb.ZScan(ctx, allTimeRankKey(GlobalRankScope), cursor, "", 1000).Result()
+		if err != nil {
+			return nil, err
+		}
+		// ZSCAN 返回成员和分数交替排列
+		for i := 0; i+1 < len(items); i += 2 {
+			key, _ := SplitMember(items[i])
+			score, _ := strconv.ParseFloat(items[i+1], 64)
+			totals[key] += int64(score)
+		}
+		if next == 0 {
+			return totals, nil
+		}
+		cursor = next
+	}
+}
+
+// RestoredClicks 从 MySQL 恢复的一个短链接的点击量
+type RestoredClicks struct {
+	ShortUrl    string // LinkKey
+	OriginalUrl string
+	Scope       string // 归属者的排行榜，见 OwnerRankScope
+	Clicks      int64
+	Ranked      bool // 是否计入排行榜，回收站中的短链接不计入
+}
+
+// ErrRestoreMoved 重建进度已被其他实例推进，或重建已经完成，当前实例应停止重建
+var ErrRestoreMoved = errors.New("重建进度已被其他实例推进")
+
+// restoreClicksScript 重建进度仍为 ARGV[1] 且尚未对齐时，累加一页点击量并把进度推进到 ARGV[2]
+// KEYS[1]、KEYS[2]、KEYS[3] 为重建进度、对齐标记和全站排行榜，之后每个短链接依次为点击计数和归属者的排行榜；
+// ARGV[3] 起每个短链接依次为成员、点击量、是否计入排行榜
+var restoreClicksScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[2]) == 1 then
+	return 0
+end
+local cursor = redis.call("GET", KEYS[1]) or ""
+if cursor ~= ARGV[1] then
+	return 0
+end
+redis.call("SET", KEYS[1], ARGV[2])
+for i = 0, (#ARGV - 2) / 3 - 1 do
+	local member, clicks, ranked = ARGV[3 + i * 3], ARGV[4 + i * 3], ARGV[5 + i * 3]
+	local counter, owner = KEYS[4 + i * 2], KEYS[5 + i * 2]
+	redis.call("INCRBY", counter, clicks)
+	if ranked == "1" then
+		redis.call("ZINCRBY", KEYS[3], clicks, member)
+		if owner ~= KEYS[3] then
+			redis.call("ZINCRBY", owner, clicks, member)
+		end
+	end
+end
+return 1
+`)
+
+// RestoreClicks Redis 数据丢失后用 MySQL 中保存的点击量重建点击计数和全部时间的排行榜
+// 使用累加而不是覆盖，数据丢失后新产生的点击不会被抹掉（它们还在增量中，之后会写入 MySQL）；
+// 只有重建进度仍为 prev 时才写入这一页并推进到 cursor，检查和写入是原子的，
+// 锁过期后多个实例同时重建也只有一个能推进，其余返回 ErrRestoreMoved，不会重复累加
+func RestoreClicks(items []RestoredClicks, prev, cursor string) error {
+	if len(items) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, 3+len(items)*2)
+	keys = append(keys, restoreCursor, durableMarker, allTimeRankKey(GlobalRankScope))
+	args := make([]any, 0, 2+len(items)*3)
+	args = append(args, prev, cursor)
+	for _, item := range items {
+		member := Member(item.ShortUrl, item.OriginalUrl)
+		ranked := "0"
+		if item.Ranked {
+			ranked = "1"
+		}
+		keys = append(keys, "click:"+member, allTimeRankKey(item.Scope))
+		args = append(args, member, item.Clicks, ranked)
+	}
+	n, err := restoreClicksScript.Run(context.Background(), cache.GetRedis(), keys, args...).Int()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrRestoreMoved
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package click
+
+import (
+	"encoding/json"
+
+	"shortLink/common/event"
+	"shortLink/shortlinkcore/config"
+	"shortLink/shortlinkcore/logger"
+	"shortLink/shortlinkcore/mq"
+
+	"go.uber.org/zap"
+)
+
+// clickTopic 点击事件的 Topic
+func clickTopic() string {
+	if t := config.GlobalConfig.Kafka.ClickTopic; t != "" {
+		return t
+	}
+	return event.ClickTopic
+}
+
+// PublishEvent 发送点击事件，按短链接分区以保证同一短链接的事件有序
+// 发送失败只记录日志，不影响跳转和点击计数
+func PublishEvent(e *event.Click) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		logger.Log.Error("序列化点击事件失败", zap.String("code", e.Code), zap.Error(err))
+		return
+	}
+	if !mq.SendMessage(clickTopic(), e.Code, data) {
+		logger.Log.Warn("点击事件发送队列不可用，丢弃事件", zap.String("code", e.Code))
+	}
+}
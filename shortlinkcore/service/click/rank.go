@@ -0,0 +1,337 @@
+package click
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"shortLink/shortlinkcore/cache"
+	"shortLink/shortlinkcore/logger"
+
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+)
+
+// 排行榜的时间窗口
+const (
+	WindowHour = "hour" // 最近60分钟
+	WindowDay  = "day"  // 最近24小时
+	WindowWeek = "week" // 最近7天
+	WindowAll  = "all"  // 全部时间
+)
+
+// 排行方式
+const (
+	RankByClicks   = "clicks"   // 按窗口内的点击量
+	RankByTrending = "trending" // 按指数衰减后的热度，越近的点击权重越高
+)
+
+// GlobalRankScope 全站排行榜，只对管理员开放
+const GlobalRankScope = ""
+
+// OwnerRankScope 返回短链接归属者的排行榜，工作区的短链接计入工作区，个人短链接计入用户
+// 没有归属者时返回空，只计入全站排行榜
+func OwnerRankScope(userID, workspaceID string) string {
+	if workspaceID != "" {
+		return "workspace:" + workspaceID
+	}
+	if userID != "" {
+		return "user:" + userID
+	}
+	return GlobalRankScope
+}
+
+// allTimeRankKey 返回全部时间的排行榜 key，全站排行榜沿用原来的 key
+func allTimeRankKey(scope string) string {
+	if scope == GlobalRankScope {
+		return "shortlink:rank"
+	}
+	return "shortlink:rank:" + scope
+}
+
+// rankWindow 时间窗口由哪种粒度的分时段排行榜合并而成
+type rankWindow struct {
+	granularity string
+	buckets     int           // 合并的时间段数量，包含当前时间段
+	cacheTTL    time.Duration // 合并结果的缓存时间
+}
+
+var rankWindows = map[string]rankWindow{
+	WindowHour: {granularity: GranularityMinute, buckets: 60, cacheTTL: 5 * time.Second},
+	WindowDay:  {granularity: GranularityHour, buckets: 24, cacheTTL: 30 * time.Second},
+	WindowWeek: {granularity: GranularityHour, buckets: 7 * 24, cacheTTL: time.Minute},
+}
+
+// rankBucketTTL 分时段排行榜的保留时间，比最长的窗口多留一段
+var rankBucketTTL = map[string]time.Duration{
+	GranularityMinute: 2 * time.Hour,
+	GranularityHour:   8 * 24 * time.Hour,
+}
+
+// ValidWindow 是否为支持的时间窗口
+func ValidWindow(w string) bool {
+	_, ok := rankWindows[w]
+	return ok || w == WindowAll
+}
+
+// RankBucketKey 返回某个时间段的排行榜 key
+func RankBucketKey(scope, g string, bucket time.Time) string {
+	if scope == GlobalRankScope {
+		return fmt.Sprintf("rank:%s:%d", g, bucket.Unix())
+	}
+	return fmt.Sprintf("rank:%s:%s:%d", scope, g, bucket.Unix())
+}
+
+// recordRank 在全站和归属者的排行榜中累加点击，包括全部时间以及当前分钟、小时的排行榜
+func recordRank(ctx context.Context, member, scope string, at time.Time) {
+	scopes := []string{GlobalRankScope}
+	if scope != GlobalRankScope {
+		scopes = append(scopes, scope)
+	}
+	pipe := cache.GetRedis().Pipeline()
+	for _, sc := range scopes {
+		pipe.ZIncrBy(ctx, allTimeRankKey(sc), 1, member)
+		for g, ttl := range rankBucketTTL {
+			key := RankBucketKey(sc, g, BucketStart(g, at))
+			pipe.ZIncrBy(ctx, key, 1, member)
+			pipe.Expire(ctx, key, ttl)
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.Log.Error("更新排行榜失败",
+			zap.String("member", member),
+			zap.String("scope", scope),
+			zap.Error(err))
+	}
+}
+
+// rankKeys 返回短链接所在的全部排行榜：全站和归属者的全部时间排行榜，以及保留期内的分时段排行榜
+func rankKeys(scope string, now time.Time) []string {
+	scopes := []string{GlobalRankScope}
+	if scope != GlobalRankScope {
+		scopes = append(scopes, scope)
+	}
+	var keys []string
+	for _, sc := range scopes {
+		keys = append(keys, allTimeRankKey(sc))
+		for g, ttl := range rankBucketTTL {
+			for b := BucketStart(g, now); !b.Before(now.Add(-ttl)); b = prevBucket(g, b) {
+				keys = append(keys, RankBucketKey(sc, g, b))
+			}
+		}
+	}
+	return keys
+}
+
+// RemoveFromRanks 将同一归属者的短链接从全站和归属者的排行榜中移除，包括保留期内的分时段排行榜
+func RemoveFromRanks(ctx context.Context, scope string, members ...string) error {
+	if len(members) == 0 {
+		return nil
+	}
+	args := make([]any, len(members))
+	for i, m := range members {
+		args[i] = m
+	}
+	pipe := cache.GetRedis().Pipeline()
+	for _, key := range rankKeys(scope, time.Now()) {
+		pipe.ZRem(ctx, key, args...)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// RankedMember 按点击量重新加入排行榜的短链接
+type RankedMember struct {
+	Member string // 见 Member
+	Scope  string // 归属者的排行榜，见 OwnerRankScope
+	Clicks int64
+}
+
+// RestoreRanks 按点击量将短链接重新加入全站和归属者的全部时间排行榜
+// 分时段排行榜没有保留每个时间段的点击量，无法恢复，之后的点击照常计入
+func RestoreRanks(ctx context.Context, items []RankedMember) error {
+	if len(items) == 0 {
+		return nil
+	}
+	pipe := cache.GetRedis().Pipeline()
+	for _, item := range items {
+		z := &redis.Z{Score: float64(item.Clicks), Member: item.Member}
+		pipe.ZAdd(ctx, allTimeRankKey(GlobalRankScope), z)
+		if item.Scope != GlobalRankScope {
+			pipe.ZAdd(ctx, allTimeRankKey(item.Scope), z)
+		}
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// ownerRankMarker 已用全站排行榜补齐归属者的全部时间排行榜，归属者排行榜上线前的点击只记录在全站排行榜中
+const ownerRankMarker = "rank:owner:backfilled"
+
+// OwnerRanksBackfilled 是否已补齐归属者的全部时间排行榜
+func OwnerRanksBackfilled() (bool, error) {
+	n, err := cache.GetRedis().Exists(context.Background(), ownerRankMarker).Result()
+	return n > 0, err
+}
+
+// MarkOwnerRanksBackfilled 标记已补齐归属者的全部时间排行榜
+func MarkOwnerRanksBackfilled() error {
+	return cache.GetRedis().Set(context.Background(), ownerRankMarker, time.Now().Unix(), 0).Err()
+}
+
+// ScanGlobalRank 分批读取全站排行榜的成员，cursor 为0时从头开始，返回的 cursor 为0时表示读取完毕
+func ScanGlobalRank(cursor uint64, count int64) ([]string, uint64, error) {
+	items, next, err := cache.GetRedis().ZScan(context.Background(), allTimeRankKey(GlobalRankScope), cursor, "", count).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+	// ZSCAN 返回成员和分数交替排列
+	members := make([]string, 0, len(items)/2)
+	for i := 0; i+1 < len(items); i += 2 {
+		members = append(members, items[i])
+	}
+	return members, next, nil
+}
+
+// backfillOwnerRankScript 把成员在全站排行榜中的点击量写入归属者的排行榜
+// KEYS[1] 为全站排行榜，KEYS[i+1] 为 ARGV[i] 所属的排行榜；读取和写入是原子的，补齐期间的新点击不会被覆盖
+var backfillOwnerRankScript = redis.NewScript(`
+for i = 1, #ARGV do
+	local score = redis.call("ZSCORE", KEYS[1], ARGV[i])
+	if score then
+		redis.call("ZADD", KEYS[i + 1], score, ARGV[i])
+	end
+end
+return 1
+`)
+
+// BackfillOwnerRanks 用全站排行榜中的点击量补齐归属者的全部时间排行榜，没有归属者的成员忽略
+func BackfillOwnerRanks(items []RankedMember) error {
+	keys := []string{allTimeRankKey(GlobalRankScope)}
+	args := make([]any, 0, len(items))
+	for _, item := range items {
+		if item.Scope == GlobalRankScope {
+			continue
+		}
+		keys = append(keys, allTimeRankKey(item.Scope))
+		args = append(args, item.Member)
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	return backfillOwnerRankScript.Run(context.Background(), cache.GetRedis(), keys, args...).Err()
+}
+
+// prevBucket 返回上一个分钟或小时时间段
+func prevBucket(g string, b time.Time) time.Time {
+	if g == GranularityMinute {
+		return b.Add(-time.Minute)
+	}
+	return b.Add(-time.Hour)
+}
+
+// windowBuckets 返回窗口覆盖的时间段，从最早到当前
+func windowBuckets(w rankWindow, now time.Time) []time.Time {
+	buckets := make([]time.Time, w.buckets)
+	b := BucketStart(w.granularity, now)
+	for i := w.buckets - 1; i >= 0; i-- {
+		buckets[i] = b
+		b = prevBucket(w.granularity, b)
+	}
+	return buckets
+}
+
+// decayWeights 计算各时间段的衰减权重：以时间段中点到 now 的时长计算，每过一个半衰期权重减半
+func decayWeights(g string, buckets []time.Time, now time.Time, halfLife time.Duration) []float64 {
+	weights := make([]float64, len(buckets))
+	for i, b := range buckets {
+		mid := b.Add(NextBucket(g, b).Sub(b) / 2)
+		age := now.Sub(mid)
+		if age < 0 {
+			age = 0
+		}
+		weights[i] = math.Pow(0.5, float64(age)/float64(halfLife))
+	}
+	return weights
+}
+
+// ShortLinkRank 排行榜中的一项
+type ShortLinkRank struct {
+	ShortUrl    string  `json:"short_url"`    // 短链接在计数中的 key，格式同 model.LinkKey
+	OriginalUrl string  `json:"original_url"` // 点击时的原始链接
+	Clicks      float64 `json:"clicks"`       // 按点击量排行时为窗口内的点击量，按热度排行时为衰减后的热度
+}
+
+// GetTopShortLinks 获取排行榜中时间窗口内排名前 n 的短链接，scope 为 GlobalRankScope 或 OwnerRankScope 的返回值
+// 全部时间直接读取总排行榜；其他窗口用 ZUNIONSTORE 合并最近的分时段排行榜，按热度排行时为各时间段加上衰减权重，
+// 合并结果缓存几秒到一分钟，避免每次查询都重新合并
+func GetTopShortLinks(scope, window, mode string, n int64, halfLife time.Duration) ([]ShortLinkRank, error) {
+	logger.Log.Info("获取热门短链接排行",
+		zap.String("scope", scope),
+		zap.String("window", window),
+		zap.String("mode", mode),
+		zap.Int64("count", n))
+
+	ctx := context.Background()
+	key := allTimeRankKey(scope)
+	if window != WindowAll {
+		var err error
+		if key, err = unionRank(ctx, scope, window, mode, halfLife); err != nil {
+			logger.Log.Error("合并分时段排行榜失败",
+				zap.String("window", window),
+				zap.String("mode", mode),
+				zap.Error(err))
+			return nil, err
+		}
+	}
+	raw, err := cache.GetRedis().ZRevRangeWithScores(ctx, key, 0, n-1).Result()
+	if err != nil {
+		logger.Log.Error("获取热门短链接排行失败",
+			zap.Int64("count", n),
+			zap.Error(err))
+		return nil, err
+	}
+
+	result := make([]ShortLinkRank, 0, len(raw))
+	for _, z := range raw {
+		shortUrl, originalUrl := SplitMember(fmt.Sprintf("%v", z.Member))
+		result = append(result, ShortLinkRank{
+			ShortUrl:    shortUrl,
+			OriginalUrl: originalUrl,
+			Clicks:      z.Score,
+		})
+	}
+
+	logger.Log.Info("获取热门短链接排行成功",
+		zap.Int64("count", n),
+		zap.Int("resultCount", len(result)))
+	return result, nil
+}
+
+// unionRank 合并窗口内的分时段排行榜，返回合并结果的 key，缓存未过期时直接复用
+func unionRank(ctx context.Context, scope, window, mode string, halfLife time.Duration) (string, error) {
+	w := rankWindows[window]
+	dest := fmt.Sprintf("rank:union:%s:%s", mode, window)
+	if scope != GlobalRankScope {
+		dest = fmt.Sprintf("rank:union:%s:%s:%s", scope, mode, window)
+	}
+	if n, err := cache.GetRedis().Exists(ctx, dest).Result(); err == nil && n > 0 {
+		return dest, nil
+	}
+
+	now := time.Now()
+	buckets := windowBuckets(w, now)
+	store := &redis.ZStore{Keys: make([]string, 0, len(buckets)), Aggregate: "SUM"}
+	for _, b := range buckets {
+		store.Keys = append(store.Keys, RankBucketKey(scope, w.granularity, b))
+	}
+	if mode == RankByTrending {
+		store.Weights = decayWeights(w.granularity, buckets, now, halfLife)
+	}
+	pipe := cache.GetRedis().TxPipeline()
+	pipe.ZUnionStore(ctx, dest, store)
+	pipe.Expire(ctx, dest, w.cacheTTL)
+	_, err := pipe.Exec(ctx)
+	return dest, err
+}
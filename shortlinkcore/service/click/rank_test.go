@@ -0,0 +1,65 @@
+package click
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWindowBuckets(t *testing.T) {
+	now := time.Date(2025, 3, 5, 10, 15, 30, 0, time.Local)
+
+	t.Run("最近一小时按分钟合并", func(t *testing.T) {
+		buckets := windowBuckets(rankWindows[WindowHour], now)
+		assert.Len(t, buckets, 60)
+		assert.Equal(t, time.Date(2025, 3, 5, 9, 16, 0, 0, time.Local), buckets[0])
+		assert.Equal(t, time.Date(2025, 3, 5, 10, 15, 0, 0, time.Local), buckets[59])
+	})
+
+	t.Run("最近一周按小时合并", func(t *testing.T) {
+		buckets := windowBuckets(rankWindows[WindowWeek], now)
+		assert.Len(t, buckets, 168)
+		assert.Equal(t, time.Date(2025, 2, 26, 11, 0, 0, 0, time.Local), buckets[0])
+		assert.Equal(t, time.Date(2025, 3, 5, 10, 0, 0, 0, time.Local), buckets[167])
+	})
+}
+
+func TestDecayWeights(t *testing.T) {
+	now := time.Date(2025, 3, 5, 10, 30, 0, 0, time.Local)
+	buckets := []time.Time{
+		time.Date(2025, 3, 5, 4, 0, 0, 0, time.Local),
+		time.Date(2025, 3, 5, 9, 0, 0, 0, time.Local),
+		time.Date(2025, 3, 5, 10, 0, 0, 0, time.Local),
+	}
+	weights := decayWeights(GranularityHour, buckets, now, 6*time.Hour)
+	assert.InDelta(t, 0.5, weights[0], 1e-9)
+	assert.InDelta(t, 0.890899, weights[1], 1e-6)
+	assert.InDelta(t, 1, weights[2], 1e-9)
+}
+
+func TestValidWindow(t *testing.T) {
+	for _, w := range []string{WindowHour, WindowDay, WindowWeek, WindowAll} {
+		assert.True(t, ValidWindow(w))
+	}
+	assert.False(t, ValidWindow("month"))
+}
+
+func TestRankKeys(t *testing.T) {
+	now := time.Date(2025, 3, 5, 10, 15, 30, 0, time.Local)
+
+	t.Run("全站排行榜", func(t *testing.T) {
+		keys := rankKeys(GlobalRankScope, now)
+		assert.Contains(t, keys, "shortlink:rank")
+		assert.Contains(t, keys, RankBucketKey(GlobalRankScope, GranularityMinute, time.Date(2025, 3, 5, 8, 16, 0, 0, time.Local)))
+		assert.Contains(t, keys, RankBucketKey(GlobalRankScope, GranularityHour, time.Date(2025, 2, 25, 11, 0, 0, 0, time.Local)))
+		assert.Equal(t, 1+120+192, len(keys))
+	})
+
+	t.Run("同时包含归属者的排行榜", func(t *testing.T) {
+		keys := rankKeys("user:1", now)
+		assert.Contains(t, keys, "shortlink:rank:user:1")
+		assert.Contains(t, keys, RankBucketKey("user:1", GranularityHour, time.Date(2025, 3, 5, 10, 0, 0, 0, time.Local)))
+		assert.Equal(t, 2*(1+120+192), len(keys))
+	})
+}
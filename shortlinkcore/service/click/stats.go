@@ -0,0 +1,151 @@
+package click
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"shortLink/shortlinkcore/cache"
+	"shortLink/shortlinkcore/logger"
+
+	"go.uber.org/zap"
+)
+
+// 点击趋势的时间粒度
+const (
+	GranularityMinute = "minute"
+	GranularityHour   = "hour"
+	GranularityDay    = "day"
+)
+
+// bucketSpec 每种粒度在 Redis 中的保留时间
+// recent 内的时间段从 Redis 读取，更早的从 analyticsservice 写入的 MySQL 统计表读取；
+// ttl 比 recent 多留一段，避免边界上的时间段刚好过期
+type bucketSpec struct {
+	recent time.Duration
+	ttl    time.Duration
+}
+
+var bucketSpecs = map[string]bucketSpec{
+	GranularityMinute: {recent: 24 * time.Hour, ttl: 25 * time.Hour},
+	GranularityHour:   {recent: 48 * time.Hour, ttl: 50 * time.Hour},
+	GranularityDay:    {recent: 48 * time.Hour, ttl: 4 * 24 * time.Hour},
+}
+
+// ValidGranularity 是否为支持的时间粒度
+func ValidGranularity(g string) bool {
+	_, ok := bucketSpecs[g]
+	return ok
+}
+
+// BucketStart 返回 t 所在时间段的开始时间，按本地时区划分小时和天
+func BucketStart(g string, t time.Time) time.Time {
+	t = t.In(time.Local)
+	switch g {
+	case GranularityMinute:
+		return t.Truncate(time.Minute)
+	case GranularityHour:
+		return t.Truncate(time.Hour)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	}
+}
+
+// NextBucket 返回下一个时间段的开始时间，按天时使用日历日，夏令时切换时也对齐到零点
+func NextBucket(g string, start time.Time) time.Time {
+	switch g {
+	case GranularityMinute:
+		return start.Add(time.Minute)
+	case GranularityHour:
+		return start.Add(time.Hour)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// Buckets 返回 [from, to) 覆盖的所有时间段的开始时间，超过 limit 个时返回 nil, false
+func Buckets(g string, from, to time.Time, limit int) ([]time.Time, bool) {
+	var buckets []time.Time
+	for b := BucketStart(g, from); b.Before(to); b = NextBucket(g, b) {
+		if len(buckets) >= limit {
+			return nil, false
+		}
+		buckets = append(buckets, b)
+	}
+	return buckets, true
+}
+
+// RecentSince 从这个时间开始的时间段可以从 Redis 读取
+func RecentSince(g string, now time.Time) time.Time {
+	return BucketStart(g, now.Add(-bucketSpecs[g].recent))
+}
+
+// StatsKey 返回短链接某个时间段的点击计数 key
+func StatsKey(g, shortUrl string, bucket time.Time) string {
+	return fmt.Sprintf("stats:%s:%s:%d", g, shortUrl, bucket.Unix())
+}
+
+// BotStatsKey 返回短链接某个时间段的机器人点击计数 key
+func BotStatsKey(g, shortUrl string, bucket time.Time) string {
+	return fmt.Sprintf("stats:bot:%s:%s:%d", g, shortUrl, bucket.Unix())
+}
+
+// recordBucketClicks 按分钟、小时、天累加点击量，key 带过期时间，只保留最近的数据
+func recordBucketClicks(ctx context.Context, shortUrl string, at time.Time) {
+	if err := incrBuckets(ctx, StatsKey, shortUrl, at); err != nil {
+		logger.Log.Error("增加分时段点击计数失败", zap.String("shortUrl", shortUrl), zap.Error(err))
+	}
+}
+
+// incrBuckets 累加 at 所在的各粒度时间段的计数
+func incrBuckets(ctx context.Context, keyFn func(g, shortUrl string, bucket time.Time) string, shortUrl string, at time.Time) error {
+	pipe := cache.GetRedis().Pipeline()
+	for g, spec := range bucketSpecs {
+		key := keyFn(g, shortUrl, BucketStart(g, at))
+		pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, spec.ttl)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetBucketClicks 批量读取各时间段的点击量，顺序与 buckets 一致，没有记录时为0
+func GetBucketClicks(g, shortUrl string, buckets []time.Time) ([]int64, error) {
+	result, err := getBuckets(StatsKey, g, shortUrl, buckets)
+	if err != nil {
+		logger.Log.Error("获取分时段点击量失败", zap.String("shortUrl", shortUrl), zap.Error(err))
+	}
+	return result, err
+}
+
+// GetBucketBotClicks 批量读取各时间段的机器人点击量，顺序与 buckets 一致，没有记录时为0
+func GetBucketBotClicks(g, shortUrl string, buckets []time.Time) ([]int64, error) {
+	result, err := getBuckets(BotStatsKey, g, shortUrl, buckets)
+	if err != nil {
+		logger.Log.Error("获取分时段机器人点击量失败", zap.String("shortUrl", shortUrl), zap.Error(err))
+	}
+	return result, err
+}
+
+// getBuckets 批量读取各时间段的计数
+func getBuckets(keyFn func(g, shortUrl string, bucket time.Time) string, g, shortUrl string, buckets []time.Time) ([]int64, error) {
+	if len(buckets) == 0 {
+		return nil, nil
+	}
+	keys := make([]string, 0, len(buckets))
+	for _, b := range buckets {
+		keys = append(keys, keyFn(g, shortUrl, b))
+	}
+	raw, err := cache.GetRedis().MGet(context.Background(), keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]int64, len(raw))
+	for i, v := range raw {
+		if s, ok := v.(string); ok {
+			result[i], _ = strconv.ParseInt(s, 10, 64)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,43 @@
+package click
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuckets(t *testing.T) {
+	from := time.Date(2025, 3, 1, 10, 15, 30, 0, time.Local)
+
+	t.Run("按小时对齐", func(t *testing.T) {
+		buckets, ok := Buckets(GranularityHour, from, from.Add(2*time.Hour), 100)
+		assert.True(t, ok)
+		assert.Equal(t, []time.Time{
+			time.Date(2025, 3, 1, 10, 0, 0, 0, time.Local),
+			time.Date(2025, 3, 1, 11, 0, 0, 0, time.Local),
+			time.Date(2025, 3, 1, 12, 0, 0, 0, time.Local),
+		}, buckets)
+	})
+
+	t.Run("按天对齐到零点", func(t *testing.T) {
+		buckets, ok := Buckets(GranularityDay, from, time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local), 100)
+		assert.True(t, ok)
+		assert.Len(t, buckets, 2)
+		assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.Local), buckets[0])
+		assert.Equal(t, time.Date(2025, 3, 2, 0, 0, 0, 0, time.Local), buckets[1])
+	})
+
+	t.Run("超过上限", func(t *testing.T) {
+		_, ok := Buckets(GranularityMinute, from, from.Add(2*time.Hour), 60)
+		assert.False(t, ok)
+	})
+}
+
+func TestRecentSince(t *testing.T) {
+	now := time.Date(2025, 3, 5, 10, 15, 0, 0, time.Local)
+	assert.Equal(t, time.Date(2025, 3, 4, 10, 15, 0, 0, time.Local), RecentSince(GranularityMinute, now))
+	assert.Equal(t, time.Date(2025, 3, 3, 10, 0, 0, 0, time.Local), RecentSince(GranularityHour, now))
+	assert.Equal(t, time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local), RecentSince(GranularityDay, now))
+	assert.False(t, ValidGranularity("week"))
+}
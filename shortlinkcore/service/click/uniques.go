@@ -0,0 +1,112 @@
+package click
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"shortLink/shortlinkcore/cache"
+	"shortLink/shortlinkcore/logger"
+
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+)
+
+// uniqueRetentionDays 每日独立访客（HyperLogLog）的保留天数，更早的日期不再计入
+const uniqueRetentionDays = 90
+
+// UniqueKey 返回短链接某天独立访客的 HyperLogLog key
+func UniqueKey(shortUrl string, day time.Time) string {
+	return "uv:" + shortUrl + ":" + day.In(time.Local).Format("20060102")
+}
+
+// VisitorID 根据加盐的 IP 和 User-Agent 生成访客标识，不保存原始 IP
+// IP 和 User-Agent 都为空时无法区分访客，返回空
+func VisitorID(salt, ip, userAgent string) string {
+	if ip == "" && userAgent == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(salt + "|" + ip + "|" + userAgent))
+	return hex.EncodeToString(sum[:16])
+}
+
+// RecordVisitor 将访客加入短链接当天的 HyperLogLog
+func RecordVisitor(shortUrl, visitor string, at time.Time) {
+	if visitor == "" {
+		return
+	}
+	ctx := context.Background()
+	key := UniqueKey(shortUrl, at)
+	pipe := cache.GetRedis().Pipeline()
+	pipe.PFAdd(ctx, key, visitor)
+	pipe.Expire(ctx, key, (uniqueRetentionDays+1)*24*time.Hour)
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.Log.Error("记录独立访客失败", zap.String("shortUrl", shortUrl), zap.Error(err))
+	}
+}
+
+// uniqueDays 返回 [from, to) 覆盖的、仍在保留期内的自然日
+func uniqueDays(from, to, now time.Time) []time.Time {
+	earliest := BucketStart(GranularityDay, now).AddDate(0, 0, -uniqueRetentionDays)
+	if from.Before(earliest) {
+		from = earliest
+	}
+	days, _ := Buckets(GranularityDay, from, to, uniqueRetentionDays+1)
+	return days
+}
+
+// UniqueVisitors 估算 [from, to) 内的独立访客数
+// 按覆盖的自然日合并每日的 HyperLogLog（PFCOUNT 多个 key 返回并集的基数），同一访客多天访问只计一次
+func UniqueVisitors(shortUrl string, from, to time.Time) (int64, error) {
+	days := uniqueDays(from, to, time.Now())
+	if len(days) == 0 {
+		return 0, nil
+	}
+	keys := make([]string, 0, len(days))
+	for _, d := range days {
+		keys = append(keys, UniqueKey(shortUrl, d))
+	}
+	n, err := cache.GetRedis().PFCount(context.Background(), keys...).Result()
+	if err != nil {
+		logger.Log.Error("获取独立访客数失败", zap.String("shortUrl", shortUrl), zap.Error(err))
+		return 0, err
+	}
+	return n, nil
+}
+
+// DailyUniqueVisitors 获取每天的独立访客数，顺序与 days 一致
+func DailyUniqueVisitors(shortUrl string, days []time.Time) ([]int64, error) {
+	if len(days) == 0 {
+		return nil, nil
+	}
+	ctx := context.Background()
+	pipe := cache.GetRedis().Pipeline()
+	cmds := make([]*redis.IntCmd, 0, len(days))
+	for _, d := range days {
+		cmds = append(cmds, pipe.PFCount(ctx, UniqueKey(shortUrl, d)))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.Log.Error("获取每日独立访客数失败", zap.String("shortUrl", shortUrl), zap.Error(err))
+		return nil, err
+	}
+	result := make([]int64, len(cmds))
+	for i, cmd := range cmds {
+		result[i] = cmd.Val()
+	}
+	return result, nil
+}
+
+// SplitMember 从排行榜成员名中拆出短链接 Key，是 Member 的逆操作
+// 短链接中可能含有连字符，按原始链接的协议（xxx://）定位分隔位置
+func SplitMember(member string) (shortUrl, originalUrl string) {
+	end := len(member)
+	if i := strings.Index(member, "://"); i >= 0 {
+		end = i
+	}
+	if i := strings.LastIndex(member[:end], "-"); i >= 0 {
+		return member[:i], member[i+1:]
+	}
+	return member, ""
+}
@@ -0,0 +1,52 @@
+package click
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVisitorID(t *testing.T) {
+	a := VisitorID("salt", "1.2.3.4", "Mozilla/5.0")
+	assert.Len(t, a, 32)
+	assert.Equal(t, a, VisitorID("salt", "1.2.3.4", "Mozilla/5.0"))
+	assert.NotEqual(t, a, VisitorID("salt", "1.2.3.4", "curl/8.0"))
+	assert.NotEqual(t, a, VisitorID("other", "1.2.3.4", "Mozilla/5.0"))
+	assert.Empty(t, VisitorID("salt", "", ""))
+}
+
+func TestUniqueDays(t *testing.T) {
+	now := time.Date(2025, 6, 10, 15, 0, 0, 0, time.Local)
+
+	t.Run("覆盖的自然日", func(t *testing.T) {
+		days := uniqueDays(now.Add(-30*time.Hour), now, now)
+		assert.Equal(t, []time.Time{
+			time.Date(2025, 6, 9, 0, 0, 0, 0, time.Local),
+			time.Date(2025, 6, 10, 0, 0, 0, 0, time.Local),
+		}, days)
+	})
+
+	t.Run("超过保留期的日期不计入", func(t *testing.T) {
+		days := uniqueDays(now.AddDate(-1, 0, 0), now, now)
+		assert.Len(t, days, uniqueRetentionDays+1)
+		assert.Equal(t, time.Date(2025, 6, 10, 0, 0, 0, 0, time.Local).AddDate(0, 0, -uniqueRetentionDays), days[0])
+	})
+}
+
+func TestSplitMember(t *testing.T) {
+	tests := []struct {
+		member, short, original string
+	}{
+		{"abc123-https://example.com/a-b", "abc123", "https://example.com/a-b"},
+		{"my-link-http://example.com", "my-link", "http://example.com"},
+		{"s.example.com/promo-https://example.com", "s.example.com/promo", "https://example.com"},
+		{"abc-myapp://open", "abc", "myapp://open"},
+	}
+	for _, tt := range tests {
+		short, original := SplitMember(tt.member)
+		assert.Equal(t, tt.short, short, tt.member)
+		assert.Equal(t, tt.original, original, tt.member)
+		assert.Equal(t, tt.member, Member(short, original))
+	}
+}
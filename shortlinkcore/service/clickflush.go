@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"shortLink/shortlinkcore/cache"
+	"shortLink/shortlinkcore/logger"
+	"shortLink/shortlinkcore/model"
+	"shortLink/shortlinkcore/pkg/locker"
+	"shortLink/shortlinkcore/service/click"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	clickFlushInterval   = 10 * time.Second
+	clickBatchStaleAfter = 2 * time.Minute // 批次取出后超过这个时间仍未写完，视为实例已退出，由其他实例重新写入
+	clickBatchRetention  = 24 * time.Hour  // 已写入批次的记录保留时间
+	clickRestorePage     = 1000
+	clickReconcileLock   = "lock:click:reconcile"
+)
+
+var (
+	clickFlushCancel context.CancelFunc
+	clickFlushWG     sync.WaitGroup
+)
+
+// StartClickFlusher 启动定期把 Redis 中的点击增量写入 MySQL 的协程
+// 启动时如果 Redis 中的点击量还没有与 MySQL 对齐（Redis 数据丢失或首次启用），先进行对齐
+func StartClickFlusher() {
+	var ctx context.Context
+	ctx, clickFlushCancel = context.WithCancel(context.Background())
+	clickFlushWG.Add(1)
+	go func() {
+		defer clickFlushWG.Done()
+		flushClicks()
+		ticker := time.NewTicker(clickFlushInterval)
+		defer ticker.Stop()
+		lastCleanup := time.Now()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			flushClicks()
+			if time.Since(lastCleanup) > time.Hour {
+				if err := model.DeleteClickFlushBatches(time.Now().Add(-clickBatchRetention)); err != nil {
+					logger.Log.Error("清理点击增量批次记录失败", zap.Error(err))
+				}
+				lastCleanup = time.Now()
+			}
+		}
+	}()
+	logger.Log.Info("点击量持久化协程已启动", zap.Duration("interval", clickFlushInterval))
+}
+
+// StopClickFlusher 停止定期写入，退出前再写入一次
+func StopClickFlusher() {
+	if clickFlushCancel == nil {
+		return
+	}
+	clickFlushCancel()
+	clickFlushWG.Wait()
+	flushClicks()
+}
+
+// flushClicks 写入上次没有写完的批次，再取出当前的增量写入
+// 同一批次由 MySQL 中的批次记录保证只累加一次，多个实例同时写入也不会重复计数
+func flushClicks() {
+	ready, err := click.DurableReady()
+	if err != nil {
+		logger.Log.Error("检查点击量持久化状态失败", zap.Error(err))
+		return
+	}
+	if !ready {
+		reconcileClicks()
+		return
+	}
+
+	now := time.Now()
+	stale, err := click.StaleBatches(now.Add(-clickBatchStaleAfter))
+	if err != nil {
+		logger.Log.Error("查询未写完的点击增量批次失败", zap.Error(err))
+	}
+	for _, id := range stale {
+		flushClickBatch(id)
+	}
+
+	id := uuid.NewString()
+	taken, err := click.TakeDeltas(id, now)
+	if err != nil || !taken {
+		return
+	}
+	flushClickBatch(id)
+}
+
+// flushClickBatch 把一批增量写入 MySQL，失败时保留批次，过期后重试
+func flushClickBatch(id string) {
+	deltas, err := click.BatchDeltas(id)
+	if err != nil {
+		logger.Log.Error("读取点击增量批次失败", zap.String("batch", id), zap.Error(err))
+		return
+	}
+	applied, err := model.ApplyClickDeltas(id, deltas)
+	if err != nil {
+		logger.Log.Error("写入点击增量失败", zap.String("batch", id), zap.Int("links", len(deltas)), zap.Error(err))
+		return
+	}
+	if err := click.FinishBatch(id); err != nil {
+		logger.Log.Error("删除点击增量批次失败", zap.String("batch", id), zap.Error(err))
+	}
+	logger.Log.Debug("点击增量已写入",
+		zap.String("batch", id),
+		zap.Int("links", len(deltas)),
+		zap.Bool("applied", applied))
+}
+
+// reconcileClicks 对齐 Redis 和 MySQL 中的点击量，只由一个实例执行
+// 重建耗时超过锁的有效期时其他实例可能同时开始重建，由 RestoreClicks 比较并推进重建进度保证只累加一次
+// MySQL 中还没有点击量时为首次启用，用 Redis 中已有的点击量初始化 MySQL；否则说明 Redis 数据丢失，用 MySQL 重建
+func reconcileClicks() {
+	lock := locker.NewRedisLock(cache.GetRedis(), clickReconcileLock, 5*time.Minute)
+	ok, err := lock.TryLock()
+	if err != nil || !ok {
+		return
+	}
+	defer lock.Unlock()
+
+	if ready, err := click.DurableReady(); err != nil || ready {
+		return
+	}
+	saved, err := model.HasLinkClicks()
+	if err != nil {
+		logger.Log.Error("查询保存的点击量失败", zap.Error(err))
+		return
+	}
+	if saved {
+		err = restoreClicks()
+	} else {
+		err = seedClicks()
+	}
+	if err != nil {
+		return
+	}
+	if err := click.MarkDurable(); err != nil {
+		logger.Log.Error("标记点击量已对齐失败", zap.Error(err))
+	}
+}
+
+// seedClicks 首次启用时把 Redis 中已有的点击量写入 MySQL
+func seedClicks() error {
+	totals, err := click.SnapshotTotals()
+	if err != nil {
+		logger.Log.Error("读取 Redis 中的点击量失败", zap.Error(err))
+		return err
+	}
+	if err := model.SeedLinkClicks(totals); err != nil {
+		logger.Log.Error("初始化保存的点击量失败", zap.Error(err))
+		return err
+	}
+	logger.Log.Info("已用 Redis 中的点击量初始化 MySQL", zap.Int("links", len(totals)))
+	return nil
+}
+
+// restoreClicks Redis 数据丢失后用 MySQL 中保存的点击量重建点击计数和排行榜
+func restoreClicks() error {
+	cursor, err := click.RestoreCursor()
+	if err != nil {
+		logger.Log.Error("读取重建进度失败", zap.Error(err))
+		return err
+	}
+	afterShortURL, afterDomain, _ := strings.Cut(cursor, "\n")
+	total := 0
+	for {
+		rows, err := model.ListSavedClicks(afterShortURL, afterDomain, clickRestorePage)
+		if err != nil {
+			logger.Log.Error("读取保存的点击量失败", zap.Error(err))
+			return err
+		}
+		if len(rows) == 0 {
+			break
+		}
+		items := make([]click.RestoredClicks, 0, len(rows))
+		for _, r := range rows {
+			items = append(items, click.RestoredClicks{
+				ShortUrl:    r.Key(),
+				OriginalUrl: r.OriginalURL,
+				Scope:       click.OwnerRankScope(r.UserID, r.WorkspaceID),
+				Clicks:      r.Clicks,
+				Ranked:      !r.Trashed,
+			})
+		}
+		last := rows[len(rows)-1]
+		next := last.ShortURL + "\n" + last.Domain
+		if err := click.RestoreClicks(items, cursor, next); err != nil {
+			if errors.Is(err, click.ErrRestoreMoved) {
+				logger.Log.Warn("重建进度已被其他实例推进，停止重建", zap.String("cursor", cursor))
+			} else {
+				logger.Log.Error("重建 Redis 中的点击量失败", zap.Error(err))
+			}
+			return err
+		}
+		cursor = next
+		afterShortURL, afterDomain = last.ShortURL, last.Domain
+		total += len(rows)
+	}
+	logger.Log.Info("已用 MySQL 中保存的点击量重建 Redis", zap.Int("links", total))
+	return nil
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"shortLink/proto/shortlinkpb"
+	"shortLink/shortlinkcore/cache"
+	"shortLink/shortlinkcore/config"
+	"shortLink/shortlinkcore/logger"
+	"shortLink/shortlinkcore/model"
+	"shortLink/shortlinkcore/pkg/domainverify"
+	"shortLink/shortlinkcore/pkg/safehttp"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// 合法的域名：至少两级，每级由字母数字和连字符组成
+var hostPattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+// HTTP 验证要访问用户提供的域名，同样需要防 SSRF
+var verifier = &domainverify.Verifier{
+	Resolver: net.DefaultResolver,
+	Client:   safehttp.New(safehttp.Options{Timeout: 5 * time.Second, MaxBodySize: 4096}),
+}
+
+// normalizeHost 统一域名格式：小写、去掉端口和末尾的点
+func normalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(host, ".")
+}
+
+// systemDomain 返回系统默认短链接域名
+func systemDomain() string {
+	return normalizeHost(config.GlobalConfig.App.DefaultDomain)
+}
+
+// requestDomain 将请求中的域名转换为存储用的域名，系统默认域名存为空字符串
+func requestDomain(host string) string {
+	host = normalizeHost(host)
+	if host == systemDomain() {
+		return ""
+	}
+	return host
+}
+
+// FullURL 拼接完整短链接，未配置系统默认域名时默认域名下的短链接返回空字符串
+func FullURL(domain, shortURL string) string {
+	host := domain
+	if host == "" {
+		host = systemDomain()
+	}
+	if host == "" {
+		return ""
+	}
+	scheme := config.GlobalConfig.App.DomainScheme
+	if scheme == "" {
+		scheme = "https"
+	}
+	return scheme + "://" + host + "/" + shortURL
+}
+
+// resolveDomain 根据跳转请求的 Host 确定短链接所在域名
+// 未登记或未验证的 Host（如网关自身的地址）都按系统默认域名处理
+func resolveDomain(host string) string {
+	host = requestDomain(host)
+	if host == "" {
+		return ""
+	}
+	if verified, ok := cache.GetDomainVerified(host); ok {
+		if verified {
+			return host
+		}
+		return ""
+	}
+	_, err := model.GetVerifiedDomain(host)
+	verified := err == nil
+	cache.SetDomainVerified(host, verified)
+	if verified {
+		return host
+	}
+	return ""
+}
+
+// domainForCreate 确定新短链接使用的域名
+// 未指定时使用用户的默认域名；指定的自定义域名必须属于该用户且已验证
+func domainForCreate(userID, requested string) (string, error) {
+	host := requestDomain(requested)
+	if host == "" {
+		if requested != "" || userID == "" {
+			return "", nil
+		}
+		d, err := model.GetUserDefaultDomain(userID)
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				logger.Log.Warn("查询用户默认域名失败", zap.String("userId", userID), zap.Error(err))
+			}
+			return "", nil
+		}
+		return d.Host, nil
+	}
+
+	d, err := model.GetUserDomain(userID, host)
+	if err != nil {
+		return "", fmt.Errorf("域名 %s 不可用", host)
+	}
+	if !d.Verified {
+		return "", fmt.Errorf("域名 %s 尚未验证", host)
+	}
+	return d.Host, nil
+}
+
+// domainInfo 转换为 protobuf 结构
+func domainInfo(d *model.Domain) *shortlinkpb.DomainInfo {
+	info := &shortlinkpb.DomainInfo{
+		Host:         d.Host,
+		IsDefault:    d.IsDefault,
+		Verified:     d.Verified,
+		VerifyMethod: d.VerifyMethod,
+		CreateTime:   d.CreateTime.Unix(),
+	}
+	// 验证通过后不再返回 token
+	if !d.Verified {
+		info.VerifyToken = d.VerifyToken
+		info.Instructions = domainverify.Instructions(d.VerifyMethod, d.Host, d.VerifyToken)
+	}
+	return info
+}
+
+// getUserDomain 获取属于用户的域名
+func getUserDomain(userID, host string) (*model.Domain, error) {
+	d, err := model.GetUserDomain(userID, normalizeHost(host))
+	if err != nil {
+		return nil, errors.New("域名不存在")
+	}
+	return d, nil
+}
+
+// AddDomain 登记自定义短链接域名
+func (s *ShortlinkService) AddDomain(ctx context.Context, req *shortlinkpb.AddDomainRequest) (*shortlinkpb.AddDomainResponse, error) {
+	logger.Log.Info("收到登记域名请求", zap.String("userId", req.UserId), zap.String("host", req.Host))
+
+	host := normalizeHost(req.Host)
+	if !hostPattern.MatchString(host) {
+		return nil, errors.New("域名格式错误")
+	}
+	if host == systemDomain() {
+		return nil, errors.New("不能登记系统默认域名")
+	}
+	method := req.VerifyMethod
+	if method == "" {
+		method = model.VerifyDNS
+	}
+	if method != model.VerifyDNS && method != model.VerifyHTTP {
+		return nil, fmt.Errorf("不支持的验证方式: %s", method)
+	}
+
+	// 未验证的登记不占用域名，其他用户仍可登记，先验证通过的成为归属者
+	if _, err := model.GetVerifiedDomain(host); err == nil {
+		return nil, errors.New("域名已被登记")
+	}
+	if _, err := model.GetUserDomain(req.UserId, host); err == nil {
+		return nil, errors.New("已经登记过该域名")
+	}
+	d := &model.Domain{
+		Host:         host,
+		UserID:       req.UserId,
+		VerifyMethod: method,
+		VerifyToken:  domainverify.NewToken(),
+	}
+	if err := model.CreateDomain(d); err != nil {
+		logger.Log.Error("登记域名失败", zap.String("host", host), zap.Error(err))
+		return nil, fmt.Errorf("登记域名失败: %w", err)
+	}
+	cache.DelDomainVerified(host)
+
+	logger.Log.Info("登记域名成功", zap.String("userId", req.UserId), zap.String("host", host))
+	return &shortlinkpb.AddDomainResponse{Domain: domainInfo(d)}, nil
+}
+
+// VerifyDomain 验证域名所有权
+func (s *ShortlinkService) VerifyDomain(ctx context.Context, req *shortlinkpb.VerifyDomainRequest) (*shortlinkpb.VerifyDomainResponse, error) {
+	logger.Log.Info("收到验证域名请求", zap.String("userId", req.UserId), zap.String("host", req.Host))
+
+	d, err := getUserDomain(req.UserId, req.Host)
+	if err != nil {
+		return nil, err
+	}
+	if d.Verified {
+		return &shortlinkpb.VerifyDomainResponse{Domain: domainInfo(d)}, nil
+	}
+
+	vctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := verifier.Verify(vctx, d.VerifyMethod, d.Host, d.VerifyToken); err != nil {
+		logger.Log.Warn("域名验证未通过", zap.String("host", d.Host), zap.Error(err))
+		return nil, fmt.Errorf("域名验证未通过: %w", err)
+	}
+
+	if err := model.MarkDomainVerified(d); err != nil {
+		if errors.Is(err, model.ErrDomainTaken) {
+			return nil, errors.New("域名已被其他用户验证")
+		}
+		return nil, fmt.Errorf("保存验证结果失败: %w", err)
+	}
+	cache.DelDomainVerified(d.Host)
+	d.Verified = true
+
+	logger.Log.Info("域名验证通过", zap.String("userId", req.UserId), zap.String("host", d.Host))
+	return &shortlinkpb.VerifyDomainResponse{Domain: domainInfo(d)}, nil
+}
+
+// ListDomains 查询用户登记的域名
+func (s *ShortlinkService) ListDomains(ctx context.Context, req *shortlinkpb.ListDomainsRequest) (*shortlinkpb.ListDomainsResponse, error) {
+	domains, err := model.ListUserDomains(req.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("查询域名失败: %w", err)
+	}
+	resp := &shortlinkpb.ListDomainsResponse{SystemDomain: systemDomain()}
+	for i := range domains {
+		resp.Domains = append(resp.Domains, domainInfo(&domains[i]))
+	}
+	return resp, nil
+}
+
+// SetDefaultDomain 设置用户创建短链接时的默认域名
+func (s *ShortlinkService) SetDefaultDomain(ctx context.Context, req *shortlinkpb.SetDefaultDomainRequest) (*shortlinkpb.SetDefaultDomainResponse, error) {
+	logger.Log.Info("收到设置默认域名请求", zap.String("userId", req.UserId), zap.String("host", req.Host))
+
+	host := requestDomain(req.Host)
+	if host != "" {
+		d, err := getUserDomain(req.UserId, host)
+		if err != nil {
+			return nil, err
+		}
+		if !d.Verified {
+			return nil, errors.New("域名尚未验证")
+		}
+	}
+	if err := model.SetDefaultDomain(req.UserId, host); err != nil {
+		return nil, fmt.Errorf("设置默认域名失败: %w", err)
+	}
+	return &shortlinkpb.SetDefaultDomainResponse{}, nil
+}
+
+// DeleteDomain 删除域名，域名下还有短链接时拒绝删除
+func (s *ShortlinkService) DeleteDomain(ctx context.Context, req *shortlinkpb.DeleteDomainRequest) (*shortlinkpb.DeleteDomainResponse, error) {
+	logger.Log.Info("收到删除域名请求", zap.String("userId", req.UserId), zap.String("host", req.Host))
+
+	d, err := getUserDomain(req.UserId, req.Host)
+	if err != nil {
+		return nil, err
+	}
+	// 未验证的登记下不会有短链接，同名域名的短链接属于验证通过的用户
+	if d.Verified {
+		count, err := model.CountDomainLinks(d.Host)
+		if err != nil {
+			return nil, fmt.Errorf("查询域名下的短链接失败: %w", err)
+		}
+		if count > 0 {
+			return nil, fmt.Errorf("域名下还有%d个短链接（包括回收站），不能删除", count)
+		}
+	}
+	if err := model.DeleteDomain(d.ID); err != nil {
+		return nil, fmt.Errorf("删除域名失败: %w", err)
+	}
+	cache.DelDomainVerified(d.Host)
+	return &shortlinkpb.DeleteDomainResponse{}, nil
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"fmt"
+
+	"shortLink/proto/shortlinkpb"
+	"shortLink/shortlinkcore/logger"
+	"shortLink/shortlinkcore/model"
+	"shortLink/shortlinkcore/service/click"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// 导出时每页读取的条数
+const (
+	defaultExportPageSize = 500
+	maxExportPageSize     = 2000
+)
+
+// ExportLinks 流式导出用户的所有个人短链接或工作区的所有短链接及点击量
+// 按游标分页读取，每页查询一次标签和点击量后立即发送，内存占用与总数无关
+func (s *ShortlinkService) ExportLinks(req *shortlinkpb.ExportLinksRequest, stream grpc.ServerStreamingServer[shortlinkpb.ExportedLink]) error {
+	logger.Log.Info("收到导出短链接请求", zap.String("userId", req.UserId), zap.String("workspaceId", req.WorkspaceId))
+
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultExportPageSize
+	}
+	if pageSize > maxExportPageSize {
+		pageSize = maxExportPageSize
+	}
+
+	owner := model.Owner{UserID: req.UserId, WorkspaceID: req.WorkspaceId}
+	var afterShortURL, afterDomain string
+	total := 0
+	for {
+		// 客户端断开后停止读取
+		if err := stream.Context().Err(); err != nil {
+			return err
+		}
+
+		mappings, err := model.ListLinksAfter(owner, afterShortURL, afterDomain, pageSize)
+		if err != nil {
+			logger.Log.Error("导出短链接时查询失败", zap.String("userId", req.UserId), zap.Error(err))
+			return fmt.Errorf("查询短链接失败: %w", err)
+		}
+		if len(mappings) == 0 {
+			break
+		}
+
+		tags, err := model.GetLinkTagNames(mappings)
+		if err != nil {
+			return fmt.Errorf("查询标签失败: %w", err)
+		}
+		members := make([]string, 0, len(mappings))
+		for i := range mappings {
+			members = append(members, click.Member(mappings[i].Key(), mappings[i].OriginalURL))
+		}
+		counts, err := click.GetClickCounts(members)
+		if err != nil {
+			return fmt.Errorf("获取点击量失败: %w", err)
+		}
+
+		for i := range mappings {
+			if err := stream.Send(exportedLink(&mappings[i], tags[mappings[i].Key()], counts[i])); err != nil {
+				return err
+			}
+		}
+		total += len(mappings)
+
+		last := mappings[len(mappings)-1]
+		afterShortURL, afterDomain = last.ShortURL, last.Domain
+		if len(mappings) < pageSize {
+			break
+		}
+	}
+
+	logger.Log.Info("导出短链接完成", zap.String("userId", req.UserId), zap.Int("count", total))
+	return nil
+}
+
+// exportedLink 转换为导出格式
+func exportedLink(m *model.URLMapping, tags []string, clicks int64) *shortlinkpb.ExportedLink {
+	link := &shortlinkpb.ExportedLink{
+		ShortUrl:     m.ShortURL,
+		Domain:       m.Domain,
+		FullUrl:      FullURL(m.Domain, m.ShortURL),
+		OriginalUrl:  m.OriginalURL,
+		Status:       m.Status,
+		Tags:         tags,
+		RedirectType: int32(m.RedirectType),
+		Clicks:       clicks,
+		CreateTime:   m.CreateTime.Unix(),
+	}
+	if m.ExpireAt != nil {
+		link.ExpireAt = m.ExpireAt.Unix()
+	}
+	return link
+}
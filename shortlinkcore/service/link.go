@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"shortLink/proto/shortlinkpb"
+	"shortLink/shortlinkcore/cache"
+	"shortLink/shortlinkcore/logger"
+	"shortLink/shortlinkcore/model"
+	"shortLink/shortlinkcore/pkg"
+	"shortLink/shortlinkcore/service/click"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetLink 查询短链接是否存在及其完整地址，不计入点击
+// 供二维码等只需要短链接本身的接口使用，已封禁的短链接视为不存在
+func (s *ShortlinkService) GetLink(ctx context.Context, req *shortlinkpb.GetLinkRequest) (*shortlinkpb.GetLinkResponse, error) {
+	domain := requestDomain(req.Domain)
+	mapping, err := Resolve(domain, req.ShortUrl)
+	if isLinkNotFound(err) {
+		return nil, status.Error(codes.NotFound, "短链接不存在")
+	}
+	if err != nil {
+		logger.Log.Error("查询短链接失败", zap.String("shortUrl", req.ShortUrl), zap.Error(err))
+		return nil, status.Error(codes.Internal, "查询短链接失败")
+	}
+	if mapping.Status == "blocked" {
+		return nil, status.Error(codes.NotFound, "短链接不存在")
+	}
+	return &shortlinkpb.GetLinkResponse{
+		ShortUrl: mapping.ShortURL,
+		Domain:   mapping.Domain,
+		FullUrl:  FullURL(mapping.Domain, mapping.ShortURL),
+	}, nil
+}
+
+// UpdateLink 修改短链接的目标地址
+// 修改后重新进行安全检查，并刷新链接预览
+func (s *ShortlinkService) UpdateLink(ctx context.Context, req *shortlinkpb.UpdateLinkRequest) (*shortlinkpb.UpdateLinkResponse, error) {
+	logger.Log.Info("收到修改短链接请求",
+		zap.String("shortUrl", req.ShortUrl),
+		zap.String("userId", req.UserId),
+		zap.String("originalUrl", req.OriginalUrl))
+
+	// 1. 校验 URL 合法性
+	if req.OriginalUrl == "" || !pkg.IsValidURL(req.OriginalUrl) {
+		return nil, errors.New("链接非法")
+	}
+
+	// 2. 校验短链接归属
+	domain := requestDomain(req.Domain)
+	mapping, err := model.GetOwnedMapping(model.Owner{UserID: req.UserId, WorkspaceID: req.WorkspaceId}, domain, req.ShortUrl)
+	if err != nil {
+		logger.Log.Warn("短链接不存在或不属于该用户或工作区",
+			zap.String("shortUrl", req.ShortUrl),
+			zap.String("userId", req.UserId),
+			zap.Error(err))
+		return nil, fmt.Errorf("短链接不存在: %w", err)
+	}
+	if mapping.OriginalURL == req.OriginalUrl {
+		return &shortlinkpb.UpdateLinkResponse{ShortUrl: req.ShortUrl, OriginalUrl: req.OriginalUrl, FullUrl: FullURL(domain, req.ShortUrl)}, nil
+	}
+
+	// 3. 先更新数据库，再删除缓存
+	if err := model.UpdateOriginalURL(domain, req.ShortUrl, req.OriginalUrl); err != nil {
+		logger.Log.Error("修改短链接失败", zap.String("shortUrl", req.ShortUrl), zap.Error(err))
+		return nil, fmt.Errorf("修改短链接失败: %w", err)
+	}
+	cache.DelLink(mapping.Key())
+	cache.DelPreview(mapping.Key())
+
+	// 4. 点击计数和排行榜按短链接和目标地址记录，迁移到新地址，避免统计归零
+	_ = click.RenameMember(mapping.Key(), mapping.OriginalURL, req.OriginalUrl, click.OwnerRankScope(mapping.UserID, mapping.WorkspaceID))
+
+	// 5. 异步安全检查和预览刷新
+	checkSafety(domain, req.ShortUrl, req.OriginalUrl)
+	schedulePreview(domain, req.ShortUrl, req.OriginalUrl)
+
+	logger.Log.Info("修改短链接成功",
+		zap.String("shortUrl", req.ShortUrl),
+		zap.String("oldUrl", mapping.OriginalURL),
+		zap.String("originalUrl", req.OriginalUrl))
+
+	// 6. 通知订阅了 link.updated 的 Webhook
+	previous := mapping.OriginalURL
+	mapping.OriginalURL = req.OriginalUrl
+	emitLinkEvent(EventLinkUpdated, mapping, func(d *webhookLinkData) {
+		d.Changes = []string{"original_url"}
+		d.PreviousURL = previous
+	})
+	return &shortlinkpb.UpdateLinkResponse{ShortUrl: req.ShortUrl, OriginalUrl: req.OriginalUrl, FullUrl: FullURL(domain, req.ShortUrl)}, nil
+}
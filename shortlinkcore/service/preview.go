@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"shortLink/proto/shortlinkpb"
+	"shortLink/shortlinkcore/cache"
+	"shortLink/shortlinkcore/logger"
+	"shortLink/shortlinkcore/model"
+	"shortLink/shortlinkcore/pkg"
+	"shortLink/shortlinkcore/pkg/gopool"
+	"shortLink/shortlinkcore/pkg/preview"
+
+	"go.uber.org/zap"
+)
+
+// 单次抓取预览的最长时间
+const previewFetchTimeout = 8 * time.Second
+
+// GetLinkPreview 获取短链接目标页面的预览信息
+// 预览在创建和修改目标地址时异步抓取，这里只在缺失或过期时补抓一次
+func (s *ShortlinkService) GetLinkPreview(ctx context.Context, req *shortlinkpb.GetLinkPreviewRequest) (*shortlinkpb.GetLinkPreviewResponse, error) {
+	logger.Log.Info("收到获取链接预览请求", zap.String("shortUrl", req.ShortUrl), zap.String("domain", req.Domain))
+
+	// 1. 解析短链接，已封禁的链接不提供预览
+	domain := requestDomain(req.Domain)
+	mapping, err := Resolve(domain, req.ShortUrl)
+	if err != nil {
+		return nil, fmt.Errorf("短链接不存在: %w", err)
+	}
+	if mapping.Status == "blocked" {
+		return nil, errors.New("短链接已被封禁")
+	}
+
+	// 2. 查缓存，再查数据库；目标地址变更后旧预览视为过期
+	key := mapping.Key()
+	p := cache.GetPreview(key)
+	if p == nil {
+		if p, err = model.GetPreview(domain, req.ShortUrl); err == nil {
+			cache.SetPreview(p)
+		}
+	}
+
+	// 3. 缺失或过期时同步抓取，singleflight 合并并发请求
+	if p == nil || p.SourceURL != mapping.OriginalURL {
+		v, err, _ := pkg.Group.Do("preview:"+key, func() (any, error) {
+			p, info, err := refreshPreview(domain, req.ShortUrl, mapping.OriginalURL)
+			if err != nil {
+				return nil, err
+			}
+			scheduleSummary(p, info)
+			return p, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("获取链接预览失败: %w", err)
+		}
+		p = v.(*model.LinkPreview)
+	}
+
+	return &shortlinkpb.GetLinkPreviewResponse{Preview: &shortlinkpb.LinkPreview{
+		Title:        p.Title,
+		Description:  p.Description,
+		Image:        p.Image,
+		CanonicalUrl: p.CanonicalURL,
+		FetchedAt:    p.FetchedAt.Unix(),
+		Summary:      p.Summary,
+	}}, nil
+}
+
+// refreshPreview 抓取目标页面并保存预览信息到数据库和缓存
+// 同时返回抓取到的页面信息，供生成摘要使用
+func refreshPreview(domain, shortURL, originalURL string) (*model.LinkPreview, *preview.Info, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), previewFetchTimeout)
+	defer cancel()
+
+	info, err := preview.Fetch(ctx, originalURL)
+	if err != nil {
+		logger.Log.Warn("抓取链接预览失败",
+			zap.String("shortUrl", shortURL),
+			zap.String("url", originalURL),
+			zap.Error(err))
+		return nil, nil, err
+	}
+
+	p := &model.LinkPreview{
+		ShortURL:     shortURL,
+		Domain:       domain,
+		Title:        info.Title,
+		Description:  info.Description,
+		Image:        info.Image,
+		CanonicalURL: info.CanonicalURL,
+		SourceURL:    originalURL,
+		FetchedAt:    time.Now(),
+	}
+	if err := model.SavePreview(p); err != nil {
+		logger.Log.Error("保存链接预览失败", zap.String("shortUrl", shortURL), zap.Error(err))
+		return nil, nil, err
+	}
+	cache.SetPreview(p)
+
+	logger.Log.Info("链接预览已更新",
+		zap.String("shortUrl", shortURL),
+		zap.String("title", p.Title))
+	return p, info, nil
+}
+
+// schedulePreview 使用协程池异步抓取预览和生成摘要，不阻塞创建和修改流程
+func schedulePreview(domain, shortURL, originalURL string) {
+	err := gopool.GetPool().Submit(func() {
+		p, info, err := refreshPreview(domain, shortURL, originalURL)
+		if err != nil {
+			return
+		}
+		// 已经在协程池中，直接生成摘要，避免任务中再向同一个池提交任务
+		summarizePreview(p, info)
+	})
+	if err != nil {
+		logger.Log.Warn("提交预览抓取任务失败", zap.String("shortUrl", shortURL), zap.Error(err))
+	}
+}
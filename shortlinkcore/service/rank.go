@@ -0,0 +1,88 @@
+package service
+
+import (
+	"time"
+
+	"shortLink/shortlinkcore/cache"
+	"shortLink/shortlinkcore/logger"
+	"shortLink/shortlinkcore/model"
+	"shortLink/shortlinkcore/pkg/locker"
+	"shortLink/shortlinkcore/service/click"
+
+	"go.uber.org/zap"
+)
+
+const (
+	rankBackfillPage = 1000
+	rankBackfillLock = "lock:rank:backfill"
+)
+
+// StartOwnerRankBackfill 启动补齐归属者排行榜的协程，只在第一次启动时执行一次
+func StartOwnerRankBackfill() {
+	go backfillOwnerRanks()
+}
+
+// backfillOwnerRanks 归属者的全部时间排行榜上线前，点击只计入全站排行榜，
+// 按全站排行榜中的成员查询短链接的归属者，把点击量写入用户或工作区的排行榜
+// 写入的是全站排行榜中的当前点击量，重复执行结果不变，锁过期后被其他实例重复执行也不会多算
+func backfillOwnerRanks() {
+	if done, err := click.OwnerRanksBackfilled(); err != nil || done {
+		return
+	}
+	lock := locker.NewRedisLock(cache.GetRedis(), rankBackfillLock, 10*time.Minute)
+	ok, err := lock.TryLock()
+	if err != nil || !ok {
+		return
+	}
+	defer lock.Unlock()
+
+	total := 0
+	var cursor uint64
+	for {
+		members, next, err := click.ScanGlobalRank(cursor, rankBackfillPage)
+		if err != nil {
+			logger.Log.Error("读取全站排行榜失败", zap.Error(err))
+			return
+		}
+		if err := backfillOwnerRankPage(members); err != nil {
+			logger.Log.Error("补齐归属者排行榜失败", zap.Error(err))
+			return
+		}
+		total += len(members)
+		if next == 0 {
+			break
+		}
+		cursor = next
+	}
+	if err := click.MarkOwnerRanksBackfilled(); err != nil {
+		logger.Log.Error("标记归属者排行榜已补齐失败", zap.Error(err))
+		return
+	}
+	logger.Log.Info("已补齐归属者排行榜", zap.Int("members", total))
+}
+
+// backfillOwnerRankPage 查询一批排行榜成员的归属者并写入对应的排行榜
+func backfillOwnerRankPage(members []string) error {
+	wanted := make([]model.URLMapping, 0, len(members))
+	byKey := make(map[string][]string, len(members))
+	for _, member := range members {
+		key, _ := click.SplitMember(member)
+		if _, ok := byKey[key]; !ok {
+			domain, shortURL := model.SplitLinkKey(key)
+			wanted = append(wanted, model.URLMapping{ShortURL: shortURL, Domain: domain})
+		}
+		byKey[key] = append(byKey[key], member)
+	}
+	mappings, err := model.FindMappings(wanted)
+	if err != nil {
+		return err
+	}
+	items := make([]click.RankedMember, 0, len(members))
+	for i := range mappings {
+		scope := click.OwnerRankScope(mappings[i].UserID, mappings[i].WorkspaceID)
+		for _, member := range byKey[mappings[i].Key()] {
+			items = append(items, click.RankedMember{Member: member, Scope: scope})
+		}
+	}
+	return click.BackfillOwnerRanks(items)
+}
@@ -0,0 +1,278 @@
+// 跳转规则模块：按访问者信息从短链接的规则列表中选出跳转目标
+package routing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"shortLink/proto/shortlinkpb"
+	"shortLink/shortlinkcore/pkg/useragent"
+)
+
+// 单个短链接允许的最大规则数
+const MaxRules = 20
+
+// Rule 跳转规则
+type Rule struct {
+	Platform    string   `json:"platform,omitempty"`     // ios / android / desktop，空表示不限
+	UAContains  string   `json:"ua_contains,omitempty"`  // UA 包含的关键字，不区分大小写
+	TargetURL   string   `json:"target_url"`             // 命中后的跳转地址，网页或 App 深链接
+	FallbackURL string   `json:"fallback_url,omitempty"` // 深链接唤起失败时的网页地址
+	Countries   []string `json:"countries,omitempty"`    // 国家代码（ISO 3166-1），空表示不限
+	Regions     []string `json:"regions,omitempty"`      // 地区代码（ISO 3166-2），空表示不限
+}
+
+// Visitor 访问者信息
+type Visitor struct {
+	ID        string // 网关下发的访问者Cookie，用于 A/B 分流粘性
+	UserAgent string
+	IP        string
+	Country   string
+	Region    string
+}
+
+// Target 最终跳转目标
+type Target struct {
+	URL         string
+	FallbackURL string // 非空表示 URL 是 App 深链接，需要网页兜底
+	Variant     string // 命中的 A/B 分组名称
+}
+
+// Destination 短链接的全部跳转配置
+type Destination struct {
+	Code        string
+	OriginalURL string
+	Rules       []Rule
+	Variants    []Variant
+}
+
+// VisitorFromHeaders 从网关转发的请求头中提取访问者信息
+func VisitorFromHeaders(headers map[string]string) Visitor {
+	v := Visitor{}
+	for k, val := range headers {
+		if strings.EqualFold(k, "User-Agent") {
+			v.UserAgent = val
+		}
+	}
+	return v
+}
+
+// Match 按顺序匹配规则，返回第一条命中的规则
+func Match(rules []Rule, v Visitor) (Rule, bool) {
+	platform := useragent.DetectPlatform(v.UserAgent)
+	ua := strings.ToLower(v.UserAgent)
+	for _, r := range rules {
+		if r.Platform != "" && r.Platform != platform {
+			continue
+		}
+		if r.UAContains != "" && !strings.Contains(ua, strings.ToLower(r.UAContains)) {
+			continue
+		}
+		if len(r.Countries) > 0 && !containsFold(r.Countries, v.Country) {
+			continue
+		}
+		if len(r.Regions) > 0 && !containsFold(r.Regions, v.Region) {
+			continue
+		}
+		return r, true
+	}
+	return Rule{}, false
+}
+
+// NeedsGeo 判断规则中是否包含地域条件，没有时可以跳过 GeoIP 查询
+func NeedsGeo(rules []Rule) bool {
+	for _, r := range rules {
+		if len(r.Countries) > 0 || len(r.Regions) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold 不区分大小写判断 list 中是否包含 s，s 为空时视为不匹配
+func containsFold(list []string, s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Pick 根据规则为访问者选出跳转目标，未命中时跳转原始链接
+func Pick(originalURL string, rules []Rule, v Visitor) Target {
+	r, ok := Match(rules, v)
+	if !ok {
+		return Target{URL: originalURL}
+	}
+	return ruleTarget(originalURL, r)
+}
+
+// Pick 先按顺序匹配跳转规则，未命中时按权重分流，都没有时跳转原始链接
+func (d Destination) Pick(v Visitor) Target {
+	if r, ok := Match(d.Rules, v); ok {
+		return ruleTarget(d.OriginalURL, r)
+	}
+	// 没有Cookie的客户端（如部分App内置浏览器）退化为按IP保持粘性
+	key := v.ID
+	if key == "" {
+		key = v.IP
+	}
+	if variant, ok := PickVariant(d.Code, d.Variants, key); ok {
+		return Target{URL: variant.TargetURL, Variant: variant.Name}
+	}
+	return Target{URL: d.OriginalURL}
+}
+
+// ruleTarget 将命中的规则转换为跳转目标
+func ruleTarget(originalURL string, r Rule) Target {
+	if !IsAppScheme(r.TargetURL) {
+		return Target{URL: r.TargetURL}
+	}
+	fallback := r.FallbackURL
+	if fallback == "" {
+		fallback = originalURL
+	}
+	// 校验加入前保存的规则可能含有不安全的 scheme，直接跳转兜底地址
+	if !IsSafeAppScheme(r.TargetURL) {
+		return Target{URL: fallback}
+	}
+	return Target{URL: r.TargetURL, FallbackURL: fallback}
+}
+
+// appSchemePattern App 深链接 scheme 的格式（RFC 3986）
+var appSchemePattern = regexp.MustCompile(`^[a-z][a-z0-9+.-]*$`)
+
+// unsafeSchemes 浏览器会在当前页面执行或读取本地内容的 scheme，不能作为深链接
+var unsafeSchemes = map[string]bool{
+	"javascript": true,
+	"data":       true,
+	"vbscript":   true,
+	"file":       true,
+	"blob":       true,
+}
+
+// IsAppScheme 判断地址是否为 App 深链接（非 http/https 的 scheme）
+func IsAppScheme(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" {
+		return false
+	}
+	scheme := strings.ToLower(u.Scheme)
+	return scheme != "http" && scheme != "https"
+}
+
+// IsSafeAppScheme 判断 App 深链接的 scheme 是否允许使用
+// 深链接会在网关的唤起页面中执行跳转，javascript: 等 scheme 会导致脚本注入
+func IsSafeAppScheme(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	scheme := strings.ToLower(u.Scheme)
+	return appSchemePattern.MatchString(scheme) && !unsafeSchemes[scheme]
+}
+
+// Validate 校验规则列表
+func Validate(rules []Rule) error {
+	if len(rules) > MaxRules {
+		return fmt.Errorf("规则数量不能超过%d条", MaxRules)
+	}
+	for i, r := range rules {
+		switch r.Platform {
+		case "", useragent.PlatformIOS, useragent.PlatformAndroid, useragent.PlatformDesktop:
+		default:
+			return fmt.Errorf("第%d条规则的平台不支持: %s", i+1, r.Platform)
+		}
+		if r.TargetURL == "" {
+			return fmt.Errorf("第%d条规则缺少跳转地址", i+1)
+		}
+		u, err := url.Parse(r.TargetURL)
+		if err != nil || u.Scheme == "" {
+			return fmt.Errorf("第%d条规则的跳转地址非法", i+1)
+		}
+		if !IsAppScheme(r.TargetURL) && u.Host == "" {
+			return fmt.Errorf("第%d条规则的跳转地址非法", i+1)
+		}
+		if IsAppScheme(r.TargetURL) && !IsSafeAppScheme(r.TargetURL) {
+			return fmt.Errorf("第%d条规则的跳转地址 scheme 不允许: %s", i+1, u.Scheme)
+		}
+		for _, c := range r.Countries {
+			if len(c) != 2 {
+				return fmt.Errorf("第%d条规则的国家代码非法: %s", i+1, c)
+			}
+		}
+		for _, region := range r.Regions {
+			if len(region) < 4 || region[2] != '-' {
+				return fmt.Errorf("第%d条规则的地区代码非法: %s", i+1, region)
+			}
+		}
+		if r.FallbackURL != "" {
+			if f, err := url.Parse(r.FallbackURL); err != nil || f.Host == "" || IsAppScheme(r.FallbackURL) {
+				return fmt.Errorf("第%d条规则的兜底地址必须是网页地址", i+1)
+			}
+		}
+	}
+	return nil
+}
+
+// Encode 将规则序列化后存入数据库，空规则返回空字符串
+func Encode(rules []Rule) (string, error) {
+	if len(rules) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Decode 解析数据库中保存的规则
+func Decode(raw string) ([]Rule, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var rules []Rule
+	if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+		return nil, errors.New("跳转规则格式错误")
+	}
+	return rules, nil
+}
+
+// normalizeCodes 统一国家/地区代码为大写
+func normalizeCodes(codes []string) []string {
+	if len(codes) == 0 {
+		return nil
+	}
+	result := make([]string, 0, len(codes))
+	for _, c := range codes {
+		if c = strings.ToUpper(strings.TrimSpace(c)); c != "" {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// FromPB 将 protobuf 规则转换为内部结构
+func FromPB(pbRules []*shortlinkpb.RedirectRule) []Rule {
+	rules := make([]Rule, 0, len(pbRules))
+	for _, r := range pbRules {
+		rules = append(rules, Rule{
+			Platform:    strings.ToLower(strings.TrimSpace(r.Platform)),
+			UAContains:  r.UaContains,
+			TargetURL:   strings.TrimSpace(r.TargetUrl),
+			FallbackURL: strings.TrimSpace(r.FallbackUrl),
+			Countries:   normalizeCodes(r.Countries),
+			Regions:     normalizeCodes(r.Regions),
+		})
+	}
+	return rules
+}
@@ -0,0 +1,145 @@
+package routing
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	iphoneUA  = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1"
+	androidUA = "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Mobile Safari/537.36"
+	desktopUA = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36"
+)
+
+func TestPick(t *testing.T) {
+	rules := []Rule{
+		{Platform: "ios", TargetURL: "https://apps.apple.com/app/id1"},
+		{Platform: "android", TargetURL: "https://play.google.com/store/apps/details?id=demo"},
+	}
+
+	t.Run("iOS跳转App Store", func(t *testing.T) {
+		target := Pick("https://example.com", rules, Visitor{UserAgent: iphoneUA})
+		assert.Equal(t, "https://apps.apple.com/app/id1", target.URL)
+		assert.Empty(t, target.FallbackURL)
+	})
+
+	t.Run("Android跳转应用商店", func(t *testing.T) {
+		target := Pick("https://example.com", rules, Visitor{UserAgent: androidUA})
+		assert.Equal(t, "https://play.google.com/store/apps/details?id=demo", target.URL)
+	})
+
+	t.Run("未命中规则跳转原始链接", func(t *testing.T) {
+		target := Pick("https://example.com", rules, Visitor{UserAgent: desktopUA})
+		assert.Equal(t, "https://example.com", target.URL)
+	})
+
+	t.Run("按顺序命中第一条规则", func(t *testing.T) {
+		ordered := []Rule{
+			{UAContains: "micromessenger", TargetURL: "https://example.com/wechat"},
+			{Platform: "ios", TargetURL: "https://example.com/ios"},
+		}
+		target := Pick("https://example.com", ordered, Visitor{UserAgent: iphoneUA + " MicroMessenger/8.0"})
+		assert.Equal(t, "https://example.com/wechat", target.URL)
+	})
+
+	t.Run("按国家和地区匹配", func(t *testing.T) {
+		geo := []Rule{
+			{Regions: []string{"US-CA"}, TargetURL: "https://example.com/california"},
+			{Countries: []string{"US"}, TargetURL: "https://example.com/us"},
+		}
+		assert.True(t, NeedsGeo(geo))
+		target := Pick("https://example.com", geo, Visitor{Country: "US", Region: "US-CA"})
+		assert.Equal(t, "https://example.com/california", target.URL)
+		target = Pick("https://example.com", geo, Visitor{Country: "US", Region: "US-NY"})
+		assert.Equal(t, "https://example.com/us", target.URL)
+		target = Pick("https://example.com", geo, Visitor{})
+		assert.Equal(t, "https://example.com", target.URL)
+	})
+
+	t.Run("深链接使用兜底地址", func(t *testing.T) {
+		deep := []Rule{{Platform: "ios", TargetURL: "myapp://item/1"}}
+		target := Pick("https://example.com/item/1", deep, Visitor{UserAgent: iphoneUA})
+		assert.Equal(t, "myapp://item/1", target.URL)
+		assert.Equal(t, "https://example.com/item/1", target.FallbackURL)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate([]Rule{{Platform: "ios", TargetURL: "myapp://home", FallbackURL: "https://example.com"}}))
+	assert.Error(t, Validate([]Rule{{Platform: "windows", TargetURL: "https://example.com"}}))
+	assert.Error(t, Validate([]Rule{{Platform: "ios"}}))
+	assert.Error(t, Validate([]Rule{{TargetURL: "myapp://home", FallbackURL: "otherapp://home"}}))
+
+	t.Run("拒绝会执行脚本或读取本地内容的scheme", func(t *testing.T) {
+		for _, target := range []string{
+			"javascript:alert(document.cookie)",
+			"JavaScript:alert(1)",
+			"data:text/html,<script>alert(1)</script>",
+			"vbscript:msgbox(1)",
+			"file:///etc/passwd",
+			"blob:https://example.com/uuid",
+		} {
+			assert.Error(t, Validate([]Rule{{Platform: "ios", TargetURL: target}}), target)
+		}
+	})
+	t.Run("允许普通App深链接", func(t *testing.T) {
+		assert.True(t, IsSafeAppScheme("myapp://home"))
+		assert.True(t, IsSafeAppScheme("com.example.app+v2://item/1"))
+		assert.False(t, IsSafeAppScheme("javascript:alert(1)"))
+	})
+}
+
+func TestPickVariant(t *testing.T) {
+	variants := []Variant{
+		{Name: "a", TargetURL: "https://example.com/a", Weight: 80},
+		{Name: "b", TargetURL: "https://example.com/b", Weight: 20},
+	}
+
+	t.Run("同一访问者结果稳定", func(t *testing.T) {
+		first, ok := PickVariant("abc", variants, "visitor-1")
+		assert.True(t, ok)
+		for i := 0; i < 10; i++ {
+			v, _ := PickVariant("abc", variants, "visitor-1")
+			assert.Equal(t, first.Name, v.Name)
+		}
+	})
+
+	t.Run("按权重分配流量", func(t *testing.T) {
+		counts := map[string]int{}
+		for i := 0; i < 10000; i++ {
+			v, _ := PickVariant("abc", variants, fmt.Sprintf("visitor-%d", i))
+			counts[v.Name]++
+		}
+		assert.InDelta(t, 8000, counts["a"], 300)
+		assert.InDelta(t, 2000, counts["b"], 300)
+	})
+
+	t.Run("规则优先于分流", func(t *testing.T) {
+		d := Destination{
+			Code:        "abc",
+			OriginalURL: "https://example.com",
+			Rules:       []Rule{{Platform: "ios", TargetURL: "https://example.com/ios"}},
+			Variants:    variants,
+		}
+		assert.Equal(t, Target{URL: "https://example.com/ios"}, d.Pick(Visitor{ID: "v", UserAgent: iphoneUA}))
+		assert.NotEmpty(t, d.Pick(Visitor{ID: "v", UserAgent: desktopUA}).Variant)
+	})
+
+	t.Run("没有Cookie时按IP保持粘性", func(t *testing.T) {
+		d := Destination{Code: "abc", OriginalURL: "https://example.com", Variants: variants}
+		for i := 0; i < 20; i++ {
+			ip := fmt.Sprintf("203.0.113.%d", i)
+			first := d.Pick(Visitor{IP: ip, UserAgent: desktopUA})
+			second := d.Pick(Visitor{IP: ip, UserAgent: desktopUA})
+			assert.NotEmpty(t, first.Variant)
+			assert.Equal(t, first, second)
+		}
+	})
+
+	t.Run("没有分组时跳转原始链接", func(t *testing.T) {
+		_, ok := PickVariant("abc", nil, "v")
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,123 @@
+package routing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/url"
+	"strings"
+
+	"shortLink/proto/shortlinkpb"
+)
+
+// 单个短链接允许的最大分组数
+const MaxVariants = 10
+
+// Variant A/B 分流目标
+type Variant struct {
+	Name      string `json:"name"`
+	TargetURL string `json:"target_url"`
+	Weight    int    `json:"weight"`
+}
+
+// PickVariant 按权重为访问者选择分组
+// 同一访问者（visitorKey 相同）对同一短链接总是落在同一分组，保证实验结果不被来回切换污染
+// 参数：
+//   - code: 短链接
+//   - variants: 分组列表
+//   - visitorKey: 访问者标识（Cookie 或 IP）
+//
+// 返回：
+//   - Variant: 选中的分组
+//   - bool: 没有可用分组时返回false
+func PickVariant(code string, variants []Variant, visitorKey string) (Variant, bool) {
+	total := 0
+	for _, v := range variants {
+		if v.Weight > 0 {
+			total += v.Weight
+		}
+	}
+	if total == 0 {
+		return Variant{}, false
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(code))
+	h.Write([]byte{':'})
+	h.Write([]byte(visitorKey))
+	point := int(h.Sum64() % uint64(total))
+
+	for _, v := range variants {
+		if v.Weight <= 0 {
+			continue
+		}
+		if point < v.Weight {
+			return v, true
+		}
+		point -= v.Weight
+	}
+	return Variant{}, false
+}
+
+// ValidateVariants 校验分组列表
+func ValidateVariants(variants []Variant) error {
+	if len(variants) > MaxVariants {
+		return fmt.Errorf("分组数量不能超过%d个", MaxVariants)
+	}
+	names := make(map[string]struct{}, len(variants))
+	for i, v := range variants {
+		if v.Name == "" {
+			return fmt.Errorf("第%d个分组缺少名称", i+1)
+		}
+		if _, ok := names[v.Name]; ok {
+			return fmt.Errorf("分组名称重复: %s", v.Name)
+		}
+		names[v.Name] = struct{}{}
+		if v.Weight <= 0 {
+			return fmt.Errorf("分组%s的权重必须大于0", v.Name)
+		}
+		u, err := url.Parse(v.TargetURL)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("分组%s的跳转地址必须是网页地址", v.Name)
+		}
+	}
+	return nil
+}
+
+// EncodeVariants 将分组序列化后存入数据库，空列表返回空字符串
+func EncodeVariants(variants []Variant) (string, error) {
+	if len(variants) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(variants)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// DecodeVariants 解析数据库中保存的分组
+func DecodeVariants(raw string) ([]Variant, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var variants []Variant
+	if err := json.Unmarshal([]byte(raw), &variants); err != nil {
+		return nil, errors.New("分流配置格式错误")
+	}
+	return variants, nil
+}
+
+// VariantsFromPB 将 protobuf 分组转换为内部结构
+func VariantsFromPB(pbVariants []*shortlinkpb.SplitVariant) []Variant {
+	variants := make([]Variant, 0, len(pbVariants))
+	for _, v := range pbVariants {
+		variants = append(variants, Variant{
+			Name:      strings.TrimSpace(v.Name),
+			TargetURL: strings.TrimSpace(v.TargetUrl),
+			Weight:    int(v.Weight),
+		})
+	}
+	return variants
+}
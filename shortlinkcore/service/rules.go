@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"shortLink/proto/shortlinkpb"
+	"shortLink/shortlinkcore/cache"
+	"shortLink/shortlinkcore/logger"
+	"shortLink/shortlinkcore/model"
+	"shortLink/shortlinkcore/service/routing"
+
+	"go.uber.org/zap"
+)
+
+// UpdateLinkRules 更新短链接的跳转规则
+func (s *ShortlinkService) UpdateLinkRules(ctx context.Context, req *shortlinkpb.UpdateLinkRulesRequest) (*shortlinkpb.UpdateLinkRulesResponse, error) {
+	logger.Log.Info("收到更新跳转规则请求",
+		zap.String("shortUrl", req.ShortUrl),
+		zap.String("userId", req.UserId),
+		zap.Int("ruleCount", len(req.Rules)))
+
+	// 1. 校验规则
+	rules := routing.FromPB(req.Rules)
+	if err := routing.Validate(rules); err != nil {
+		return nil, fmt.Errorf("跳转规则非法: %w", err)
+	}
+
+	// 2. 校验短链接归属
+	domain := requestDomain(req.Domain)
+	mapping, err := model.GetOwnedMapping(model.Owner{UserID: req.UserId, WorkspaceID: req.WorkspaceId}, domain, req.ShortUrl)
+	if err != nil {
+		logger.Log.Warn("短链接不存在或不属于该用户或工作区",
+			zap.String("shortUrl", req.ShortUrl),
+			zap.String("userId", req.UserId),
+			zap.Error(err))
+		return nil, fmt.Errorf("短链接不存在: %w", err)
+	}
+
+	// 3. 先更新数据库，再删除缓存
+	encoded, err := routing.Encode(rules)
+	if err != nil {
+		return nil, fmt.Errorf("跳转规则序列化失败: %w", err)
+	}
+	if err := model.UpdateRules(domain, req.ShortUrl, encoded); err != nil {
+		logger.Log.Error("更新跳转规则失败", zap.String("shortUrl", req.ShortUrl), zap.Error(err))
+		return nil, fmt.Errorf("更新跳转规则失败: %w", err)
+	}
+	cache.DelLink(mapping.Key())
+	emitLinkEvent(EventLinkUpdated, mapping, func(d *webhookLinkData) { d.Changes = []string{"rules"} })
+
+	logger.Log.Info("更新跳转规则成功",
+		zap.String("shortUrl", req.ShortUrl),
+		zap.Int("ruleCount", len(rules)))
+	return &shortlinkpb.UpdateLinkRulesResponse{RuleCount: int32(len(rules))}, nil
+}
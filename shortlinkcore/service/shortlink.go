@@ -5,6 +5,8 @@ import (
 	"crypto/sha256"
 	"errors"
 	"fmt"
+	"regexp"
+	"shortLink/common/event"
 	"shortLink/proto/shortlinkpb"
 	"shortLink/shortlinkcore/cache"
 	"shortLink/shortlinkcore/config"
@@ -12,13 +14,20 @@ import (
 	"shortLink/shortlinkcore/model"
 	"shortLink/shortlinkcore/pkg"
 	"shortLink/shortlinkcore/pkg/circuitbreaker"
+	"shortLink/shortlinkcore/pkg/geoip"
 	"shortLink/shortlinkcore/pkg/gopool"
 	"shortLink/shortlinkcore/pkg/locker"
 	"shortLink/shortlinkcore/pkg/safebrowsing"
+	"shortLink/shortlinkcore/pkg/useragent"
 	"shortLink/shortlinkcore/service/click"
+	"shortLink/shortlinkcore/service/routing"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
 )
 
 // ShortlinkService 实现短链接服务
@@ -36,19 +45,38 @@ func NewShortlinkService() *ShortlinkService {
 
 // 生成短链接
 func (s *ShortlinkService) ShortenURL(ctx context.Context, req *shortlinkpb.ShortenRequest) (*shortlinkpb.ShortenResponse, error) {
-	logger.Log.Info("收到生成短链接请求", zap.String("originalUrl", req.OriginalUrl))
+	logger.Log.Info("收到生成短链接请求", zap.String("originalUrl", req.OriginalUrl), zap.String("domain", req.Domain))
 
-	// 1. 检查数据库是否存在该长链接
-	ShortUrlDB := model.IsOriginalURLExist(req.OriginalUrl)
-	if ShortUrlDB != "" {
-		logger.Log.Info("找到已存在的短链接",
-			zap.String("originalUrl", req.OriginalUrl),
-			zap.String("shortUrl", ShortUrlDB))
-		return &shortlinkpb.ShortenResponse{ShortUrl: ShortUrlDB}, nil
+	domain, err := domainForCreate(req.UserId, req.Domain)
+	if err != nil {
+		return nil, err
+	}
+	rules := routing.FromPB(req.Rules)
+	if err := routing.Validate(rules); err != nil {
+		return nil, fmt.Errorf("跳转规则非法: %w", err)
+	}
+	variants := routing.VariantsFromPB(req.Variants)
+	if err := routing.ValidateVariants(variants); err != nil {
+		return nil, fmt.Errorf("分流配置非法: %w", err)
+	}
+
+	// 1. 检查数据库是否存在该长链接（带跳转规则或分流的短链接各自独立，不复用）
+	if len(rules) == 0 && len(variants) == 0 {
+		ShortUrlDB, err := model.IsOriginalURLExist(model.Owner{UserID: req.UserId, WorkspaceID: req.WorkspaceId}, domain, req.OriginalUrl)
+		if err != nil {
+			// 查询失败时直接生成新的短链接
+			logger.Log.Warn("查询已存在的短链接失败", zap.String("originalUrl", req.OriginalUrl), zap.Error(err))
+		}
+		if ShortUrlDB != "" {
+			logger.Log.Info("找到已存在的短链接",
+				zap.String("originalUrl", req.OriginalUrl),
+				zap.String("shortUrl", ShortUrlDB))
+			return &shortlinkpb.ShortenResponse{ShortUrl: ShortUrlDB, FullUrl: FullURL(domain, ShortUrlDB), Domain: domain}, nil
+		}
 	}
 
 	// 2. 生成短链接
-	shortUrl, err := Shorten(req.OriginalUrl, req.UserId)
+	shortUrl, err := ShortenWithOptions(req.OriginalUrl, req.UserId, ShortenOptions{Rules: rules, Variants: variants, Domain: domain, WorkspaceID: req.WorkspaceId})
 	if err != nil {
 		logger.Log.Error("生成短链接失败",
 			zap.String("originalUrl", req.OriginalUrl),
@@ -60,65 +88,220 @@ func (s *ShortlinkService) ShortenURL(ctx context.Context, req *shortlinkpb.Shor
 	logger.Log.Info("短链接生成成功",
 		zap.String("originalUrl", req.OriginalUrl),
 		zap.String("shortUrl", shortUrl))
-	return &shortlinkpb.ShortenResponse{ShortUrl: shortUrl}, nil
+	return &shortlinkpb.ShortenResponse{ShortUrl: shortUrl, FullUrl: FullURL(domain, shortUrl), Domain: domain}, nil
 }
 
 // 解析短链接
 func (s *ShortlinkService) Redierect(ctx context.Context, req *shortlinkpb.ResolveRequest) (*shortlinkpb.ResolveResponse, error) {
-	logger.Log.Info("收到解析短链接请求", zap.String("shortUrl", req.ShortUrl))
+	logger.Log.Info("收到解析短链接请求", zap.String("shortUrl", req.ShortUrl), zap.String("host", req.Host))
 
-	// 1. 解析短链接
-	originalURL, err := Resolve(req.ShortUrl)
+	// 1. 根据访问的域名解析短链接
+	mapping, err := Resolve(resolveDomain(req.Host), req.ShortUrl)
 	if err != nil {
 		logger.Log.Error("短链接解析失败",
 			zap.String("shortUrl", req.ShortUrl),
 			zap.Error(err))
-		return nil, fmt.Errorf("短链接不存在: %w", err)
+		return nil, status.Errorf(codes.NotFound, "短链接不存在: %v", err)
+	}
+	if mapping.Expired(time.Now()) {
+		logger.Log.Info("短链接已过期", zap.String("shortUrl", req.ShortUrl))
+		return nil, status.Error(codes.FailedPrecondition, "短链接已过期")
 	}
 
-	// 2. 异步更新点击量
-	go click.IncrClickCount(req.ShortUrl, originalURL)
+	// 2. 按访问者信息匹配跳转规则和 A/B 分流
+	dest := routing.Destination{Code: mapping.Key(), OriginalURL: mapping.OriginalURL}
+	if dest.Rules, err = routing.Decode(mapping.Rules); err != nil {
+		logger.Log.Warn("跳转规则解析失败，忽略规则",
+			zap.String("shortUrl", req.ShortUrl),
+			zap.Error(err))
+	}
+	if dest.Variants, err = routing.DecodeVariants(mapping.Variants); err != nil {
+		logger.Log.Warn("分流配置解析失败，忽略分流",
+			zap.String("shortUrl", req.ShortUrl),
+			zap.Error(err))
+	}
+	visitor := routing.VisitorFromHeaders(req.Headers)
+	visitor.ID = req.VisitorId
+	visitor.IP = req.ClientIp
+	if routing.NeedsGeo(dest.Rules) {
+		loc := geoip.Lookup(req.ClientIp)
+		visitor.Country, visitor.Region = loc.Country, loc.Region
+	}
+	target := dest.Pick(visitor)
+
+	// 3. 异步更新点击量并发送点击事件，点击量达到阈值时通知 Webhook
+	// 机器人访问照常跳转，但只计入机器人点击量，不影响点击量、排行榜、独立访客和 Webhook
+	go func() {
+		if reason := classifyClick(mapping.Key(), visitor.UserAgent, req.ClientIp); reason != "" {
+			click.IncrBotClick(mapping.Key(), reason)
+			e := newClickEvent(mapping, req, visitor, target.Variant)
+			e.Bot = reason
+			click.PublishEvent(e)
+			return
+		}
+		clicks := click.IncrClickCount(mapping.Key(), mapping.OriginalURL, target.Variant, click.OwnerRankScope(mapping.UserID, mapping.WorkspaceID))
+		click.RecordVisitor(mapping.Key(), click.VisitorID(config.GlobalConfig.App.AnalyticsSalt, req.ClientIp, visitor.UserAgent), time.Now())
+		click.PublishEvent(newClickEvent(mapping, req, visitor, target.Variant))
+		notifyClickThreshold(mapping, clicks)
+	}()
 
-	// 3. 返回原始链接
+	// 4. 返回跳转目标
 	logger.Log.Info("短链接解析成功",
 		zap.String("shortUrl", req.ShortUrl),
-		zap.String("originalUrl", originalURL))
-	return &shortlinkpb.ResolveResponse{OriginalUrl: originalURL}, nil
+		zap.String("originalUrl", mapping.OriginalURL),
+		zap.String("targetUrl", target.URL),
+		zap.String("variant", target.Variant))
+	return &shortlinkpb.ResolveResponse{
+		OriginalUrl:  target.URL,
+		FallbackUrl:  target.FallbackURL,
+		Variant:      target.Variant,
+		RedirectType: int32(mapping.RedirectType),
+	}, nil
 }
 
+// 热门短链接排行的返回数量
+const (
+	defaultTopCount = 10
+	maxTopCount     = 100
+)
+
+// GetTopLinks 获取时间窗口内点击量或热度最高的短链接
+// 默认只统计用户的个人短链接或指定工作区的短链接，global 为 true 时返回全站排行
 func (s *ShortlinkService) GetTopLinks(ctx context.Context, req *shortlinkpb.TopRequest) (*shortlinkpb.TopResponse, error) {
-	logger.Log.Info("收到获取热门短链接请求", zap.Int64("count", req.Count))
+	logger.Log.Info("收到获取热门短链接请求",
+		zap.String("userId", req.UserId),
+		zap.String("workspaceId", req.WorkspaceId),
+		zap.Bool("global", req.Global),
+		zap.Int64("count", req.Count),
+		zap.String("window", req.Window),
+		zap.String("mode", req.Mode))
+
+	// 1. 校验参数
+	mode := req.Mode
+	if mode == "" {
+		mode = click.RankByClicks
+	}
+	if mode != click.RankByClicks && mode != click.RankByTrending {
+		return nil, status.Errorf(codes.InvalidArgument, "不支持的排行方式: %s", mode)
+	}
+	window := req.Window
+	if window == "" {
+		window = click.WindowAll
+		if mode == click.RankByTrending {
+			window = click.WindowWeek
+		}
+	}
+	if !click.ValidWindow(window) {
+		return nil, status.Errorf(codes.InvalidArgument, "不支持的时间窗口: %s", window)
+	}
+	if window == click.WindowAll && mode == click.RankByTrending {
+		return nil, status.Error(codes.InvalidArgument, "热度排行最长支持最近7天")
+	}
+	scope := click.GlobalRankScope
+	if !req.Global {
+		if req.UserId == "" && req.WorkspaceId == "" {
+			return nil, status.Error(codes.InvalidArgument, "缺少用户或工作区")
+		}
+		scope = click.OwnerRankScope(req.UserId, req.WorkspaceId)
+	}
+	count := req.Count
+	if count <= 0 {
+		count = defaultTopCount
+	}
+	if count > maxTopCount {
+		count = maxTopCount
+	}
 
-	// 1. 获取点击量最高的短链接
-	rankList, err := click.GetTopShortLinks(req.Count)
+	// 2. 获取排名靠前的短链接
+	rankList, err := click.GetTopShortLinks(scope, window, mode, count, trendingHalfLife())
 	if err != nil {
 		logger.Log.Error("获取热门短链接失败", zap.Error(err))
-		return nil, err
+		return nil, status.Error(codes.Internal, "获取热门短链接失败")
 	}
 
-	// 2. 返回点击量最高的短链接
+	// 3. 附带最近7天的独立访客数
+	now := time.Now()
 	items := make([]*shortlinkpb.ShortLinkItem, 0)
 	for _, r := range rankList {
+		uniques, _ := click.UniqueVisitors(r.ShortUrl, now.AddDate(0, 0, -6), now)
+		domain, shortUrl := model.SplitLinkKey(r.ShortUrl)
 		items = append(items, &shortlinkpb.ShortLinkItem{
-			ShortUrl: r.ShortUrl,
-			Clicks:   r.Clicks,
+			ShortUrl:       shortUrl,
+			Domain:         domain,
+			OriginalUrl:    r.OriginalUrl,
+			Clicks:         r.Clicks,
+			UniqueVisitors: uniques,
 		})
 	}
 
-	// 3. 返回点击量最高的短链接
+	// 4. 返回排行
 	logger.Log.Info("获取热门短链接成功",
-		zap.Int64("count", req.Count),
+		zap.Int64("count", count),
 		zap.Int("resultCount", len(items)))
-	return &shortlinkpb.TopResponse{Top: items}, nil
+	return &shortlinkpb.TopResponse{Top: items, Window: window, Mode: mode}, nil
+}
+
+// trendingHalfLife 热度排行的半衰期
+func trendingHalfLife() time.Duration {
+	if h := config.GlobalConfig.App.TrendingHalfLifeHours; h > 0 {
+		return time.Duration(h * float64(time.Hour))
+	}
+	return 6 * time.Hour
+}
+
+// ShortenOptions 生成短链接的可选参数
+type ShortenOptions struct {
+	Rules        []routing.Rule    // 跳转规则
+	Variants     []routing.Variant // A/B 分流目标
+	Domain       string            // 短链接域名，为空表示系统默认域名
+	WorkspaceID  string            // 所属工作区，为空表示个人短链接
+	Alias        string            // 自定义短链接，为空时自动生成
+	Tags         []string          // 创建后打上的标签
+	ExpireAt     *time.Time        // 过期时间，为空表示永不过期
+	RedirectType int               // 跳转状态码，0 表示默认 302
 }
 
+// 自定义短链接只允许字母、数字、下划线和连字符
+var aliasPattern = regexp.MustCompile(`^[0-9A-Za-z_-]{1,32}$`)
+
+// 支持的跳转状态码
+var redirectTypes = map[int]bool{0: true, 301: true, 302: true, 307: true, 308: true}
+
+// validate 校验自定义短链接、过期时间和跳转状态码
+func (o ShortenOptions) validate() error {
+	if o.Alias != "" && !aliasPattern.MatchString(o.Alias) {
+		return errors.New("自定义短链接只能包含字母、数字、下划线和连字符，最长32位")
+	}
+	if o.ExpireAt != nil && !o.ExpireAt.After(time.Now()) {
+		return errors.New("过期时间必须晚于当前时间")
+	}
+	if !redirectTypes[o.RedirectType] {
+		return fmt.Errorf("不支持的跳转状态码: %d", o.RedirectType)
+	}
+	return nil
+}
+
+// Shorten 使用默认参数生成短链接
 func Shorten(longUrl, userID string) (string, error) {
+	return ShortenWithOptions(longUrl, userID, ShortenOptions{})
+}
+
+// ShortenWithOptions 生成短链接
+func ShortenWithOptions(longUrl, userID string, opts ShortenOptions) (string, error) {
 	// 1. 校验 URL 合法性
 	if !pkg.IsValidURL(longUrl) {
 		return "", errors.New("链接非法")
 	}
+	if err := opts.validate(); err != nil {
+		return "", err
+	}
 	// 2. 分布式锁（对 URL 做哈希防止 key 过长）防止并发过程中生成重复短链
-	urlHash := fmt.Sprintf("%x", sha256.Sum256([]byte(longUrl)))
+	// 自定义短链接按别名加锁，防止并发占用同一个别名
+	lockTarget := opts.Domain + "|" + longUrl
+	if opts.Alias != "" {
+		lockTarget = opts.Domain + "|alias|" + opts.Alias
+	}
+	urlHash := fmt.Sprintf("%x", sha256.Sum256([]byte(lockTarget)))
 	lockKey := "lock:shorten:" + urlHash
 	lock := locker.NewRedisLock(cache.GetRedis(), lockKey, 3*time.Second)
 	ok, err := lock.TryLock()
@@ -146,23 +329,134 @@ func Shorten(longUrl, userID string) (string, error) {
 	// 	return ShortUrlDB, nil
 	// }
 
-	// 4. 生成短链 Key（Base62）
-	shortKey, err := pkg.GenerateShortURL(config.GlobalConfig.App.Base62Length, cache.MightContain)
-	if err != nil {
-		logger.Log.Error("短链生成失败", zap.Error(err))
-		return "", errors.New("生成失败")
+	// 4. 生成短链 Key（Base62），指定了自定义短链接时检查是否已被占用
+	// 不同域名下的短链 Key 互不冲突，按域名区分检查；回收站中的短链接仍然占用
+	shortKey := opts.Alias
+	if shortKey != "" {
+		taken, err := model.IsCodeTaken(opts.Domain, shortKey)
+		if err != nil {
+			logger.Log.Error("检查自定义短链接失败", zap.Error(err), zap.String("alias", shortKey))
+			return "", errors.New("系统繁忙，请稍后重试")
+		}
+		if taken {
+			return "", fmt.Errorf("短链接 %s 已被占用", shortKey)
+		}
+	} else {
+		shortKey, err = pkg.GenerateShortURL(config.GlobalConfig.App.Base62Length, func(code string) bool {
+			return cache.MightContain(model.LinkKey(opts.Domain, code))
+		})
+		if err != nil {
+			logger.Log.Error("短链生成失败", zap.Error(err))
+			return "", errors.New("生成失败")
+		}
 	}
 
 	// 5. 更新布隆过滤器
-	cache.AddToBloom(shortKey)
+	cache.AddToBloom(model.LinkKey(opts.Domain, shortKey))
+
+	rules, err := routing.Encode(opts.Rules)
+	if err != nil {
+		logger.Log.Error("跳转规则序列化失败", zap.Error(err))
+		return "", errors.New("跳转规则非法")
+	}
+	variants, err := routing.EncodeVariants(opts.Variants)
+	if err != nil {
+		logger.Log.Error("分流配置序列化失败", zap.Error(err))
+		return "", errors.New("分流配置非法")
+	}
 
 	// 6. 持久化数据库
-	if err := model.SaveURLMappingWithUserID(shortKey, longUrl, userID); err != nil {
+	mapping := &model.URLMapping{
+		ShortURL:     shortKey,
+		Domain:       opts.Domain,
+		OriginalURL:  longUrl,
+		UserID:       userID,
+		WorkspaceID:  opts.WorkspaceID,
+		Status:       "active",
+		Rules:        rules,
+		Variants:     variants,
+		ExpireAt:     opts.ExpireAt,
+		RedirectType: opts.RedirectType,
+	}
+	if err := model.SaveMapping(mapping); err != nil {
 		logger.Log.Error("数据库保存失败", zap.Error(err), zap.String("shortKey", shortKey))
 		return "", errors.New("持久化失败")
 	}
 
-	// 6.1 异步安全检查
+	// 6.1 打标签，失败不影响短链接的使用
+	if len(opts.Tags) > 0 {
+		tagNewLink(userID, mapping, opts.Tags)
+	}
+
+	// 6.2 异步安全检查和预览抓取
+	checkSafety(opts.Domain, shortKey, longUrl)
+	schedulePreview(opts.Domain, shortKey, longUrl)
+
+	// 7. 写入 Redis 缓存
+	cache.SetLink(mapping)
+
+	// 8. 通知订阅了 link.created 的 Webhook
+	emitLinkEvent(EventLinkCreated, mapping, nil)
+
+	logger.Log.Info("短链生成成功",
+		zap.String("shortKey", shortKey),
+		zap.String("domain", opts.Domain),
+		zap.String("url", longUrl),
+	)
+
+	return shortKey, nil
+}
+
+// newClickEvent 生成点击事件，访问者IP只保留加盐哈希
+func newClickEvent(mapping *model.URLMapping, req *shortlinkpb.ResolveRequest, visitor routing.Visitor, variant string) *event.Click {
+	country := visitor.Country
+	if country == "" {
+		country = geoip.Lookup(req.ClientIp).Country
+	}
+	ua := useragent.Parse(visitor.UserAgent)
+	var referer string
+	for k, v := range req.Headers {
+		if strings.EqualFold(k, "Referer") {
+			referer = v
+		}
+	}
+	return &event.Click{
+		Code:        mapping.Key(),
+		Domain:      mapping.Domain,
+		ShortURL:    mapping.ShortURL,
+		UserID:      mapping.UserID,
+		WorkspaceID: mapping.WorkspaceID,
+		Timestamp:   time.Now().UnixMilli(),
+		IPHash:      event.HashIP(config.GlobalConfig.App.AnalyticsSalt, req.ClientIp),
+		UserAgent:   visitor.UserAgent,
+		Referer:     referer,
+		Country:     country,
+		Device:      ua.Device,
+		Browser:     ua.Browser,
+		OS:          ua.OS,
+		Variant:     variant,
+	}
+}
+
+// tagNewLink 为新建的短链接打标签
+func tagNewLink(userID string, mapping *model.URLMapping, names []string) {
+	valid, err := tagNames(names)
+	if err == nil {
+		var tags []model.Tag
+		if tags, err = model.GetOrCreateTags(userID, model.TagKindTag, valid); err == nil {
+			_, err = model.TagLinks(tags, []model.URLMapping{*mapping})
+		}
+	}
+	if err != nil {
+		logger.Log.Warn("新建短链接打标签失败",
+			zap.String("shortKey", mapping.ShortURL),
+			zap.Strings("tags", names),
+			zap.Error(err))
+	}
+}
+
+// checkSafety 异步检查目标地址是否安全
+func checkSafety(domain, shortKey, longUrl string) {
 	// 使用协程池进行异步安全检查，避免阻塞主流程
 	// 如果发现不安全URL，会更新数据库状态为blocked
 	pool := gopool.GetPool()
@@ -184,13 +478,13 @@ func Shorten(longUrl, userID string) (string, error) {
 		// 3. 更新数据库中的URL状态为blocked
 		// 4. 记录操作日志
 		if !isSafe {
-			cache.Del(shortKey)
+			cache.DelLink(model.LinkKey(domain, shortKey))
 			logger.Log.Warn("发现不安全URL",
 				zap.String("url", longUrl),
 				zap.String("threatType", threatType))
 
 			// 更新数据库状态为blocked
-			err = model.UpdateStatus(shortKey, "blocked", threatType)
+			err = model.UpdateStatus(domain, shortKey, "blocked", threatType)
 			if err != nil {
 				logger.Log.Error("更新URL状态失败",
 					zap.String("shortURL", shortKey),
@@ -200,46 +494,51 @@ func Shorten(longUrl, userID string) (string, error) {
 			logger.Log.Info("已封禁不安全URL",
 				zap.String("shortURL", shortKey),
 				zap.String("threatType", threatType))
+
+			// 通知订阅了 link.blocked 的 Webhook
+			if mapping, err := model.GetMapping(domain, shortKey); err == nil {
+				emitLinkEvent(EventLinkBlocked, mapping, nil)
+			}
 			return
 		}
 
 		logger.Log.Info("URL安全检查通过",
 			zap.String("url", longUrl))
 	})
+}
 
-	// 7. 写入 Redis 缓存
-	cache.Set(shortKey, longUrl)
-
-	logger.Log.Info("短链生成成功",
-		zap.String("shortKey", shortKey),
-		zap.String("url", longUrl),
-	)
+// errLinkNotFound 布隆过滤器判断短链接不存在
+var errLinkNotFound = errors.New("数据不存在")
 
-	return shortKey, nil
+// isLinkNotFound 是否为短链接不存在，其他错误（如数据库不可用）不算
+func isLinkNotFound(err error) bool {
+	return errors.Is(err, errLinkNotFound) || errors.Is(err, gorm.ErrRecordNotFound)
 }
 
 // Resolve 解析短链接
 // 参数：
+//   - domain: 短链接域名，为空表示系统默认域名
 //   - short: 短链接
 //
 // 返回：
-//   - string: 原始URL
+//   - *model.URLMapping: 短链接映射（原始URL、跳转规则等）
 //   - error: 错误信息，如果解析成功则为nil
-func Resolve(short string) (string, error) {
-	logger.Log.Debug("开始解析短链接", zap.String("shortUrl", short))
+func Resolve(domain, short string) (*model.URLMapping, error) {
+	logger.Log.Debug("开始解析短链接", zap.String("shortUrl", short), zap.String("domain", domain))
+	key := model.LinkKey(domain, short)
 
 	// 使用布隆过滤器检查短链接是否存在
-	if !cache.MightContain(short) {
+	if !cache.MightContain(key) {
 		logger.Log.Warn("布隆过滤器不存在该值", zap.String("shortUrl", short))
-		return "", errors.New("数据不存在")
+		return nil, errLinkNotFound
 	}
 
 	// 查缓存
-	if url := cache.Get(short); url != "" {
+	if mapping := cache.GetLink(key); mapping != nil {
 		logger.Log.Debug("从缓存中获取到原始链接",
 			zap.String("shortUrl", short),
-			zap.String("originalUrl", url))
-		return url, nil
+			zap.String("originalUrl", mapping.OriginalURL))
+		return mapping, nil
 	}
 
 	// 使用布隆过滤器检查短链接是否存在
@@ -256,41 +555,30 @@ func Resolve(short string) (string, error) {
 
 	// 使用 singleflight 防止缓存击穿
 	logger.Log.Debug("使用singleflight从数据库获取原始链接", zap.String("shortUrl", short))
-	v, err, _ := pkg.Group.Do(short, func() (any, error) {
-		return model.GetOriginalURL(short)
+	v, err, _ := pkg.Group.Do(key, func() (any, error) {
+		return model.GetMapping(domain, short)
 	})
 	if err != nil {
 		logger.Log.Error("从数据库获取原始链接失败",
 			zap.String("shortUrl", short),
 			zap.Error(err))
-		return "", err
+		return nil, err
 	}
-	original := v.(string)
+	mapping := v.(*model.URLMapping)
 
 	// 缓存结果
-	cache.Set(short, original)
+	cache.SetLink(mapping)
 	logger.Log.Debug("解析短链接成功并更新缓存",
 		zap.String("shortUrl", short),
-		zap.String("originalUrl", original))
-	return original, nil
+		zap.String("originalUrl", mapping.OriginalURL))
+	return mapping, nil
 }
 
-// DeleteUserURLs 删除用户的所有短链接
+// DeleteUserURLs 将用户的所有短链接移入回收站
+// 点击量、标签和预览保留，恢复后继续使用；保留期过后由 purgeTrash 彻底删除
 func (s *ShortlinkService) DeleteUserURLs(ctx context.Context, req *shortlinkpb.DeleteUserURLsRequest) (*shortlinkpb.DeleteUserURLsResponse, error) {
 	logger.Log.Info("收到删除用户短链接请求", zap.String("userId", req.UserId))
 
-	// 1. 获取用户的所有短链接
-	var mappings []model.URLMapping
-	if err := model.GetDB().Where("user_id = ?", req.UserId).Find(&mappings).Error; err != nil {
-		logger.Log.Error("获取用户短链接失败", zap.String("userId", req.UserId), zap.Error(err))
-		return nil, fmt.Errorf("获取用户短链接失败: %w", err)
-	}
-
-	if len(mappings) == 0 {
-		logger.Log.Info("用户没有短链接", zap.String("userId", req.UserId))
-		return &shortlinkpb.DeleteUserURLsResponse{DeletedCount: 0}, nil
-	}
-
 	// 保持正确的删除顺序：先删除数据库，再删除缓存
 	/* 	线程A删除缓存
 	线程B查询数据库，发现数据还存在
@@ -298,25 +586,43 @@ func (s *ShortlinkService) DeleteUserURLs(ctx context.Context, req *shortlinkpb.
 	线程A删除数据库
 	最终导致缓存和数据库不一致*/
 
-	// 2. 先删除数据库记录
-	result := model.GetDB().Where("user_id = ?", req.UserId).Delete(&model.URLMapping{})
-	if result.Error != nil {
-		logger.Log.Error("删除用户短链接失败", zap.String("userId", req.UserId), zap.Error(result.Error))
-		return nil, fmt.Errorf("删除用户短链接失败: %w", result.Error)
+	// 1. 先将数据库记录移入回收站
+	mappings, err := model.TrashUserLinks(req.UserId)
+	if err != nil {
+		logger.Log.Error("删除用户短链接失败", zap.String("userId", req.UserId), zap.Error(err))
+		return nil, fmt.Errorf("删除用户短链接失败: %w", err)
 	}
 
-	deletedCount := int32(result.RowsAffected)
+	if len(mappings) == 0 {
+		logger.Log.Info("用户没有短链接", zap.String("userId", req.UserId))
+		return &shortlinkpb.DeleteUserURLsResponse{DeletedCount: 0}, nil
+	}
 
-	// 3. 删除Redis缓存和点击量
+	deletedCount := int32(len(mappings))
+
+	// 2. 删除Redis缓存，点击量保留
 	redis := cache.GetRedis()
+	ranked := make(map[string][]string)
 	for _, mapping := range mappings {
-		// 删除短链接缓存
-		redis.Del(ctx, mapping.ShortURL)
-		// 删除点击量
-		redis.Del(ctx, fmt.Sprintf("click:%s-%s", mapping.ShortURL, mapping.OriginalURL))
-		// 从排行榜中删除
-		redis.ZRem(ctx, "shortlink:rank", fmt.Sprintf("%s-%s", mapping.ShortURL, mapping.OriginalURL))
+		key := mapping.Key()
+		// 删除短链接缓存和预览缓存
+		redis.Del(ctx, cache.LinkKey(key), cache.PreviewKey(key))
+		scope := click.OwnerRankScope(mapping.UserID, mapping.WorkspaceID)
+		ranked[scope] = append(ranked[scope], click.Member(key, mapping.OriginalURL))
+	}
+	// 从全站和归属者的排行榜中删除，恢复时按点击量重新加入
+	for scope, members := range ranked {
+		if err := click.RemoveFromRanks(ctx, scope, members...); err != nil {
+			logger.Log.Warn("从排行榜中删除短链接失败", zap.String("scope", scope), zap.Error(err))
+		}
+	}
+
+	// 3. 通知订阅了 link.deleted 的 Webhook
+	deleted := make([]webhookLinkData, 0, len(mappings))
+	for i := range mappings {
+		deleted = append(deleted, newWebhookLinkData(&mappings[i]))
 	}
+	emitLinkEvents(req.UserId, EventLinkDeleted, deleted)
 
 	logger.Log.Info("删除用户短链接成功",
 		zap.String("userId", req.UserId),
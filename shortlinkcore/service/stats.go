@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"shortLink/proto/shortlinkpb"
+	"shortLink/shortlinkcore/logger"
+	"shortLink/shortlinkcore/model"
+	"shortLink/shortlinkcore/service/click"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// 单次查询最多返回的时间段数量
+const maxStatsBuckets = 1500
+
+// defaultStatsRange 未指定起始时间时各粒度的默认查询范围
+var defaultStatsRange = map[string]time.Duration{
+	click.GranularityMinute: time.Hour,
+	click.GranularityHour:   24 * time.Hour,
+	click.GranularityDay:    30 * 24 * time.Hour,
+}
+
+// GetLinkStats 按分钟、小时或天查询短链接的点击趋势和独立访客数
+// 最近的时间段从 Redis 分时段计数读取，更早的从 MySQL 统计表读取；分钟粒度只保留最近24小时
+func (s *ShortlinkService) GetLinkStats(ctx context.Context, req *shortlinkpb.GetLinkStatsRequest) (*shortlinkpb.GetLinkStatsResponse, error) {
+	logger.Log.Info("收到查询点击趋势请求",
+		zap.String("shortUrl", req.ShortUrl),
+		zap.String("userId", req.UserId),
+		zap.String("granularity", req.Granularity))
+
+	// 1. 校验参数
+	g := req.Granularity
+	if g == "" {
+		g = click.GranularityHour
+	}
+	if !click.ValidGranularity(g) {
+		return nil, status.Errorf(codes.InvalidArgument, "不支持的时间粒度: %s", g)
+	}
+	now := time.Now()
+	to := now
+	if req.To > 0 {
+		to = time.Unix(req.To, 0)
+	}
+	from := to.Add(-defaultStatsRange[g])
+	if req.From > 0 {
+		from = time.Unix(req.From, 0)
+	}
+	if !from.Before(to) {
+		return nil, status.Error(codes.InvalidArgument, "起始时间必须早于结束时间")
+	}
+	recent := click.RecentSince(g, now)
+	if g == click.GranularityMinute && click.BucketStart(g, from).Before(recent) {
+		return nil, status.Error(codes.InvalidArgument, "分钟粒度只支持查询最近24小时")
+	}
+	buckets, ok := click.Buckets(g, from, to, maxStatsBuckets)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "时间段过多，单次最多查询%d个", maxStatsBuckets)
+	}
+
+	// 2. 校验短链接归属
+	mapping, err := model.GetOwnedMapping(model.Owner{UserID: req.UserId, WorkspaceID: req.WorkspaceId}, requestDomain(req.Domain), req.ShortUrl)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Log.Warn("短链接不存在或不属于该用户或工作区",
+			zap.String("shortUrl", req.ShortUrl),
+			zap.String("userId", req.UserId))
+		return nil, status.Error(codes.NotFound, "短链接不存在")
+	}
+	if err != nil {
+		logger.Log.Error("查询短链接失败", zap.String("shortUrl", req.ShortUrl), zap.Error(err))
+		return nil, status.Error(codes.Internal, "查询点击趋势失败")
+	}
+
+	// 3. 早于 recent 的时间段读 MySQL，其余读 Redis
+	split := 0
+	for split < len(buckets) && buckets[split].Before(recent) {
+		split++
+	}
+	clicks := make([]int64, 0, len(buckets))
+	if split > 0 {
+		old, err := rollupClicks(g, mapping.Key(), buckets[0], recent)
+		if err != nil {
+			logger.Log.Error("查询点击统计表失败", zap.String("shortUrl", req.ShortUrl), zap.Error(err))
+			return nil, status.Error(codes.Internal, "查询点击趋势失败")
+		}
+		for _, b := range buckets[:split] {
+			clicks = append(clicks, old[b.Unix()])
+		}
+	}
+	latest, err := click.GetBucketClicks(g, mapping.Key(), buckets[split:])
+	if err != nil {
+		return nil, status.Error(codes.Internal, "查询点击趋势失败")
+	}
+	clicks = append(clicks, latest...)
+
+	resp := &shortlinkpb.GetLinkStatsResponse{
+		Granularity: g,
+		From:        from.Unix(),
+		To:          to.Unix(),
+		Buckets:     make([]*shortlinkpb.StatsBucket, 0, len(buckets)),
+	}
+	for i, b := range buckets {
+		resp.Buckets = append(resp.Buckets, &shortlinkpb.StatsBucket{Time: b.Unix(), Clicks: clicks[i]})
+		resp.TotalClicks += clicks[i]
+	}
+
+	// 4. 机器人点击量与点击量按相同的时间段统计；它和独立访客数读取失败时都不影响点击量
+	if resp.BotClicks, err = botClicks(g, mapping.Key(), buckets, split, recent); err != nil {
+		logger.Log.Warn("查询机器人点击量失败", zap.String("shortUrl", req.ShortUrl), zap.Error(err))
+		resp.BotClicks = 0
+	}
+	if resp.UniqueVisitors, err = click.UniqueVisitors(mapping.Key(), from, to); err != nil {
+		resp.UniqueVisitors = 0
+	}
+	if g == click.GranularityDay {
+		if daily, err := click.DailyUniqueVisitors(mapping.Key(), buckets); err == nil {
+			for i, n := range daily {
+				resp.Buckets[i].UniqueVisitors = n
+			}
+		}
+	}
+	return resp, nil
+}
+
+// botClicks 统计各时间段的机器人点击量之和，早于 recent 的前 split 个时间段读 MySQL，其余读 Redis
+func botClicks(g, linkKey string, buckets []time.Time, split int, recent time.Time) (int64, error) {
+	var total int64
+	if split > 0 {
+		var err error
+		if g == click.GranularityDay {
+			total, err = model.GetDailyBotClicks(linkKey, buckets[0], recent)
+		} else {
+			total, err = model.GetHourlyBotClicks(linkKey, buckets[0], recent)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	latest, err := click.GetBucketBotClicks(g, linkKey, buckets[split:])
+	if err != nil {
+		return 0, err
+	}
+	for _, n := range latest {
+		total += n
+	}
+	return total, nil
+}
+
+// rollupClicks 从 MySQL 统计表读取 [from, to) 内各时间段的点击量
+func rollupClicks(g, linkKey string, from, to time.Time) (map[int64]int64, error) {
+	if g == click.GranularityDay {
+		return model.GetDailyClicks(linkKey, from, to)
+	}
+	return model.GetHourlyClicks(linkKey, from, to)
+}
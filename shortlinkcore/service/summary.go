@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"shortLink/common/ratelimit"
+	"shortLink/shortlinkcore/cache"
+	"shortLink/shortlinkcore/config"
+	"shortLink/shortlinkcore/logger"
+	"shortLink/shortlinkcore/model"
+	"shortLink/shortlinkcore/pkg/gopool"
+	"shortLink/shortlinkcore/pkg/preview"
+
+	"go.uber.org/zap"
+)
+
+// 当前生效的摘要生成器，Nacos 配置变化后重建
+var (
+	summarizerMu   sync.Mutex
+	summarizerCfg  config.SummaryConfig
+	summarizerOK   bool
+	summarizer     preview.Summarizer
+	summaryLimiter ratelimit.RateLimiter
+)
+
+// currentSummarizer 根据最新配置返回摘要生成器和限流器，未启用时返回nil
+func currentSummarizer() (preview.Summarizer, ratelimit.RateLimiter) {
+	cfg := config.GlobalConfig.Summary
+
+	summarizerMu.Lock()
+	defer summarizerMu.Unlock()
+	if summarizerOK && cfg == summarizerCfg {
+		return summarizer, summaryLimiter
+	}
+
+	summarizerCfg, summarizerOK = cfg, true
+	switch cfg.Provider {
+	case "openai":
+		summarizer = preview.NewOpenAISummarizer(preview.OpenAIConfig{
+			BaseURL: cfg.BaseURL,
+			APIKey:  cfg.APIKey,
+			Model:   cfg.Model,
+			Prompt:  cfg.Prompt,
+			Timeout: time.Duration(cfg.Timeout) * time.Second,
+		})
+	case "extractive":
+		summarizer = preview.ExtractiveSummarizer{}
+	default:
+		summarizer = nil
+		if cfg.Provider != "" {
+			logger.Log.Warn("未知的摘要生成方式，不生成摘要", zap.String("provider", cfg.Provider))
+		}
+	}
+
+	rate := cfg.RateLimit
+	if rate <= 0 {
+		rate = 1
+	}
+	capacity := int64(rate)
+	if capacity < 1 {
+		capacity = 1
+	}
+	summaryLimiter, _ = ratelimit.NewRateLimiter(&ratelimit.Config{
+		Type:     "token_bucket",
+		Rate:     rate,
+		Capacity: capacity,
+	})
+	logger.Log.Info("摘要生成器已更新", zap.String("provider", cfg.Provider), zap.Float64("rate", rate))
+	return summarizer, summaryLimiter
+}
+
+// 超过限流的摘要延迟重试，每次重试的等待时间翻倍
+const (
+	summaryRetryDelay = 30 * time.Second
+	summaryMaxRetries = 5
+)
+
+// summarizePreview 生成并保存摘要，超过限流时延迟重试
+func summarizePreview(p *model.LinkPreview, info *preview.Info) {
+	trySummarize(p, info, 0)
+}
+
+// trySummarize 生成并保存摘要，attempt 为已经因限流重试的次数
+func trySummarize(p *model.LinkPreview, info *preview.Info, attempt int) {
+	s, limiter := currentSummarizer()
+	if s == nil {
+		return
+	}
+	if !limiter.Allow(context.Background(), "summary") {
+		retrySummary(p, info, attempt)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	summary, err := s.Summarize(ctx, info)
+	if err != nil {
+		logger.Log.Warn("生成摘要失败", zap.String("shortUrl", p.ShortURL), zap.Error(err))
+		return
+	}
+
+	if err := model.UpdatePreviewSummary(p.Domain, p.ShortURL, p.SourceURL, summary); err != nil {
+		logger.Log.Error("保存摘要失败", zap.String("shortUrl", p.ShortURL), zap.Error(err))
+		return
+	}
+	// 删除缓存，下次读取时从数据库加载带摘要的预览
+	cache.DelPreview(p.Key())
+	logger.Log.Info("摘要生成成功", zap.String("shortUrl", p.ShortURL))
+}
+
+// retrySummary 超过限流时延迟后重新提交摘要任务，超过重试次数后放弃
+func retrySummary(p *model.LinkPreview, info *preview.Info, attempt int) {
+	if attempt >= summaryMaxRetries {
+		logger.Log.Warn("摘要生成多次超过限流，放弃", zap.String("shortUrl", p.ShortURL), zap.Int("attempts", attempt))
+		return
+	}
+	delay := summaryRetryDelay << attempt
+	logger.Log.Info("摘要生成超过限流，稍后重试",
+		zap.String("shortUrl", p.ShortURL),
+		zap.Int("attempt", attempt+1),
+		zap.Duration("delay", delay))
+	time.AfterFunc(delay, func() {
+		err := gopool.GetPool().Submit(func() {
+			trySummarize(p, info, attempt+1)
+		})
+		if err != nil {
+			logger.Log.Warn("提交摘要任务失败", zap.String("shortUrl", p.ShortURL), zap.Error(err))
+		}
+	})
+}
+
+// scheduleSummary 使用协程池异步生成摘要
+func scheduleSummary(p *model.LinkPreview, info *preview.Info) {
+	err := gopool.GetPool().Submit(func() {
+		summarizePreview(p, info)
+	})
+	if err != nil {
+		logger.Log.Warn("提交摘要任务失败", zap.String("shortUrl", p.ShortURL), zap.Error(err))
+	}
+}
@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"shortLink/proto/shortlinkpb"
+	"shortLink/shortlinkcore/logger"
+	"shortLink/shortlinkcore/model"
+	"shortLink/shortlinkcore/service/click"
+
+	"go.uber.org/zap"
+)
+
+// 单次批量操作的上限
+const (
+	maxTagsPerRequest  = 20
+	maxLinksPerRequest = 500
+)
+
+// tagKind 校验标签类型，为空时默认为普通标签
+func tagKind(kind string) (string, error) {
+	switch kind {
+	case "":
+		return model.TagKindTag, nil
+	case model.TagKindTag, model.TagKindCampaign:
+		return kind, nil
+	}
+	return "", fmt.Errorf("不支持的标签类型: %s", kind)
+}
+
+// tagNames 清理并去重标签名称
+func tagNames(names []string) ([]string, error) {
+	seen := make(map[string]bool, len(names))
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		if utf8.RuneCountInString(name) > 64 {
+			return nil, fmt.Errorf("标签名称过长: %s", name)
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+	if len(result) == 0 {
+		return nil, errors.New("标签不能为空")
+	}
+	if len(result) > maxTagsPerRequest {
+		return nil, fmt.Errorf("单次最多操作%d个标签", maxTagsPerRequest)
+	}
+	return result, nil
+}
+
+// ownedLinks 将请求中的短链接转换为归属范围内的短链接，返回被忽略的数量
+// 指定工作区时为工作区的短链接（权限由网关校验），否则为用户的个人短链接
+func ownedLinks(owner model.Owner, refs []*shortlinkpb.LinkRef) ([]model.URLMapping, int, error) {
+	if len(refs) == 0 {
+		return nil, 0, errors.New("短链接列表为空")
+	}
+	if len(refs) > maxLinksPerRequest {
+		return nil, 0, fmt.Errorf("单次最多操作%d个短链接", maxLinksPerRequest)
+	}
+	seen := make(map[string]bool, len(refs))
+	wanted := make([]model.URLMapping, 0, len(refs))
+	for _, ref := range refs {
+		m := model.URLMapping{ShortURL: ref.ShortUrl, Domain: requestDomain(ref.Domain)}
+		if m.ShortURL == "" || seen[m.Key()] {
+			continue
+		}
+		seen[m.Key()] = true
+		wanted = append(wanted, m)
+	}
+	mappings, err := model.GetOwnedMappings(owner, wanted)
+	if err != nil {
+		return nil, 0, fmt.Errorf("查询短链接失败: %w", err)
+	}
+	return mappings, len(wanted) - len(mappings), nil
+}
+
+// TagLinks 批量为短链接打标签，不存在的标签自动创建
+func (s *ShortlinkService) TagLinks(ctx context.Context, req *shortlinkpb.TagLinksRequest) (*shortlinkpb.TagLinksResponse, error) {
+	logger.Log.Info("收到批量打标签请求",
+		zap.String("userId", req.UserId),
+		zap.String("workspaceId", req.WorkspaceId),
+		zap.String("kind", req.Kind),
+		zap.Strings("tags", req.Tags),
+		zap.Int("linkCount", len(req.Links)))
+
+	kind, err := tagKind(req.Kind)
+	if err != nil {
+		return nil, err
+	}
+	names, err := tagNames(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+	mappings, skipped, err := ownedLinks(model.Owner{UserID: req.UserId, WorkspaceID: req.WorkspaceId}, req.Links)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := model.GetOrCreateTags(req.UserId, kind, names)
+	if err != nil {
+		logger.Log.Error("创建标签失败", zap.String("userId", req.UserId), zap.Error(err))
+		return nil, fmt.Errorf("创建标签失败: %w", err)
+	}
+	affected, err := model.TagLinks(tags, mappings)
+	if err != nil {
+		logger.Log.Error("打标签失败", zap.String("userId", req.UserId), zap.Error(err))
+		return nil, fmt.Errorf("打标签失败: %w", err)
+	}
+
+	logger.Log.Info("批量打标签成功",
+		zap.String("userId", req.UserId),
+		zap.Int64("affected", affected),
+		zap.Int("skipped", skipped))
+	return &shortlinkpb.TagLinksResponse{Affected: affected, Skipped: int32(skipped)}, nil
+}
+
+// UntagLinks 批量移除短链接的标签，标签本身保留
+func (s *ShortlinkService) UntagLinks(ctx context.Context, req *shortlinkpb.TagLinksRequest) (*shortlinkpb.TagLinksResponse, error) {
+	logger.Log.Info("收到批量移除标签请求",
+		zap.String("userId", req.UserId),
+		zap.String("workspaceId", req.WorkspaceId),
+		zap.String("kind", req.Kind),
+		zap.Strings("tags", req.Tags),
+		zap.Int("linkCount", len(req.Links)))
+
+	kind, err := tagKind(req.Kind)
+	if err != nil {
+		return nil, err
+	}
+	names, err := tagNames(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+	mappings, skipped, err := ownedLinks(model.Owner{UserID: req.UserId, WorkspaceID: req.WorkspaceId}, req.Links)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []model.Tag
+	for _, name := range names {
+		if tag, err := model.GetTag(req.UserId, kind, name); err == nil {
+			tags = append(tags, *tag)
+		}
+	}
+	affected, err := model.UntagLinks(tags, mappings)
+	if err != nil {
+		logger.Log.Error("移除标签失败", zap.String("userId", req.UserId), zap.Error(err))
+		return nil, fmt.Errorf("移除标签失败: %w", err)
+	}
+
+	logger.Log.Info("批量移除标签成功",
+		zap.String("userId", req.UserId),
+		zap.Int64("affected", affected),
+		zap.Int("skipped", skipped))
+	return &shortlinkpb.TagLinksResponse{Affected: affected, Skipped: int32(skipped)}, nil
+}
+
+// ListTags 查询用户的标签和活动
+func (s *ShortlinkService) ListTags(ctx context.Context, req *shortlinkpb.ListTagsRequest) (*shortlinkpb.ListTagsResponse, error) {
+	kind := req.Kind
+	if kind != "" {
+		var err error
+		if kind, err = tagKind(kind); err != nil {
+			return nil, err
+		}
+	}
+	tags, err := model.ListUserTags(req.UserId, kind)
+	if err != nil {
+		return nil, fmt.Errorf("查询标签失败: %w", err)
+	}
+	resp := &shortlinkpb.ListTagsResponse{Tags: make([]*shortlinkpb.TagInfo, 0, len(tags))}
+	for _, t := range tags {
+		resp.Tags = append(resp.Tags, &shortlinkpb.TagInfo{
+			Name:       t.Name,
+			Kind:       t.Kind,
+			LinkCount:  t.LinkCount,
+			CreateTime: t.CreateTime.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// ListLinks 分页查询用户的个人短链接，指定工作区时查询工作区的所有短链接
+func (s *ShortlinkService) ListLinks(ctx context.Context, req *shortlinkpb.ListLinksRequest) (*shortlinkpb.ListLinksResponse, error) {
+	page, pageSize := int(req.Page), int(req.PageSize)
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+	owner := model.Owner{UserID: req.UserId, WorkspaceID: req.WorkspaceId}
+	mappings, total, err := model.ListLinks(owner, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("查询短链接失败: %w", err)
+	}
+
+	resp := &shortlinkpb.ListLinksResponse{Total: total, Links: make([]*shortlinkpb.LinkInfo, 0, len(mappings))}
+	for _, m := range mappings {
+		resp.Links = append(resp.Links, linkInfo(&m))
+	}
+	return resp, nil
+}
+
+// ListLinksByTag 分页查询标签下归属范围内的短链接
+func (s *ShortlinkService) ListLinksByTag(ctx context.Context, req *shortlinkpb.ListLinksByTagRequest) (*shortlinkpb.ListLinksByTagResponse, error) {
+	kind, err := tagKind(req.Kind)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := model.GetTag(req.UserId, kind, strings.TrimSpace(req.Tag))
+	if err != nil {
+		return nil, errors.New("标签不存在")
+	}
+
+	page, pageSize := int(req.Page), int(req.PageSize)
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+	owner := model.Owner{UserID: req.UserId, WorkspaceID: req.WorkspaceId}
+	mappings, total, err := model.ListTagLinks(owner, tag.ID, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("查询标签下的短链接失败: %w", err)
+	}
+
+	resp := &shortlinkpb.ListLinksByTagResponse{Total: total, Links: make([]*shortlinkpb.LinkInfo, 0, len(mappings))}
+	for _, m := range mappings {
+		resp.Links = append(resp.Links, linkInfo(&m))
+	}
+	return resp, nil
+}
+
+// linkInfo 转换为列表中展示的短链接信息
+func linkInfo(m *model.URLMapping) *shortlinkpb.LinkInfo {
+	return &shortlinkpb.LinkInfo{
+		ShortUrl:    m.ShortURL,
+		Domain:      m.Domain,
+		FullUrl:     FullURL(m.Domain, m.ShortURL),
+		OriginalUrl: m.OriginalURL,
+		Status:      m.Status,
+		CreateTime:  m.CreateTime.Unix(),
+	}
+}
+
+// GetTagClicks 汇总标签或活动下归属范围内所有短链接的点击量
+func (s *ShortlinkService) GetTagClicks(ctx context.Context, req *shortlinkpb.GetTagClicksRequest) (*shortlinkpb.GetTagClicksResponse, error) {
+	logger.Log.Info("收到查询标签点击量请求",
+		zap.String("userId", req.UserId),
+		zap.String("workspaceId", req.WorkspaceId),
+		zap.String("kind", req.Kind),
+		zap.String("tag", req.Tag))
+
+	kind, err := tagKind(req.Kind)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := model.GetTag(req.UserId, kind, strings.TrimSpace(req.Tag))
+	if err != nil {
+		return nil, errors.New("标签不存在")
+	}
+	// 只统计当前仍有权限的短链接，离开工作区后不能再查看工作区短链接的点击量
+	mappings, err := model.GetTagMappings(model.Owner{UserID: req.UserId, WorkspaceID: req.WorkspaceId}, tag.ID)
+	if err != nil {
+		return nil, fmt.Errorf("查询标签下的短链接失败: %w", err)
+	}
+
+	members := make([]string, 0, len(mappings))
+	for i := range mappings {
+		members = append(members, click.Member(mappings[i].Key(), mappings[i].OriginalURL))
+	}
+	counts, err := click.GetClickCounts(members)
+	if err != nil {
+		return nil, fmt.Errorf("获取点击量失败: %w", err)
+	}
+
+	resp := &shortlinkpb.GetTagClicksResponse{LinkCount: int64(len(mappings))}
+	for i, m := range mappings {
+		resp.TotalClicks += counts[i]
+		resp.Links = append(resp.Links, &shortlinkpb.LinkClicks{
+			ShortUrl: m.ShortURL,
+			Domain:   m.Domain,
+			Clicks:   counts[i],
+		})
+	}
+	sort.SliceStable(resp.Links, func(i, j int) bool {
+		return resp.Links[i].Clicks > resp.Links[j].Clicks
+	})
+	return resp, nil
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"shortLink/proto/shortlinkpb"
+	"shortLink/shortlinkcore/cache"
+	"shortLink/shortlinkcore/config"
+	"shortLink/shortlinkcore/logger"
+	"shortLink/shortlinkcore/model"
+	"shortLink/shortlinkcore/service/click"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultTrashRetentionDays = 30
+	trashPurgeInterval        = time.Hour // 清理回收站的间隔
+	trashPurgeBatch           = 500       // 每次彻底删除的数量
+)
+
+// trashRetention 回收站的保留时间
+func trashRetention() time.Duration {
+	days := config.GlobalConfig.App.TrashRetentionDays
+	if days <= 0 {
+		days = defaultTrashRetentionDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// ListTrash 分页查询回收站中的短链接
+func (s *ShortlinkService) ListTrash(ctx context.Context, req *shortlinkpb.ListTrashRequest) (*shortlinkpb.ListTrashResponse, error) {
+	page, pageSize := int(req.Page), int(req.PageSize)
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+	mappings, total, err := model.ListTrash(req.UserId, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("查询回收站失败: %w", err)
+	}
+
+	retention := trashRetention()
+	resp := &shortlinkpb.ListTrashResponse{Total: total, Links: make([]*shortlinkpb.TrashedLink, 0, len(mappings))}
+	for _, m := range mappings {
+		resp.Links = append(resp.Links, &shortlinkpb.TrashedLink{
+			ShortUrl:    m.ShortURL,
+			Domain:      m.Domain,
+			FullUrl:     FullURL(m.Domain, m.ShortURL),
+			OriginalUrl: m.OriginalURL,
+			DeletedAt:   m.DeletedAt.Time.Unix(),
+			PurgeAt:     m.DeletedAt.Time.Add(retention).Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// RestoreLinks 将短链接移出回收站，点击量、标签和预览都还在
+func (s *ShortlinkService) RestoreLinks(ctx context.Context, req *shortlinkpb.RestoreLinksRequest) (*shortlinkpb.RestoreLinksResponse, error) {
+	logger.Log.Info("收到恢复短链接请求",
+		zap.String("userId", req.UserId),
+		zap.Int("count", len(req.Links)))
+
+	if len(req.Links) > maxLinksPerRequest {
+		return nil, fmt.Errorf("单次最多操作%d个短链接", maxLinksPerRequest)
+	}
+	seen := make(map[string]bool, len(req.Links))
+	wanted := make([]model.URLMapping, 0, len(req.Links))
+	for _, ref := range req.Links {
+		m := model.URLMapping{ShortURL: ref.ShortUrl, Domain: requestDomain(ref.Domain)}
+		if m.ShortURL == "" || seen[m.Key()] {
+			continue
+		}
+		seen[m.Key()] = true
+		wanted = append(wanted, m)
+	}
+	if len(req.Links) > 0 && len(wanted) == 0 {
+		return nil, errors.New("短链接列表为空")
+	}
+
+	// wanted 为空时恢复整个回收站
+	trashed, err := model.GetTrashedMappings(req.UserId, wanted)
+	if err != nil {
+		return nil, fmt.Errorf("查询回收站失败: %w", err)
+	}
+	restored, err := model.RestoreMappings(trashed)
+	if err != nil {
+		logger.Log.Error("恢复短链接失败", zap.String("userId", req.UserId), zap.Error(err))
+		return nil, fmt.Errorf("恢复短链接失败: %w", err)
+	}
+	restoreRank(ctx, trashed)
+
+	skipped := 0
+	if len(wanted) > 0 {
+		skipped = len(wanted) - len(trashed)
+	}
+	logger.Log.Info("恢复短链接成功",
+		zap.String("userId", req.UserId),
+		zap.Int64("restored", restored),
+		zap.Int("skipped", skipped))
+	return &shortlinkpb.RestoreLinksResponse{Restored: restored, Skipped: int64(skipped)}, nil
+}
+
+// restoreRank 按保留的点击量把恢复的短链接重新加入全站和归属者的排行榜
+func restoreRank(ctx context.Context, mappings []model.URLMapping) {
+	if len(mappings) == 0 {
+		return
+	}
+	members := make([]string, 0, len(mappings))
+	for i := range mappings {
+		members = append(members, click.Member(mappings[i].Key(), mappings[i].OriginalURL))
+	}
+	counts, err := click.GetClickCounts(members)
+	if err != nil {
+		logger.Log.Warn("恢复排行榜失败", zap.Error(err))
+		return
+	}
+	ranks := make([]click.RankedMember, 0, len(members))
+	for i, n := range counts {
+		if n > 0 {
+			ranks = append(ranks, click.RankedMember{
+				Member: members[i],
+				Scope:  click.OwnerRankScope(mappings[i].UserID, mappings[i].WorkspaceID),
+				Clicks: n,
+			})
+		}
+	}
+	if err := click.RestoreRanks(ctx, ranks); err != nil {
+		logger.Log.Warn("恢复排行榜失败", zap.Error(err))
+	}
+}
+
+// StartTrashPurge 启动定时清理回收站的协程，多个实例同时清理互不影响
+func StartTrashPurge() {
+	go func() {
+		purgeTrash()
+		ticker := time.NewTicker(trashPurgeInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			purgeTrash()
+		}
+	}()
+}
+
+// purgeTrash 彻底删除超过保留期的短链接，以及它们的缓存和点击量
+// 彻底删除的短链接记录为已删除，不会再被分配，点击趋势、独立访客等按 LinkKey 记录的统计数据不会被新短链接继承，
+// 这些数据在 Redis 中会自然过期
+func purgeTrash() {
+	before := time.Now().Add(-trashRetention())
+	total := 0
+	for {
+		mappings, err := model.ListExpiredTrash(before, trashPurgeBatch)
+		if err != nil {
+			logger.Log.Error("查询过期的回收站短链接失败", zap.Error(err))
+			return
+		}
+		if len(mappings) == 0 {
+			break
+		}
+		purged, err := model.PurgeMappings(mappings)
+		if err != nil {
+			logger.Log.Error("清理回收站失败", zap.Error(err))
+			return
+		}
+
+		ctx := context.Background()
+		rdb := cache.GetRedis()
+		ranked := make(map[string][]string)
+		for _, m := range purged {
+			key := m.Key()
+			member := click.Member(key, m.OriginalURL)
+			rdb.Del(ctx, cache.LinkKey(key), cache.PreviewKey(key), "click:"+member, click.VariantKey(key))
+			scope := click.OwnerRankScope(m.UserID, m.WorkspaceID)
+			ranked[scope] = append(ranked[scope], member)
+		}
+		for scope, members := range ranked {
+			if err := click.RemoveFromRanks(ctx, scope, members...); err != nil {
+				logger.Log.Warn("从排行榜中删除短链接失败", zap.String("scope", scope), zap.Error(err))
+			}
+		}
+		total += len(purged)
+
+		if len(mappings) < trashPurgeBatch {
+			break
+		}
+	}
+	if total > 0 {
+		logger.Log.Info("回收站清理完成", zap.Int("purged", total))
+	}
+}
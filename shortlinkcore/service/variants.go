@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"shortLink/proto/shortlinkpb"
+	"shortLink/shortlinkcore/cache"
+	"shortLink/shortlinkcore/logger"
+	"shortLink/shortlinkcore/model"
+	"shortLink/shortlinkcore/service/click"
+	"shortLink/shortlinkcore/service/routing"
+
+	"go.uber.org/zap"
+)
+
+// UpdateLinkVariants 更新短链接的 A/B 分流配置
+// 分组名称不变时保留其历史点击量，只调整权重不会清空实验数据
+func (s *ShortlinkService) UpdateLinkVariants(ctx context.Context, req *shortlinkpb.UpdateLinkVariantsRequest) (*shortlinkpb.UpdateLinkVariantsResponse, error) {
+	logger.Log.Info("收到更新分流配置请求",
+		zap.String("shortUrl", req.ShortUrl),
+		zap.String("userId", req.UserId),
+		zap.Int("variantCount", len(req.Variants)))
+
+	// 1. 校验分组
+	variants := routing.VariantsFromPB(req.Variants)
+	if err := routing.ValidateVariants(variants); err != nil {
+		return nil, fmt.Errorf("分流配置非法: %w", err)
+	}
+
+	// 2. 校验短链接归属
+	domain := requestDomain(req.Domain)
+	mapping, err := model.GetOwnedMapping(model.Owner{UserID: req.UserId, WorkspaceID: req.WorkspaceId}, domain, req.ShortUrl)
+	if err != nil {
+		logger.Log.Warn("短链接不存在或不属于该用户或工作区",
+			zap.String("shortUrl", req.ShortUrl),
+			zap.String("userId", req.UserId),
+			zap.Error(err))
+		return nil, fmt.Errorf("短链接不存在: %w", err)
+	}
+
+	// 3. 先更新数据库，再删除缓存
+	encoded, err := routing.EncodeVariants(variants)
+	if err != nil {
+		return nil, fmt.Errorf("分流配置序列化失败: %w", err)
+	}
+	if err := model.UpdateVariants(domain, req.ShortUrl, encoded); err != nil {
+		logger.Log.Error("更新分流配置失败", zap.String("shortUrl", req.ShortUrl), zap.Error(err))
+		return nil, fmt.Errorf("更新分流配置失败: %w", err)
+	}
+	cache.DelLink(mapping.Key())
+	emitLinkEvent(EventLinkUpdated, mapping, func(d *webhookLinkData) { d.Changes = []string{"variants"} })
+
+	logger.Log.Info("更新分流配置成功",
+		zap.String("shortUrl", req.ShortUrl),
+		zap.Int("variantCount", len(variants)))
+	return &shortlinkpb.UpdateLinkVariantsResponse{VariantCount: int32(len(variants))}, nil
+}
+
+// GetVariantStats 查询短链接各 A/B 分组的点击量
+func (s *ShortlinkService) GetVariantStats(ctx context.Context, req *shortlinkpb.GetVariantStatsRequest) (*shortlinkpb.GetVariantStatsResponse, error) {
+	logger.Log.Info("收到查询分组点击量请求",
+		zap.String("shortUrl", req.ShortUrl),
+		zap.String("userId", req.UserId))
+
+	// 1. 校验短链接归属
+	mapping, err := model.GetOwnedMapping(model.Owner{UserID: req.UserId, WorkspaceID: req.WorkspaceId}, requestDomain(req.Domain), req.ShortUrl)
+	if err != nil {
+		logger.Log.Warn("短链接不存在或不属于该用户或工作区",
+			zap.String("shortUrl", req.ShortUrl),
+			zap.String("userId", req.UserId),
+			zap.Error(err))
+		return nil, fmt.Errorf("短链接不存在: %w", err)
+	}
+
+	variants, err := routing.DecodeVariants(mapping.Variants)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 读取各分组点击量
+	clicks, err := click.GetVariantClicks(mapping.Key())
+	if err != nil {
+		return nil, fmt.Errorf("获取分组点击量失败: %w", err)
+	}
+
+	// 3. 当前分组按配置顺序返回，已被移除但仍有历史点击的分组排在后面
+	resp := &shortlinkpb.GetVariantStatsResponse{}
+	for _, v := range variants {
+		resp.Variants = append(resp.Variants, &shortlinkpb.VariantStat{
+			Name:      v.Name,
+			TargetUrl: v.TargetURL,
+			Weight:    int32(v.Weight),
+			Clicks:    clicks[v.Name],
+		})
+		resp.TotalClicks += clicks[v.Name]
+		delete(clicks, v.Name)
+	}
+	for name, n := range clicks {
+		resp.Variants = append(resp.Variants, &shortlinkpb.VariantStat{Name: name, Clicks: n})
+		resp.TotalClicks += n
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,590 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"shortLink/proto/shortlinkpb"
+	"shortLink/shortlinkcore/config"
+	"shortLink/shortlinkcore/logger"
+	"shortLink/shortlinkcore/model"
+	"shortLink/shortlinkcore/pkg/safehttp"
+	"shortLink/shortlinkcore/pkg/webhook"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// Webhook 事件
+const (
+	EventLinkCreated        = "link.created"
+	EventLinkUpdated        = "link.updated"
+	EventLinkDeleted        = "link.deleted"
+	EventLinkBlocked        = "link.blocked"         // 安全检查发现目标地址不安全
+	EventLinkClickThreshold = "link.click_threshold" // 点击量达到阈值
+)
+
+var webhookEvents = []string{EventLinkCreated, EventLinkUpdated, EventLinkDeleted, EventLinkBlocked, EventLinkClickThreshold}
+
+const (
+	maxWebhooksPerUser    = 10
+	maxWebhookURLLen      = 2048
+	webhookMaxAttempts    = 8                // 投递失败超过这个次数不再重试
+	webhookWorkers        = 4                // 每个实例同时投递的数量
+	webhookClaimBatch     = 20               // 每次接手的投递数量
+	webhookLease          = 2 * time.Minute  // 接手后的锁定时间，处理它的实例退出后过期即可被重新接手
+	webhookPollInterval   = 5 * time.Second  // 没有新事件时检查到期投递的间隔
+	webhookRequestTimeout = 10 * time.Second // 单次投递的超时时间
+	webhookUserAgent      = "ShortLink-Webhook/1.0"
+)
+
+// defaultClickThresholds 默认触发 link.click_threshold 事件的点击量
+var defaultClickThresholds = []int64{100, 1000, 10000, 100000, 1000000}
+
+var (
+	// webhookClient 投递使用的 HTTP 客户端，不允许访问内网地址
+	webhookClient = safehttp.New(safehttp.Options{Timeout: webhookRequestTimeout, MaxBodySize: 64 << 10})
+	// webhookWakeup 产生新事件后唤醒空闲的投递协程
+	webhookWakeup = make(chan struct{}, 1)
+	webhookCancel context.CancelFunc
+	webhookWG     sync.WaitGroup
+	// webhookOwner 本实例接手投递时使用的标识
+	webhookOwner string
+)
+
+// webhookPayload 投递的请求体
+type webhookPayload struct {
+	ID        string          `json:"id"` // 事件ID，重试和重放时不变，接收方可用于去重
+	Event     string          `json:"event"`
+	CreatedAt int64           `json:"created_at"`
+	Data      webhookLinkData `json:"data"`
+}
+
+// webhookLinkData 事件中的短链接信息
+type webhookLinkData struct {
+	ShortURL    string   `json:"short_url"`
+	Domain      string   `json:"domain"`
+	FullURL     string   `json:"full_url"`
+	OriginalURL string   `json:"original_url"`
+	WorkspaceID string   `json:"workspace_id,omitempty"`
+	Status      string   `json:"status,omitempty"`
+	Changes     []string `json:"changes,omitempty"`      // link.updated：修改的字段
+	PreviousURL string   `json:"previous_url,omitempty"` // link.updated：修改前的目标地址
+	BlockReason string   `json:"block_reason,omitempty"` // link.blocked：威胁类型
+	Clicks      int64    `json:"clicks,omitempty"`       // link.click_threshold：达到的点击量
+}
+
+// newWebhookLinkData 根据短链接生成事件数据
+func newWebhookLinkData(m *model.URLMapping) webhookLinkData {
+	return webhookLinkData{
+		ShortURL:    m.ShortURL,
+		Domain:      m.Domain,
+		FullURL:     FullURL(m.Domain, m.ShortURL),
+		OriginalURL: m.OriginalURL,
+		WorkspaceID: m.WorkspaceID,
+		Status:      m.Status,
+	}
+}
+
+// emitLinkEvents 为用户订阅了该事件的 Webhook 生成待投递的事件，每个短链接一个事件
+// 事件先写入数据库再由后台协程投递，写入失败只记录日志，不影响触发事件的操作
+func emitLinkEvents(userID, event string, links []webhookLinkData) {
+	if userID == "" || len(links) == 0 {
+		return
+	}
+	hooks, err := model.ListSubscribedWebhooks(userID, event)
+	if err != nil {
+		logger.Log.Error("查询订阅的 Webhook 失败", zap.String("userId", userID), zap.String("event", event), zap.Error(err))
+		return
+	}
+	if len(hooks) == 0 {
+		return
+	}
+
+	now := time.Now()
+	deliveries := make([]model.WebhookDelivery, 0, len(hooks)*len(links))
+	for _, link := range links {
+		payload := webhookPayload{ID: uuid.NewString(), Event: event, CreatedAt: now.Unix(), Data: link}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			logger.Log.Error("序列化 Webhook 事件失败", zap.String("event", event), zap.Error(err))
+			continue
+		}
+		for _, hook := range hooks {
+			deliveries = append(deliveries, model.WebhookDelivery{
+				ID:            uuid.NewString(),
+				WebhookID:     hook.ID,
+				EventID:       payload.ID,
+				Event:         event,
+				Payload:       string(body),
+				Status:        model.WebhookDeliveryPending,
+				NextAttemptAt: now,
+			})
+		}
+	}
+	if err := model.CreateWebhookDeliveries(deliveries); err != nil {
+		logger.Log.Error("保存 Webhook 事件失败",
+			zap.String("userId", userID),
+			zap.String("event", event),
+			zap.Int("count", len(deliveries)),
+			zap.Error(err))
+		return
+	}
+	logger.Log.Debug("已生成 Webhook 事件",
+		zap.String("userId", userID),
+		zap.String("event", event),
+		zap.Int("count", len(deliveries)))
+	wakeWebhookWorkers()
+}
+
+// emitLinkEvent 为单个短链接生成事件
+func emitLinkEvent(event string, m *model.URLMapping, fill func(*webhookLinkData)) {
+	data := newWebhookLinkData(m)
+	if fill != nil {
+		fill(&data)
+	}
+	emitLinkEvents(m.UserID, event, []webhookLinkData{data})
+}
+
+// clickThresholds 触发 link.click_threshold 事件的点击量
+func clickThresholds() []int64 {
+	if t := config.GlobalConfig.App.WebhookClickThresholds; len(t) > 0 {
+		return t
+	}
+	return defaultClickThresholds
+}
+
+// notifyClickThreshold 点击量正好达到阈值时生成 link.click_threshold 事件
+// 点击量由 Redis 原子自增，每个阈值只会有一次点击命中
+func notifyClickThreshold(m *model.URLMapping, clicks int64) {
+	if clicks <= 0 {
+		return
+	}
+	for _, threshold := range clickThresholds() {
+		if clicks == threshold {
+			emitLinkEvent(EventLinkClickThreshold, m, func(d *webhookLinkData) { d.Clicks = clicks })
+			return
+		}
+	}
+}
+
+// wakeWebhookWorkers 通知空闲的投递协程有新事件
+func wakeWebhookWorkers() {
+	select {
+	case webhookWakeup <- struct{}{}:
+	default:
+	}
+}
+
+// StartWebhookWorkers 启动后台投递 Webhook 事件的协程
+// 启动时会接手到期的投递，以及其他实例退出时没有放回的投递（锁定过期后）
+func StartWebhookWorkers() {
+	var ctx context.Context
+	ctx, webhookCancel = context.WithCancel(context.Background())
+	webhookOwner = uuid.NewString()
+	webhookWG.Add(1)
+	go func() {
+		defer webhookWG.Done()
+		webhookDispatcher(ctx)
+	}()
+	logger.Log.Info("Webhook 投递协程已启动", zap.Int("workers", webhookWorkers))
+}
+
+// StopWebhookWorkers 停止投递，正在投递的事件放回队列，由其他实例或重启后继续
+func StopWebhookWorkers() {
+	if webhookCancel == nil {
+		return
+	}
+	webhookCancel()
+	webhookWG.Wait()
+	if err := model.ReleaseWebhookDeliveries(webhookOwner); err != nil {
+		logger.Log.Error("放回 Webhook 投递失败", zap.Error(err))
+	}
+}
+
+func webhookDispatcher(ctx context.Context) {
+	ticker := time.NewTicker(webhookPollInterval)
+	defer ticker.Stop()
+	for {
+		// 一直投递到没有到期的事件
+		for runDueWebhookDeliveries(ctx) {
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-webhookWakeup:
+		case <-ticker.C:
+		}
+	}
+}
+
+// runDueWebhookDeliveries 接手并投递一批到期的事件，接手数量不足一批时返回 false
+func runDueWebhookDeliveries(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	now := time.Now()
+	deliveries, err := model.ClaimDueWebhookDeliveries(webhookOwner, now, now.Add(webhookLease), webhookClaimBatch)
+	if err != nil {
+		logger.Log.Error("接手 Webhook 投递失败", zap.Error(err))
+		return false
+	}
+
+	sem := make(chan struct{}, webhookWorkers)
+	var wg sync.WaitGroup
+	for i := range deliveries {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(d *model.WebhookDelivery) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			deliverWebhook(ctx, d)
+		}(&deliveries[i])
+	}
+	wg.Wait()
+	return len(deliveries) >= webhookClaimBatch
+}
+
+// deliverWebhook 投递一次事件并保存结果，失败时按指数退避安排下次投递
+func deliverWebhook(ctx context.Context, d *model.WebhookDelivery) {
+	hook, err := model.GetWebhookByID(d.WebhookID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		d.Status = model.WebhookDeliveryFailed
+		d.LastError = "Webhook 已删除"
+		saveWebhookAttempt(d)
+		return
+	}
+	if err != nil {
+		// 保持锁定，过期后重新接手
+		logger.Log.Error("查询 Webhook 失败", zap.String("webhookId", d.WebhookID), zap.Error(err))
+		return
+	}
+
+	code, err := postWebhook(ctx, hook, d)
+	if ctx.Err() != nil {
+		// 服务退出中断的投递不计入次数
+		return
+	}
+	now := time.Now()
+	d.Attempts++
+	d.ResponseCode = code
+	if err == nil {
+		d.Status = model.WebhookDeliverySucceeded
+		d.LastError = ""
+		d.DeliveredAt = &now
+	} else {
+		d.LastError = truncateError(err.Error(), 1000)
+		if d.Attempts >= webhookMaxAttempts {
+			d.Status = model.WebhookDeliveryFailed
+		} else {
+			d.NextAttemptAt = now.Add(webhook.Backoff(d.Attempts))
+		}
+		logger.Log.Warn("Webhook 投递失败",
+			zap.String("deliveryId", d.ID),
+			zap.String("webhookId", d.WebhookID),
+			zap.Int("attempts", d.Attempts),
+			zap.Int("responseCode", code),
+			zap.Error(err))
+	}
+	saveWebhookAttempt(d)
+}
+
+func saveWebhookAttempt(d *model.WebhookDelivery) {
+	if err := model.SaveWebhookAttempt(d, webhookOwner); err != nil {
+		logger.Log.Error("保存 Webhook 投递结果失败", zap.String("deliveryId", d.ID), zap.Error(err))
+	}
+}
+
+// postWebhook 发送签名的事件请求，返回响应状态码，非 2xx 视为失败
+func postWebhook(ctx context.Context, hook *model.Webhook, d *model.WebhookDelivery) (int, error) {
+	body := []byte(d.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	ts := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", webhookUserAgent)
+	req.Header.Set(webhook.HeaderEvent, d.Event)
+	req.Header.Set(webhook.HeaderDelivery, d.ID)
+	req.Header.Set(webhook.HeaderTimestamp, strconv.FormatInt(ts, 10))
+	req.Header.Set(webhook.HeaderSignature, webhook.Sign(hook.Secret, ts, body))
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// 读完响应体以复用连接，超过上限的部分不再读取
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("响应状态码 %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// truncateError 按字节截断错误信息，不截断多字节字符
+func truncateError(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// normalizeWebhookEvents 校验并去重订阅的事件
+func normalizeWebhookEvents(events []string) ([]string, error) {
+	if len(events) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "至少需要订阅一个事件")
+	}
+	seen := make(map[string]bool, len(events))
+	result := make([]string, 0, len(events))
+	for _, event := range events {
+		event = strings.TrimSpace(event)
+		valid := false
+		for _, e := range webhookEvents {
+			if e == event {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, status.Errorf(codes.InvalidArgument, "不支持的事件: %s", event)
+		}
+		if !seen[event] {
+			seen[event] = true
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+// validateWebhookURL 校验回调地址，内网地址在投递时由 safehttp 拒绝
+func validateWebhookURL(raw string) error {
+	if raw == "" || len(raw) > maxWebhookURLLen {
+		return status.Error(codes.InvalidArgument, "回调地址非法")
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return status.Error(codes.InvalidArgument, "回调地址必须是 http/https 地址")
+	}
+	return nil
+}
+
+// newWebhookSecret 生成签名密钥
+func newWebhookSecret() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
+
+// CreateWebhook 登记 Webhook，签名密钥只在创建时返回
+func (s *ShortlinkService) CreateWebhook(ctx context.Context, req *shortlinkpb.CreateWebhookRequest) (*shortlinkpb.CreateWebhookResponse, error) {
+	logger.Log.Info("收到创建 Webhook 请求", zap.String("userId", req.UserId), zap.String("url", req.Url))
+
+	if err := validateWebhookURL(req.Url); err != nil {
+		return nil, err
+	}
+	events, err := normalizeWebhookEvents(req.Events)
+	if err != nil {
+		return nil, err
+	}
+	count, err := model.CountWebhooks(req.UserId)
+	if err != nil {
+		logger.Log.Error("创建 Webhook 失败：统计数量错误", zap.String("userId", req.UserId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "创建 Webhook 失败")
+	}
+	if count >= maxWebhooksPerUser {
+		return nil, status.Errorf(codes.FailedPrecondition, "每个用户最多创建%d个 Webhook", maxWebhooksPerUser)
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		logger.Log.Error("创建 Webhook 失败：生成密钥错误", zap.Error(err))
+		return nil, status.Error(codes.Internal, "创建 Webhook 失败")
+	}
+	hook := &model.Webhook{
+		ID:     uuid.NewString(),
+		UserID: req.UserId,
+		URL:    req.Url,
+		Secret: secret,
+		Events: strings.Join(events, ","),
+	}
+	if err := model.CreateWebhook(hook); err != nil {
+		logger.Log.Error("创建 Webhook 失败", zap.String("userId", req.UserId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "创建 Webhook 失败")
+	}
+
+	logger.Log.Info("创建 Webhook 成功",
+		zap.String("userId", req.UserId),
+		zap.String("webhookId", hook.ID),
+		zap.Strings("events", events))
+	return &shortlinkpb.CreateWebhookResponse{Webhook: webhookInfo(hook), Secret: secret}, nil
+}
+
+// ListWebhooks 查询用户的 Webhook
+func (s *ShortlinkService) ListWebhooks(ctx context.Context, req *shortlinkpb.ListWebhooksRequest) (*shortlinkpb.ListWebhooksResponse, error) {
+	hooks, err := model.ListWebhooks(req.UserId)
+	if err != nil {
+		logger.Log.Error("查询 Webhook 失败", zap.String("userId", req.UserId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "查询 Webhook 失败")
+	}
+	resp := &shortlinkpb.ListWebhooksResponse{Webhooks: make([]*shortlinkpb.WebhookInfo, 0, len(hooks))}
+	for i := range hooks {
+		resp.Webhooks = append(resp.Webhooks, webhookInfo(&hooks[i]))
+	}
+	return resp, nil
+}
+
+// DeleteWebhook 删除 Webhook，投递日志保留，尚未投递的事件在投递时标记为失败
+func (s *ShortlinkService) DeleteWebhook(ctx context.Context, req *shortlinkpb.DeleteWebhookRequest) (*shortlinkpb.DeleteWebhookResponse, error) {
+	deleted, err := model.DeleteWebhook(req.UserId, req.WebhookId)
+	if err != nil {
+		logger.Log.Error("删除 Webhook 失败", zap.String("userId", req.UserId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "删除 Webhook 失败")
+	}
+	if !deleted {
+		return nil, status.Error(codes.NotFound, "Webhook 不存在")
+	}
+	logger.Log.Info("删除 Webhook 成功", zap.String("userId", req.UserId), zap.String("webhookId", req.WebhookId))
+	return &shortlinkpb.DeleteWebhookResponse{Message: "删除成功"}, nil
+}
+
+// ListWebhookDeliveries 分页查询 Webhook 的投递日志
+func (s *ShortlinkService) ListWebhookDeliveries(ctx context.Context, req *shortlinkpb.ListWebhookDeliveriesRequest) (*shortlinkpb.ListWebhookDeliveriesResponse, error) {
+	if _, err := ownedWebhook(req.UserId, req.WebhookId); err != nil {
+		return nil, err
+	}
+	switch req.Status {
+	case "", model.WebhookDeliveryPending, model.WebhookDeliverySucceeded, model.WebhookDeliveryFailed:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "不支持的投递状态: %s", req.Status)
+	}
+	page, pageSize := int(req.Page), int(req.PageSize)
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	deliveries, total, err := model.ListWebhookDeliveries(req.WebhookId, req.Status, (page-1)*pageSize, pageSize)
+	if err != nil {
+		logger.Log.Error("查询 Webhook 投递日志失败", zap.String("webhookId", req.WebhookId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "查询投递日志失败")
+	}
+	resp := &shortlinkpb.ListWebhookDeliveriesResponse{Total: total, Deliveries: make([]*shortlinkpb.WebhookDeliveryInfo, 0, len(deliveries))}
+	for i := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, webhookDeliveryInfo(&deliveries[i]))
+	}
+	return resp, nil
+}
+
+// ReplayWebhookDelivery 重新投递一次事件，生成新的投递记录，事件ID和请求体不变
+func (s *ShortlinkService) ReplayWebhookDelivery(ctx context.Context, req *shortlinkpb.ReplayWebhookDeliveryRequest) (*shortlinkpb.ReplayWebhookDeliveryResponse, error) {
+	if _, err := ownedWebhook(req.UserId, req.WebhookId); err != nil {
+		return nil, err
+	}
+	original, err := model.GetWebhookDelivery(req.WebhookId, req.DeliveryId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.NotFound, "投递记录不存在")
+	}
+	if err != nil {
+		logger.Log.Error("查询 Webhook 投递失败", zap.String("deliveryId", req.DeliveryId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "重放失败")
+	}
+
+	replay := model.WebhookDelivery{
+		ID:            uuid.NewString(),
+		WebhookID:     original.WebhookID,
+		EventID:       original.EventID,
+		Event:         original.Event,
+		Payload:       original.Payload,
+		Status:        model.WebhookDeliveryPending,
+		NextAttemptAt: time.Now(),
+		ReplayOf:      original.ID,
+	}
+	if err := model.CreateWebhookDeliveries([]model.WebhookDelivery{replay}); err != nil {
+		logger.Log.Error("保存重放的 Webhook 投递失败", zap.String("deliveryId", req.DeliveryId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "重放失败")
+	}
+	wakeWebhookWorkers()
+
+	logger.Log.Info("已重放 Webhook 投递",
+		zap.String("webhookId", req.WebhookId),
+		zap.String("deliveryId", original.ID),
+		zap.String("replayId", replay.ID))
+	return &shortlinkpb.ReplayWebhookDeliveryResponse{Delivery: webhookDeliveryInfo(&replay)}, nil
+}
+
+// ownedWebhook 获取用户的 Webhook，不存在或不属于该用户时返回 NotFound
+func ownedWebhook(userID, id string) (*model.Webhook, error) {
+	hook, err := model.GetWebhook(userID, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.NotFound, "Webhook 不存在")
+	}
+	if err != nil {
+		logger.Log.Error("查询 Webhook 失败", zap.String("webhookId", id), zap.Error(err))
+		return nil, status.Error(codes.Internal, "查询 Webhook 失败")
+	}
+	return hook, nil
+}
+
+// webhookInfo 转换为不包含签名密钥的 Webhook 信息
+func webhookInfo(h *model.Webhook) *shortlinkpb.WebhookInfo {
+	var events []string
+	if h.Events != "" {
+		events = strings.Split(h.Events, ",")
+	}
+	return &shortlinkpb.WebhookInfo{
+		Id:         h.ID,
+		Url:        h.URL,
+		Events:     events,
+		CreateTime: h.CreateTime.Unix(),
+	}
+}
+
+func webhookDeliveryInfo(d *model.WebhookDelivery) *shortlinkpb.WebhookDeliveryInfo {
+	info := &shortlinkpb.WebhookDeliveryInfo{
+		Id:           d.ID,
+		WebhookId:    d.WebhookID,
+		EventId:      d.EventID,
+		Event:        d.Event,
+		Payload:      d.Payload,
+		Status:       d.Status,
+		Attempts:     int32(d.Attempts),
+		ResponseCode: int32(d.ResponseCode),
+		LastError:    d.LastError,
+		ReplayOf:     d.ReplayOf,
+		CreateTime:   d.CreateTime.Unix(),
+	}
+	if d.Status == model.WebhookDeliveryPending {
+		info.NextAttemptAt = d.NextAttemptAt.Unix()
+	}
+	if d.DeliveredAt != nil {
+		info.DeliveredAt = d.DeliveredAt.Unix()
+	}
+	return info
+}
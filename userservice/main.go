@@ -13,9 +13,11 @@ import (
 	"shortLink/userservice/pkg/discovery"
 	"shortLink/userservice/repository"
 	"shortLink/userservice/scripts"
+	"shortLink/userservice/service/apikey"
 	"shortLink/userservice/service/auth"
 	"shortLink/userservice/service/rbac"
 	"shortLink/userservice/service/user"
+	"shortLink/userservice/service/workspace"
 	"syscall"
 	"time"
 
@@ -59,6 +61,8 @@ func main() {
 	// 2. 创建存储库
 	userRepository := repository.NewGormUserRepository(db)
 	rbacRepository := repository.NewGormRBACRepository(db)
+	workspaceRepository := repository.NewGormWorkspaceRepository(db)
+	apiKeyRepository := repository.NewGormAPIKeyRepository(db)
 
 	// 3. 创建认证服务
 	authService := auth.NewDefaultAuthService(redisCache)
@@ -69,12 +73,20 @@ func main() {
 	// 5. 创建RBAC服务
 	rbacService := rbac.NewRBACService(rbacRepository, rbacCache)
 
+	// 6. 创建工作区服务
+	workspaceService := workspace.NewWorkspaceService(workspaceRepository, userRepository)
+
+	// 7. 创建 API Key 服务
+	apiKeyService := apikey.NewAPIKeyService(apiKeyRepository, userRepository)
+
 	// 创建gRPC服务器
 	grpcServer := grpc.NewServer()
 
 	// 注册服务
 	pb.RegisterUserServiceServer(grpcServer, userService)
 	pb.RegisterRBACServiceServer(grpcServer, rbacService)
+	pb.RegisterWorkspaceServiceServer(grpcServer, workspaceService)
+	pb.RegisterAPIKeyServiceServer(grpcServer, apiKeyService)
 
 	// 启动gRPC服务器
 	lis, err := net.Listen("tcp", ":8081")
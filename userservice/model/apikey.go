@@ -0,0 +1,56 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// API Key 的权限范围
+const (
+	ScopeLinksWrite    = "links:write"
+	ScopeLinksRead     = "links:read"
+	ScopeAnalyticsRead = "analytics:read"
+)
+
+// validScopes 支持的权限范围
+var validScopes = map[string]bool{
+	ScopeLinksWrite:    true,
+	ScopeLinksRead:     true,
+	ScopeAnalyticsRead: true,
+}
+
+// IsValidScope 是否为支持的权限范围
+func IsValidScope(scope string) bool {
+	return validScopes[scope]
+}
+
+// APIKey 用户创建的 API Key，用于脚本和 CI 调用接口
+// 只保存 Key 的哈希，前缀明文保存用于在列表中识别和验证时查找
+type APIKey struct {
+	ID         uint       `gorm:"primarykey" json:"id"`
+	UserID     uint       `gorm:"index;not null" json:"user_id"`
+	Name       string     `gorm:"size:100" json:"name"`
+	Prefix     string     `gorm:"uniqueIndex;size:16;not null" json:"prefix"`
+	KeyHash    string     `gorm:"size:64;not null" json:"-"`
+	Scopes     string     `gorm:"size:255" json:"scopes"` // 逗号分隔
+	ExpiresAt  *time.Time `json:"expires_at"`             // 为空表示永不过期
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// ScopeList 返回 Key 的权限范围列表
+func (k *APIKey) ScopeList() []string {
+	if k.Scopes == "" {
+		return nil
+	}
+	return strings.Split(k.Scopes, ",")
+}
+
+// Expired Key 是否已过期
+func (k *APIKey) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
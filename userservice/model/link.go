@@ -17,7 +17,7 @@ func InitDB(dataSource string) error {
 	var err error
 	db, err = gorm.Open(mysql.Open(dataSource), &gorm.Config{})
 	// 自动建表自动递归创建 User 模型中关联的所有表结构（包括多对多中间表）
-	_ = db.AutoMigrate(&User{}, &Role{}, &Permission{}, &UserRole{}, &RolePermission{})
+	_ = db.AutoMigrate(&User{}, &Role{}, &Permission{}, &UserRole{}, &RolePermission{}, &Workspace{}, &WorkspaceMember{}, &APIKey{})
 	return err
 }
 
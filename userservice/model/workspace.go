@@ -0,0 +1,77 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 工作区内的角色
+const (
+	WorkspaceOwner  = "owner"
+	WorkspaceEditor = "editor"
+	WorkspaceViewer = "viewer"
+)
+
+// Workspace 工作区，成员共享工作区下的短链接
+type Workspace struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	Name      string         `gorm:"size:100;not null" json:"name"`
+	CreatedBy uint           `json:"created_by"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+}
+
+// WorkspaceMember 工作区成员及其角色，一个用户可以加入多个工作区
+type WorkspaceMember struct {
+	WorkspaceID uint      `gorm:"primarykey" json:"workspace_id"`
+	UserID      uint      `gorm:"primarykey;index" json:"user_id"`
+	Role        string    `gorm:"size:20;not null" json:"role"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// WorkspaceWithRole 用户所在的工作区及其角色
+type WorkspaceWithRole struct {
+	Workspace
+	Role string
+}
+
+// WorkspaceMemberInfo 工作区成员及其用户信息
+type WorkspaceMemberInfo struct {
+	WorkspaceMember
+	Username string
+	Nickname string
+}
+
+// workspaceRolePermissions 工作区角色拥有的权限，格式为 resource:action
+var workspaceRolePermissions = map[string][]string{
+	WorkspaceOwner: {
+		"link:create", "link:read", "link:update", "link:delete",
+		"analytics:read", "workspace:read", "workspace:manage",
+	},
+	WorkspaceEditor: {
+		"link:create", "link:read", "link:update", "link:delete",
+		"analytics:read", "workspace:read",
+	},
+	WorkspaceViewer: {
+		"link:read", "analytics:read", "workspace:read",
+	},
+}
+
+// IsWorkspaceRole 是否为有效的工作区角色
+func IsWorkspaceRole(role string) bool {
+	_, ok := workspaceRolePermissions[role]
+	return ok
+}
+
+// WorkspaceRoleAllows 工作区角色是否拥有指定的权限
+func WorkspaceRoleAllows(role, resource, action string) bool {
+	want := resource + ":" + action
+	for _, p := range workspaceRolePermissions[role] {
+		if p == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+// Package apikey 生成和解析 API Key
+// 格式为 slk_<前缀>_<密钥>，前缀明文保存用于识别和查找，完整的 Key 只保存哈希
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+const (
+	keyTag       = "slk"
+	prefixLength = 8
+	secretLength = 32
+	charset      = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// ErrMalformed Key 格式不正确
+var ErrMalformed = errors.New("API Key 格式不正确")
+
+// Generate 生成新的 API Key，返回完整的 Key 和用于识别的前缀
+func Generate() (key, prefix string, err error) {
+	prefix, err = randomString(prefixLength)
+	if err != nil {
+		return "", "", err
+	}
+	secret, err := randomString(secretLength)
+	if err != nil {
+		return "", "", err
+	}
+	return keyTag + "_" + prefix + "_" + secret, prefix, nil
+}
+
+// Parse 从完整的 Key 中取出前缀
+func Parse(key string) (prefix string, err error) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 || parts[0] != keyTag || len(parts[1]) != prefixLength || len(parts[2]) != secretLength {
+		return "", ErrMalformed
+	}
+	return parts[1], nil
+}
+
+// Hash 计算 Key 的哈希，Key 本身是高熵随机串，不需要加盐和慢哈希
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify 以固定时间比较 Key 与保存的哈希
+func Verify(key, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(Hash(key)), []byte(hash)) == 1
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	max := big.NewInt(int64(len(charset)))
+	for i := range b {
+		idx, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = charset[idx.Int64()]
+	}
+	return string(b), nil
+}
@@ -0,0 +1,41 @@
+package apikey
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	key, prefix, err := Generate()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(key, "slk_"+prefix+"_"))
+
+	parsed, err := Parse(key)
+	assert.NoError(t, err)
+	assert.Equal(t, prefix, parsed)
+
+	other, _, err := Generate()
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
+
+func TestParse(t *testing.T) {
+	t.Run("格式错误", func(t *testing.T) {
+		for _, key := range []string{"", "slk_abc", "abc_12345678_" + strings.Repeat("x", 32), "slk_1234567_" + strings.Repeat("x", 32)} {
+			_, err := Parse(key)
+			assert.ErrorIs(t, err, ErrMalformed, key)
+		}
+	})
+}
+
+func TestVerify(t *testing.T) {
+	key, _, err := Generate()
+	assert.NoError(t, err)
+	hash := Hash(key)
+
+	assert.True(t, Verify(key, hash))
+	assert.False(t, Verify(key+"x", hash))
+	assert.Len(t, hash, 64)
+}
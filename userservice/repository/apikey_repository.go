@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"shortLink/userservice/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APIKeyRepository 定义 API Key 数据访问接口
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *model.APIKey) error
+	ListByUser(ctx context.Context, userID uint) ([]model.APIKey, error)
+	FindByPrefix(ctx context.Context, prefix string) (*model.APIKey, error)
+	Delete(ctx context.Context, userID, id uint) error
+	TouchLastUsed(ctx context.Context, id uint, at time.Time) error
+}
+
+// GormAPIKeyRepository 实现基于Gorm的 API Key 数据访问
+type GormAPIKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAPIKeyRepository 创建一个新的GormAPIKeyRepository实例
+func NewGormAPIKeyRepository(db *gorm.DB) *GormAPIKeyRepository {
+	return &GormAPIKeyRepository{db: db}
+}
+
+// Create 保存新的 API Key
+func (r *GormAPIKeyRepository) Create(ctx context.Context, key *model.APIKey) error {
+	return r.db.Create(key).Error
+}
+
+// ListByUser 获取用户的所有 API Key，最新创建的在前
+func (r *GormAPIKeyRepository) ListByUser(ctx context.Context, userID uint) ([]model.APIKey, error) {
+	var keys []model.APIKey
+	err := r.db.Where("user_id = ?", userID).Order("id DESC").Find(&keys).Error
+	return keys, err
+}
+
+// FindByPrefix 按前缀查找 API Key
+func (r *GormAPIKeyRepository) FindByPrefix(ctx context.Context, prefix string) (*model.APIKey, error) {
+	var key model.APIKey
+	if err := r.db.Where("prefix = ?", prefix).First(&key).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// Delete 删除用户的 API Key，不存在时返回 gorm.ErrRecordNotFound
+func (r *GormAPIKeyRepository) Delete(ctx context.Context, userID, id uint) error {
+	result := r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&model.APIKey{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// TouchLastUsed 更新最近使用时间
+func (r *GormAPIKeyRepository) TouchLastUsed(ctx context.Context, id uint, at time.Time) error {
+	return r.db.Model(&model.APIKey{}).Where("id = ?", id).Update("last_used_at", at).Error
+}
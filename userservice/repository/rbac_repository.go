@@ -18,6 +18,7 @@ type RBACRepository interface {
 	AssignRoleToUser(ctx context.Context, userID, roleID uint) error
 	AssignPermissionToRole(ctx context.Context, roleID, permissionID uint) error
 	HasPermission(ctx context.Context, userID uint, resource, action string) (bool, error)
+	GetWorkspaceRole(ctx context.Context, workspaceID, userID uint) (string, error)
 }
 
 // GormRBACRepository 实现基于Gorm的RBAC数据访问
@@ -116,3 +117,8 @@ func (r *GormRBACRepository) HasPermission(ctx context.Context, userID uint, req
 
 	return false, nil
 }
+
+// GetWorkspaceRole 获取用户在工作区中的角色，不是成员时返回 gorm.ErrRecordNotFound
+func (r *GormRBACRepository) GetWorkspaceRole(ctx context.Context, workspaceID, userID uint) (string, error) {
+	return NewGormWorkspaceRepository(r.db).GetMemberRole(ctx, workspaceID, userID)
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"shortLink/userservice/model"
+
+	"gorm.io/gorm"
+)
+
+// WorkspaceRepository 定义工作区数据访问接口
+type WorkspaceRepository interface {
+	CreateWorkspace(ctx context.Context, workspace *model.Workspace, ownerID uint) error
+	ListUserWorkspaces(ctx context.Context, userID uint) ([]model.WorkspaceWithRole, error)
+	GetMemberRole(ctx context.Context, workspaceID, userID uint) (string, error)
+	ListMembers(ctx context.Context, workspaceID uint) ([]model.WorkspaceMemberInfo, error)
+	AddMember(ctx context.Context, member *model.WorkspaceMember) error
+	UpdateMemberRole(ctx context.Context, workspaceID, userID uint, role string) error
+	RemoveMember(ctx context.Context, workspaceID, userID uint) error
+	CountOwners(ctx context.Context, workspaceID uint) (int64, error)
+}
+
+// GormWorkspaceRepository 实现基于Gorm的工作区数据访问
+type GormWorkspaceRepository struct {
+	db *gorm.DB
+}
+
+// NewGormWorkspaceRepository 创建一个新的GormWorkspaceRepository实例
+func NewGormWorkspaceRepository(db *gorm.DB) *GormWorkspaceRepository {
+	return &GormWorkspaceRepository{db: db}
+}
+
+// CreateWorkspace 创建工作区，创建者成为所有者
+func (r *GormWorkspaceRepository) CreateWorkspace(ctx context.Context, workspace *model.Workspace, ownerID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(workspace).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model.WorkspaceMember{
+			WorkspaceID: workspace.ID,
+			UserID:      ownerID,
+			Role:        model.WorkspaceOwner,
+		}).Error
+	})
+}
+
+// ListUserWorkspaces 获取用户加入的所有工作区
+func (r *GormWorkspaceRepository) ListUserWorkspaces(ctx context.Context, userID uint) ([]model.WorkspaceWithRole, error) {
+	var workspaces []model.WorkspaceWithRole
+	err := r.db.Model(&model.Workspace{}).
+		Select("workspaces.*, workspace_members.role").
+		Joins("JOIN workspace_members ON workspace_members.workspace_id = workspaces.id").
+		Where("workspace_members.user_id = ?", userID).
+		Order("workspaces.id").
+		Scan(&workspaces).Error
+	return workspaces, err
+}
+
+// GetMemberRole 获取用户在工作区中的角色，不是成员时返回 gorm.ErrRecordNotFound
+func (r *GormWorkspaceRepository) GetMemberRole(ctx context.Context, workspaceID, userID uint) (string, error) {
+	var member model.WorkspaceMember
+	err := r.db.Joins("JOIN workspaces ON workspaces.id = workspace_members.workspace_id AND workspaces.deleted_at IS NULL").
+		Where("workspace_members.workspace_id = ? AND workspace_members.user_id = ?", workspaceID, userID).
+		First(&member).Error
+	if err != nil {
+		return "", err
+	}
+	return member.Role, nil
+}
+
+// ListMembers 获取工作区的所有成员
+func (r *GormWorkspaceRepository) ListMembers(ctx context.Context, workspaceID uint) ([]model.WorkspaceMemberInfo, error) {
+	var members []model.WorkspaceMemberInfo
+	err := r.db.Model(&model.WorkspaceMember{}).
+		Select("workspace_members.*, users.username, users.nickname").
+		Joins("JOIN users ON users.id = workspace_members.user_id").
+		Where("workspace_members.workspace_id = ?", workspaceID).
+		Order("workspace_members.created_at").
+		Scan(&members).Error
+	return members, err
+}
+
+// AddMember 添加工作区成员
+func (r *GormWorkspaceRepository) AddMember(ctx context.Context, member *model.WorkspaceMember) error {
+	return r.db.Create(member).Error
+}
+
+// UpdateMemberRole 修改成员的角色
+func (r *GormWorkspaceRepository) UpdateMemberRole(ctx context.Context, workspaceID, userID uint, role string) error {
+	result := r.db.Model(&model.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+		Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// RemoveMember 移除工作区成员
+func (r *GormWorkspaceRepository) RemoveMember(ctx context.Context, workspaceID, userID uint) error {
+	result := r.db.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).Delete(&model.WorkspaceMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CountOwners 统计工作区的所有者数量，工作区至少保留一个所有者
+func (r *GormWorkspaceRepository) CountOwners(ctx context.Context, workspaceID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.WorkspaceMember{}).
+		Where("workspace_id = ? AND role = ?", workspaceID, model.WorkspaceOwner).
+		Count(&count).Error
+	return count, err
+}
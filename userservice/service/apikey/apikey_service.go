@@ -0,0 +1,211 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"shortLink/proto/userpb"
+	"shortLink/userservice/logger"
+	"shortLink/userservice/model"
+	keyutil "shortLink/userservice/pkg/apikey"
+	"shortLink/userservice/repository"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+const (
+	maxKeysPerUser   = 20
+	maxKeyNameLen    = 100
+	maxExpiresInDays = 3650
+	// 最近使用时间的精度，间隔内重复使用不再写数据库
+	lastUsedInterval = time.Minute
+)
+
+// APIKeyService 实现 API Key 相关的gRPC服务
+type APIKeyService struct {
+	userpb.UnimplementedAPIKeyServiceServer
+	apiKeyRepo     repository.APIKeyRepository
+	userRepository repository.UserRepository
+}
+
+// NewAPIKeyService 创建 API Key 服务实例
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository, userRepository repository.UserRepository) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepo:     apiKeyRepo,
+		userRepository: userRepository,
+	}
+}
+
+// CreateAPIKey 创建 API Key，完整的 Key 只在此时返回一次
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, req *userpb.CreateAPIKeyRequest) (*userpb.CreateAPIKeyResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "名称不能为空")
+	}
+	if len([]rune(name)) > maxKeyNameLen {
+		return nil, status.Errorf(codes.InvalidArgument, "名称不能超过%d个字符", maxKeyNameLen)
+	}
+	scopes, err := normalizeScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	if req.ExpiresInDays < 0 || req.ExpiresInDays > maxExpiresInDays {
+		return nil, status.Errorf(codes.InvalidArgument, "有效天数必须在0到%d之间", maxExpiresInDays)
+	}
+
+	existing, err := s.apiKeyRepo.ListByUser(ctx, uint(req.UserId))
+	if err != nil {
+		logger.Log.Error("创建 API Key 失败：查询已有的 Key 错误", zap.Uint32("user_id", req.UserId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "创建 API Key 失败")
+	}
+	if len(existing) >= maxKeysPerUser {
+		return nil, status.Errorf(codes.FailedPrecondition, "每个用户最多创建%d个 API Key", maxKeysPerUser)
+	}
+
+	key, prefix, err := keyutil.Generate()
+	if err != nil {
+		logger.Log.Error("创建 API Key 失败：生成 Key 错误", zap.Error(err))
+		return nil, status.Error(codes.Internal, "创建 API Key 失败")
+	}
+	record := &model.APIKey{
+		UserID:  uint(req.UserId),
+		Name:    name,
+		Prefix:  prefix,
+		KeyHash: keyutil.Hash(key),
+		Scopes:  strings.Join(scopes, ","),
+	}
+	if req.ExpiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, int(req.ExpiresInDays))
+		record.ExpiresAt = &expiresAt
+	}
+	if err := s.apiKeyRepo.Create(ctx, record); err != nil {
+		logger.Log.Error("创建 API Key 失败", zap.Uint32("user_id", req.UserId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "创建 API Key 失败")
+	}
+
+	logger.Log.Info("创建 API Key 成功",
+		zap.Uint32("user_id", req.UserId),
+		zap.String("prefix", prefix),
+		zap.Strings("scopes", scopes))
+	return &userpb.CreateAPIKeyResponse{Key: key, Info: keyInfo(record)}, nil
+}
+
+// ListAPIKeys 获取用户的所有 API Key
+func (s *APIKeyService) ListAPIKeys(ctx context.Context, req *userpb.ListAPIKeysRequest) (*userpb.ListAPIKeysResponse, error) {
+	keys, err := s.apiKeyRepo.ListByUser(ctx, uint(req.UserId))
+	if err != nil {
+		logger.Log.Error("获取 API Key 列表失败", zap.Uint32("user_id", req.UserId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "获取 API Key 列表失败")
+	}
+	resp := &userpb.ListAPIKeysResponse{Keys: make([]*userpb.APIKeyInfo, 0, len(keys))}
+	for i := range keys {
+		resp.Keys = append(resp.Keys, keyInfo(&keys[i]))
+	}
+	return resp, nil
+}
+
+// RevokeAPIKey 吊销 API Key，立即失效
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, req *userpb.RevokeAPIKeyRequest) (*userpb.RevokeAPIKeyResponse, error) {
+	err := s.apiKeyRepo.Delete(ctx, uint(req.UserId), uint(req.KeyId))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.NotFound, "API Key 不存在")
+	}
+	if err != nil {
+		logger.Log.Error("吊销 API Key 失败", zap.Uint32("user_id", req.UserId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "吊销 API Key 失败")
+	}
+	logger.Log.Info("吊销 API Key 成功", zap.Uint32("user_id", req.UserId), zap.Uint32("key_id", req.KeyId))
+	return &userpb.RevokeAPIKeyResponse{Message: "吊销成功"}, nil
+}
+
+// VerifyAPIKey 校验 API Key，返回所属用户和权限范围
+func (s *APIKeyService) VerifyAPIKey(ctx context.Context, req *userpb.VerifyAPIKeyRequest) (*userpb.VerifyAPIKeyResponse, error) {
+	prefix, err := keyutil.Parse(req.Key)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "无效的 API Key")
+	}
+	record, err := s.apiKeyRepo.FindByPrefix(ctx, prefix)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.Unauthenticated, "无效的 API Key")
+	}
+	if err != nil {
+		logger.Log.Error("校验 API Key 失败：查询错误", zap.String("prefix", prefix), zap.Error(err))
+		return nil, status.Error(codes.Internal, "校验 API Key 失败")
+	}
+	if !keyutil.Verify(req.Key, record.KeyHash) {
+		logger.Log.Warn("API Key 校验失败", zap.String("prefix", prefix))
+		return nil, status.Error(codes.Unauthenticated, "无效的 API Key")
+	}
+	now := time.Now()
+	if record.Expired(now) {
+		return nil, status.Error(codes.Unauthenticated, "API Key 已过期")
+	}
+
+	user, err := s.userRepository.FindByID(record.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.Unauthenticated, "用户不存在")
+	}
+	if err != nil {
+		logger.Log.Error("校验 API Key 失败：查询用户错误", zap.Uint("user_id", record.UserID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "校验 API Key 失败")
+	}
+	if user.Status == 1 {
+		return nil, status.Error(codes.Unauthenticated, "用户已被禁用")
+	}
+
+	// 最近使用时间只用于展示，写入失败不影响本次请求
+	if record.LastUsedAt == nil || now.Sub(*record.LastUsedAt) >= lastUsedInterval {
+		if err := s.apiKeyRepo.TouchLastUsed(ctx, record.ID, now); err != nil {
+			logger.Log.Warn("更新 API Key 最近使用时间失败", zap.Uint("key_id", record.ID), zap.Error(err))
+		}
+	}
+
+	return &userpb.VerifyAPIKeyResponse{
+		UserId: uint32(user.ID),
+		Role:   user.Role,
+		Scopes: record.ScopeList(),
+	}, nil
+}
+
+// normalizeScopes 校验并去重权限范围
+func normalizeScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "至少需要一个权限范围")
+	}
+	seen := make(map[string]bool, len(scopes))
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if !model.IsValidScope(scope) {
+			return nil, status.Errorf(codes.InvalidArgument, "不支持的权限范围: %s", scope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			result = append(result, scope)
+		}
+	}
+	return result, nil
+}
+
+// keyInfo 转换为不包含 Key 本身的 API Key 信息
+func keyInfo(k *model.APIKey) *userpb.APIKeyInfo {
+	info := &userpb.APIKeyInfo{
+		Id:        uint32(k.ID),
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Scopes:    k.ScopeList(),
+		CreatedAt: k.CreatedAt.Unix(),
+	}
+	if k.ExpiresAt != nil {
+		info.ExpiresAt = k.ExpiresAt.Unix()
+	}
+	if k.LastUsedAt != nil {
+		info.LastUsedAt = k.LastUsedAt.Unix()
+	}
+	return info
+}
@@ -0,0 +1,218 @@
+package apikey
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"shortLink/proto/userpb"
+	"shortLink/userservice/logger"
+	"shortLink/userservice/model"
+	keyutil "shortLink/userservice/pkg/apikey"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// MockAPIKeyRepository 模拟APIKeyRepository
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *model.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) ListByUser(ctx context.Context, userID uint) ([]model.APIKey, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) FindByPrefix(ctx context.Context, prefix string) (*model.APIKey, error) {
+	args := m.Called(ctx, prefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Delete(ctx context.Context, userID, id uint) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id uint, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+// MockUserRepository 模拟UserRepository
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) FindByUsername(username string) (*model.User, error) {
+	args := m.Called(username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Create(user *model.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) FindByID(id uint) (*model.User, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) DeleteByID(id uint) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func setupTestLogger() {
+	// 创建一个测试用的logger，输出到内存而不是控制台或文件
+	encoder := zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
+	core := zapcore.NewCore(encoder, zapcore.AddSync(io.Discard), zapcore.DebugLevel)
+	logger.Log = zap.New(core)
+}
+
+func TestAPIKeyService_CreateAPIKey(t *testing.T) {
+	setupTestLogger()
+
+	t.Run("创建成功只保存哈希", func(t *testing.T) {
+		keyRepo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(keyRepo, new(MockUserRepository))
+		var saved *model.APIKey
+		keyRepo.On("ListByUser", mock.Anything, uint(1)).Return([]model.APIKey{}, nil)
+		keyRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.APIKey")).
+			Run(func(args mock.Arguments) { saved = args.Get(1).(*model.APIKey) }).
+			Return(nil)
+
+		resp, err := service.CreateAPIKey(context.Background(), &userpb.CreateAPIKeyRequest{
+			UserId:        1,
+			Name:          "ci",
+			Scopes:        []string{model.ScopeLinksWrite, model.ScopeLinksRead, model.ScopeLinksWrite},
+			ExpiresInDays: 30,
+		})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, resp.Key)
+		assert.Equal(t, []string{model.ScopeLinksWrite, model.ScopeLinksRead}, resp.Info.Scopes)
+		assert.NotZero(t, resp.Info.ExpiresAt)
+		assert.Equal(t, keyutil.Hash(resp.Key), saved.KeyHash)
+		assert.NotEqual(t, resp.Key, saved.KeyHash)
+		keyRepo.AssertExpectations(t)
+	})
+
+	t.Run("不支持的权限范围", func(t *testing.T) {
+		service := NewAPIKeyService(new(MockAPIKeyRepository), new(MockUserRepository))
+		_, err := service.CreateAPIKey(context.Background(), &userpb.CreateAPIKeyRequest{
+			UserId: 1,
+			Name:   "ci",
+			Scopes: []string{"admin"},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("超过数量上限", func(t *testing.T) {
+		keyRepo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(keyRepo, new(MockUserRepository))
+		keyRepo.On("ListByUser", mock.Anything, uint(1)).Return(make([]model.APIKey, maxKeysPerUser), nil)
+
+		_, err := service.CreateAPIKey(context.Background(), &userpb.CreateAPIKeyRequest{
+			UserId: 1,
+			Name:   "ci",
+			Scopes: []string{model.ScopeLinksRead},
+		})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestAPIKeyService_VerifyAPIKey(t *testing.T) {
+	setupTestLogger()
+	key, prefix, err := keyutil.Generate()
+	assert.NoError(t, err)
+
+	t.Run("校验成功并更新最近使用时间", func(t *testing.T) {
+		keyRepo := new(MockAPIKeyRepository)
+		userRepo := new(MockUserRepository)
+		service := NewAPIKeyService(keyRepo, userRepo)
+		keyRepo.On("FindByPrefix", mock.Anything, prefix).Return(&model.APIKey{
+			ID: 5, UserID: 1, Prefix: prefix, KeyHash: keyutil.Hash(key), Scopes: "links:read,analytics:read",
+		}, nil)
+		userRepo.On("FindByID", uint(1)).Return(&model.User{ID: 1, Role: "user"}, nil)
+		keyRepo.On("TouchLastUsed", mock.Anything, uint(5), mock.AnythingOfType("time.Time")).Return(nil)
+
+		resp, err := service.VerifyAPIKey(context.Background(), &userpb.VerifyAPIKeyRequest{Key: key})
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(1), resp.UserId)
+		assert.Equal(t, "user", resp.Role)
+		assert.Equal(t, []string{model.ScopeLinksRead, model.ScopeAnalyticsRead}, resp.Scopes)
+		keyRepo.AssertExpectations(t)
+	})
+
+	t.Run("最近刚使用过不重复写入", func(t *testing.T) {
+		keyRepo := new(MockAPIKeyRepository)
+		userRepo := new(MockUserRepository)
+		service := NewAPIKeyService(keyRepo, userRepo)
+		recent := time.Now().Add(-10 * time.Second)
+		keyRepo.On("FindByPrefix", mock.Anything, prefix).Return(&model.APIKey{
+			ID: 5, UserID: 1, Prefix: prefix, KeyHash: keyutil.Hash(key), Scopes: "links:read", LastUsedAt: &recent,
+		}, nil)
+		userRepo.On("FindByID", uint(1)).Return(&model.User{ID: 1, Role: "user"}, nil)
+
+		_, err := service.VerifyAPIKey(context.Background(), &userpb.VerifyAPIKeyRequest{Key: key})
+		assert.NoError(t, err)
+		keyRepo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("密钥不匹配", func(t *testing.T) {
+		keyRepo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(keyRepo, new(MockUserRepository))
+		other, _, _ := keyutil.Generate()
+		keyRepo.On("FindByPrefix", mock.Anything, prefix).Return(&model.APIKey{
+			ID: 5, UserID: 1, Prefix: prefix, KeyHash: keyutil.Hash(other),
+		}, nil)
+
+		_, err := service.VerifyAPIKey(context.Background(), &userpb.VerifyAPIKeyRequest{Key: key})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("已过期", func(t *testing.T) {
+		keyRepo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(keyRepo, new(MockUserRepository))
+		expired := time.Now().Add(-time.Hour)
+		keyRepo.On("FindByPrefix", mock.Anything, prefix).Return(&model.APIKey{
+			ID: 5, UserID: 1, Prefix: prefix, KeyHash: keyutil.Hash(key), ExpiresAt: &expired,
+		}, nil)
+
+		_, err := service.VerifyAPIKey(context.Background(), &userpb.VerifyAPIKeyRequest{Key: key})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("已吊销", func(t *testing.T) {
+		keyRepo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(keyRepo, new(MockUserRepository))
+		keyRepo.On("FindByPrefix", mock.Anything, prefix).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := service.VerifyAPIKey(context.Background(), &userpb.VerifyAPIKeyRequest{Key: key})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
@@ -2,6 +2,7 @@ package rbac
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"shortLink/proto/userpb"
 	"shortLink/userservice/logger"
@@ -9,6 +10,7 @@ import (
 	"shortLink/userservice/repository"
 
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 // RBACService 实现RBAC相关的gRPC服务
@@ -66,6 +68,51 @@ func (s *RBACService) CheckPermission(ctx context.Context, req *userpb.CheckPerm
 	}, nil
 }
 
+// CheckWorkspacePermission 实现gRPC接口，按用户在工作区中的角色检查权限
+func (s *RBACService) CheckWorkspacePermission(ctx context.Context, req *userpb.CheckWorkspacePermissionRequest) (*userpb.CheckWorkspacePermissionResponse, error) {
+	userID := uint(req.UserId)
+	workspaceID := uint(req.WorkspaceId)
+
+	role, err := s.rbacRepo.GetWorkspaceRole(ctx, workspaceID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Log.Warn("工作区权限检查失败：不是工作区成员",
+			zap.Uint("user_id", userID),
+			zap.Uint("workspace_id", workspaceID))
+		return &userpb.CheckWorkspacePermissionResponse{
+			HasPermission: false,
+			Message:       "不是工作区成员",
+		}, nil
+	}
+	if err != nil {
+		logger.Log.Error("检查工作区权限失败",
+			zap.Uint("user_id", userID),
+			zap.Uint("workspace_id", workspaceID),
+			zap.Error(err))
+		return &userpb.CheckWorkspacePermissionResponse{
+			HasPermission: false,
+			Message:       "检查权限时发生错误",
+		}, err
+	}
+
+	hasPermission := model.WorkspaceRoleAllows(role, req.Resource, req.Action)
+	message := "用户有权限执行该操作"
+	if !hasPermission {
+		message = fmt.Sprintf("工作区角色 %s 没有权限执行该操作: %s %s", role, req.Resource, req.Action)
+		logger.Log.Warn("工作区权限检查失败",
+			zap.Uint("user_id", userID),
+			zap.Uint("workspace_id", workspaceID),
+			zap.String("role", role),
+			zap.String("resource", req.Resource),
+			zap.String("action", req.Action))
+	}
+
+	return &userpb.CheckWorkspacePermissionResponse{
+		HasPermission: hasPermission,
+		Role:          role,
+		Message:       message,
+	}, nil
+}
+
 // GetUserRoles 实现gRPC接口，获取用户的所有角色
 func (s *RBACService) GetUserRoles(ctx context.Context, req *userpb.GetUserRolesRequest) (*userpb.GetUserRolesResponse, error) {
 	userID := uint(req.UserId)
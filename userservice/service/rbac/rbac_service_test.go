@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gorm.io/gorm"
 )
 
 // MockRBACRepository 模拟RBACRepository
@@ -68,6 +69,12 @@ func (m *MockRBACRepository) HasPermission(ctx context.Context, userID uint, res
 	return args.Bool(0), args.Error(1)
 }
 
+// GetWorkspaceRole implements repository.RBACRepository.
+func (m *MockRBACRepository) GetWorkspaceRole(ctx context.Context, workspaceID uint, userID uint) (string, error) {
+	args := m.Called(ctx, workspaceID, userID)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockRBACRepository) GetUserRoles(ctx context.Context, userID uint) ([]model.Role, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
@@ -236,6 +243,75 @@ func TestRBACService_CheckPermission(t *testing.T) {
 	})
 }
 
+func TestRBACService_CheckWorkspacePermission(t *testing.T) {
+	// 初始化日志系统
+	setupTestLogger()
+	// 初始化mock对象
+	rbacRepo := new(MockRBACRepository)
+	cacheRepo := new(MockRBACCacheRepository)
+	service := NewRBACService(rbacRepo, cacheRepo)
+
+	// 测试用例
+	t.Run("编辑者可以修改短链接", func(t *testing.T) {
+		rbacRepo.On("GetWorkspaceRole", mock.Anything, uint(10), uint(1)).Return(model.WorkspaceEditor, nil)
+
+		resp, err := service.CheckWorkspacePermission(context.Background(), &userpb.CheckWorkspacePermissionRequest{
+			UserId:      1,
+			WorkspaceId: 10,
+			Resource:    "link",
+			Action:      "update",
+		})
+		assert.NoError(t, err)
+		assert.True(t, resp.HasPermission)
+		assert.Equal(t, model.WorkspaceEditor, resp.Role)
+		rbacRepo.AssertExpectations(t)
+	})
+
+	t.Run("查看者不能修改短链接", func(t *testing.T) {
+		rbacRepo.On("GetWorkspaceRole", mock.Anything, uint(10), uint(2)).Return(model.WorkspaceViewer, nil)
+
+		resp, err := service.CheckWorkspacePermission(context.Background(), &userpb.CheckWorkspacePermissionRequest{
+			UserId:      2,
+			WorkspaceId: 10,
+			Resource:    "link",
+			Action:      "update",
+		})
+		assert.NoError(t, err)
+		assert.False(t, resp.HasPermission)
+		assert.Equal(t, model.WorkspaceViewer, resp.Role)
+		rbacRepo.AssertExpectations(t)
+	})
+
+	t.Run("不是工作区成员", func(t *testing.T) {
+		rbacRepo.On("GetWorkspaceRole", mock.Anything, uint(10), uint(3)).Return("", gorm.ErrRecordNotFound)
+
+		resp, err := service.CheckWorkspacePermission(context.Background(), &userpb.CheckWorkspacePermissionRequest{
+			UserId:      3,
+			WorkspaceId: 10,
+			Resource:    "link",
+			Action:      "read",
+		})
+		assert.NoError(t, err)
+		assert.False(t, resp.HasPermission)
+		assert.Empty(t, resp.Role)
+		rbacRepo.AssertExpectations(t)
+	})
+
+	t.Run("检查工作区权限出错", func(t *testing.T) {
+		rbacRepo.On("GetWorkspaceRole", mock.Anything, uint(11), uint(1)).Return("", assert.AnError)
+
+		resp, err := service.CheckWorkspacePermission(context.Background(), &userpb.CheckWorkspacePermissionRequest{
+			UserId:      1,
+			WorkspaceId: 11,
+			Resource:    "link",
+			Action:      "read",
+		})
+		assert.Error(t, err)
+		assert.False(t, resp.HasPermission)
+		rbacRepo.AssertExpectations(t)
+	})
+}
+
 func TestRBACService_CreateRole(t *testing.T) {
 	// 初始化日志系统
 	setupTestLogger()
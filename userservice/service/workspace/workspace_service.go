@@ -0,0 +1,259 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"shortLink/proto/userpb"
+	"shortLink/userservice/logger"
+	"shortLink/userservice/model"
+	"shortLink/userservice/repository"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+const maxWorkspaceNameLen = 100
+
+// WorkspaceService 实现工作区相关的gRPC服务
+type WorkspaceService struct {
+	userpb.UnimplementedWorkspaceServiceServer
+	workspaceRepo  repository.WorkspaceRepository
+	userRepository repository.UserRepository
+}
+
+// NewWorkspaceService 创建工作区服务实例
+func NewWorkspaceService(workspaceRepo repository.WorkspaceRepository, userRepository repository.UserRepository) *WorkspaceService {
+	return &WorkspaceService{
+		workspaceRepo:  workspaceRepo,
+		userRepository: userRepository,
+	}
+}
+
+// CreateWorkspace 创建工作区，创建者成为所有者
+func (s *WorkspaceService) CreateWorkspace(ctx context.Context, req *userpb.CreateWorkspaceRequest) (*userpb.CreateWorkspaceResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "工作区名称不能为空")
+	}
+	if len([]rune(name)) > maxWorkspaceNameLen {
+		return nil, status.Errorf(codes.InvalidArgument, "工作区名称不能超过%d个字符", maxWorkspaceNameLen)
+	}
+
+	workspace := &model.Workspace{Name: name, CreatedBy: uint(req.UserId)}
+	if err := s.workspaceRepo.CreateWorkspace(ctx, workspace, uint(req.UserId)); err != nil {
+		logger.Log.Error("创建工作区失败", zap.Uint32("user_id", req.UserId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "创建工作区失败")
+	}
+
+	logger.Log.Info("创建工作区成功",
+		zap.Uint32("user_id", req.UserId),
+		zap.Uint("workspace_id", workspace.ID))
+	return &userpb.CreateWorkspaceResponse{Workspace: &userpb.WorkspaceInfo{
+		Id:        uint32(workspace.ID),
+		Name:      workspace.Name,
+		Role:      model.WorkspaceOwner,
+		CreatedAt: workspace.CreatedAt.Unix(),
+	}}, nil
+}
+
+// ListWorkspaces 获取用户加入的所有工作区
+func (s *WorkspaceService) ListWorkspaces(ctx context.Context, req *userpb.ListWorkspacesRequest) (*userpb.ListWorkspacesResponse, error) {
+	workspaces, err := s.workspaceRepo.ListUserWorkspaces(ctx, uint(req.UserId))
+	if err != nil {
+		logger.Log.Error("获取工作区列表失败", zap.Uint32("user_id", req.UserId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "获取工作区列表失败")
+	}
+
+	resp := &userpb.ListWorkspacesResponse{Workspaces: make([]*userpb.WorkspaceInfo, 0, len(workspaces))}
+	for _, w := range workspaces {
+		resp.Workspaces = append(resp.Workspaces, &userpb.WorkspaceInfo{
+			Id:        uint32(w.ID),
+			Name:      w.Name,
+			Role:      w.Role,
+			CreatedAt: w.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// ListWorkspaceMembers 获取工作区的成员，工作区成员都可以查看
+func (s *WorkspaceService) ListWorkspaceMembers(ctx context.Context, req *userpb.ListWorkspaceMembersRequest) (*userpb.ListWorkspaceMembersResponse, error) {
+	if _, err := s.authorize(ctx, req.WorkspaceId, req.UserId, "read"); err != nil {
+		return nil, err
+	}
+
+	members, err := s.workspaceRepo.ListMembers(ctx, uint(req.WorkspaceId))
+	if err != nil {
+		logger.Log.Error("获取工作区成员失败", zap.Uint32("workspace_id", req.WorkspaceId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "获取工作区成员失败")
+	}
+
+	resp := &userpb.ListWorkspaceMembersResponse{Members: make([]*userpb.WorkspaceMemberInfo, 0, len(members))}
+	for _, m := range members {
+		resp.Members = append(resp.Members, &userpb.WorkspaceMemberInfo{
+			UserId:   uint32(m.UserID),
+			Username: m.Username,
+			Nickname: m.Nickname,
+			Role:     m.Role,
+			JoinedAt: m.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// AddWorkspaceMember 按用户名添加工作区成员，只有所有者可以操作
+func (s *WorkspaceService) AddWorkspaceMember(ctx context.Context, req *userpb.AddWorkspaceMemberRequest) (*userpb.AddWorkspaceMemberResponse, error) {
+	if !model.IsWorkspaceRole(req.Role) {
+		return nil, status.Errorf(codes.InvalidArgument, "无效的工作区角色: %s", req.Role)
+	}
+	if _, err := s.authorize(ctx, req.WorkspaceId, req.UserId, "manage"); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepository.FindByUsername(req.Username)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "用户 %s 不存在", req.Username)
+	}
+	if err != nil {
+		logger.Log.Error("添加工作区成员失败：查询用户错误", zap.String("username", req.Username), zap.Error(err))
+		return nil, status.Error(codes.Internal, "添加工作区成员失败")
+	}
+
+	if _, err := s.workspaceRepo.GetMemberRole(ctx, uint(req.WorkspaceId), user.ID); err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "用户 %s 已经是工作区成员", req.Username)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Log.Error("添加工作区成员失败：查询成员错误", zap.Uint32("workspace_id", req.WorkspaceId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "添加工作区成员失败")
+	}
+
+	member := &model.WorkspaceMember{WorkspaceID: uint(req.WorkspaceId), UserID: user.ID, Role: req.Role}
+	if err := s.workspaceRepo.AddMember(ctx, member); err != nil {
+		logger.Log.Error("添加工作区成员失败", zap.Uint32("workspace_id", req.WorkspaceId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "添加工作区成员失败")
+	}
+
+	logger.Log.Info("添加工作区成员成功",
+		zap.Uint32("workspace_id", req.WorkspaceId),
+		zap.Uint("member_id", user.ID),
+		zap.String("role", req.Role))
+	return &userpb.AddWorkspaceMemberResponse{Member: &userpb.WorkspaceMemberInfo{
+		UserId:   uint32(user.ID),
+		Username: user.Username,
+		Nickname: user.Nickname,
+		Role:     member.Role,
+		JoinedAt: member.CreatedAt.Unix(),
+	}}, nil
+}
+
+// UpdateWorkspaceMember 修改成员的角色，只有所有者可以操作，工作区至少保留一个所有者
+func (s *WorkspaceService) UpdateWorkspaceMember(ctx context.Context, req *userpb.UpdateWorkspaceMemberRequest) (*userpb.UpdateWorkspaceMemberResponse, error) {
+	if !model.IsWorkspaceRole(req.Role) {
+		return nil, status.Errorf(codes.InvalidArgument, "无效的工作区角色: %s", req.Role)
+	}
+	if _, err := s.authorize(ctx, req.WorkspaceId, req.UserId, "manage"); err != nil {
+		return nil, err
+	}
+
+	current, err := s.memberRole(ctx, req.WorkspaceId, req.MemberId)
+	if err != nil {
+		return nil, err
+	}
+	if current == model.WorkspaceOwner && req.Role != model.WorkspaceOwner {
+		if err := s.ensureAnotherOwner(ctx, req.WorkspaceId); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.workspaceRepo.UpdateMemberRole(ctx, uint(req.WorkspaceId), uint(req.MemberId), req.Role); err != nil {
+		logger.Log.Error("修改工作区成员角色失败", zap.Uint32("workspace_id", req.WorkspaceId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "修改工作区成员角色失败")
+	}
+
+	logger.Log.Info("修改工作区成员角色成功",
+		zap.Uint32("workspace_id", req.WorkspaceId),
+		zap.Uint32("member_id", req.MemberId),
+		zap.String("role", req.Role))
+	return &userpb.UpdateWorkspaceMemberResponse{Message: "修改成功"}, nil
+}
+
+// RemoveWorkspaceMember 移除工作区成员，所有者可以移除任何人，成员也可以自己退出
+func (s *WorkspaceService) RemoveWorkspaceMember(ctx context.Context, req *userpb.RemoveWorkspaceMemberRequest) (*userpb.RemoveWorkspaceMemberResponse, error) {
+	if req.MemberId != req.UserId {
+		if _, err := s.authorize(ctx, req.WorkspaceId, req.UserId, "manage"); err != nil {
+			return nil, err
+		}
+	}
+
+	current, err := s.memberRole(ctx, req.WorkspaceId, req.MemberId)
+	if err != nil {
+		return nil, err
+	}
+	if current == model.WorkspaceOwner {
+		if err := s.ensureAnotherOwner(ctx, req.WorkspaceId); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.workspaceRepo.RemoveMember(ctx, uint(req.WorkspaceId), uint(req.MemberId)); err != nil {
+		logger.Log.Error("移除工作区成员失败", zap.Uint32("workspace_id", req.WorkspaceId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "移除工作区成员失败")
+	}
+
+	logger.Log.Info("移除工作区成员成功",
+		zap.Uint32("workspace_id", req.WorkspaceId),
+		zap.Uint32("member_id", req.MemberId),
+		zap.Uint32("operator", req.UserId))
+	return &userpb.RemoveWorkspaceMemberResponse{Message: "移除成功"}, nil
+}
+
+// authorize 校验用户在工作区中拥有 workspace:<action> 权限，返回用户的角色
+func (s *WorkspaceService) authorize(ctx context.Context, workspaceID, userID uint32, action string) (string, error) {
+	role, err := s.workspaceRepo.GetMemberRole(ctx, uint(workspaceID), uint(userID))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", status.Error(codes.PermissionDenied, "不是工作区成员")
+	}
+	if err != nil {
+		logger.Log.Error("查询工作区成员失败",
+			zap.Uint32("workspace_id", workspaceID),
+			zap.Uint32("user_id", userID),
+			zap.Error(err))
+		return "", status.Error(codes.Internal, "查询工作区成员失败")
+	}
+	if !model.WorkspaceRoleAllows(role, "workspace", action) {
+		return "", status.Error(codes.PermissionDenied, "只有工作区所有者可以管理成员")
+	}
+	return role, nil
+}
+
+// memberRole 获取被操作成员的角色
+func (s *WorkspaceService) memberRole(ctx context.Context, workspaceID, memberID uint32) (string, error) {
+	role, err := s.workspaceRepo.GetMemberRole(ctx, uint(workspaceID), uint(memberID))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", status.Error(codes.NotFound, "成员不存在")
+	}
+	if err != nil {
+		logger.Log.Error("查询工作区成员失败",
+			zap.Uint32("workspace_id", workspaceID),
+			zap.Uint32("member_id", memberID),
+			zap.Error(err))
+		return "", status.Error(codes.Internal, "查询工作区成员失败")
+	}
+	return role, nil
+}
+
+// ensureAnotherOwner 确认去掉一个所有者后工作区仍有所有者
+func (s *WorkspaceService) ensureAnotherOwner(ctx context.Context, workspaceID uint32) error {
+	owners, err := s.workspaceRepo.CountOwners(ctx, uint(workspaceID))
+	if err != nil {
+		logger.Log.Error("统计工作区所有者失败", zap.Uint32("workspace_id", workspaceID), zap.Error(err))
+		return status.Error(codes.Internal, "统计工作区所有者失败")
+	}
+	if owners <= 1 {
+		return status.Error(codes.FailedPrecondition, "工作区至少需要保留一个所有者")
+	}
+	return nil
+}
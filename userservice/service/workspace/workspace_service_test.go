@@ -0,0 +1,229 @@
+package workspace
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"shortLink/proto/userpb"
+	"shortLink/userservice/logger"
+	"shortLink/userservice/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// MockWorkspaceRepository 模拟WorkspaceRepository
+type MockWorkspaceRepository struct {
+	mock.Mock
+}
+
+func (m *MockWorkspaceRepository) CreateWorkspace(ctx context.Context, workspace *model.Workspace, ownerID uint) error {
+	args := m.Called(ctx, workspace, ownerID)
+	return args.Error(0)
+}
+
+func (m *MockWorkspaceRepository) ListUserWorkspaces(ctx context.Context, userID uint) ([]model.WorkspaceWithRole, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.WorkspaceWithRole), args.Error(1)
+}
+
+func (m *MockWorkspaceRepository) GetMemberRole(ctx context.Context, workspaceID, userID uint) (string, error) {
+	args := m.Called(ctx, workspaceID, userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockWorkspaceRepository) ListMembers(ctx context.Context, workspaceID uint) ([]model.WorkspaceMemberInfo, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.WorkspaceMemberInfo), args.Error(1)
+}
+
+func (m *MockWorkspaceRepository) AddMember(ctx context.Context, member *model.WorkspaceMember) error {
+	args := m.Called(ctx, member)
+	return args.Error(0)
+}
+
+func (m *MockWorkspaceRepository) UpdateMemberRole(ctx context.Context, workspaceID, userID uint, role string) error {
+	args := m.Called(ctx, workspaceID, userID, role)
+	return args.Error(0)
+}
+
+func (m *MockWorkspaceRepository) RemoveMember(ctx context.Context, workspaceID, userID uint) error {
+	args := m.Called(ctx, workspaceID, userID)
+	return args.Error(0)
+}
+
+func (m *MockWorkspaceRepository) CountOwners(ctx context.Context, workspaceID uint) (int64, error) {
+	args := m.Called(ctx, workspaceID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockUserRepository 模拟UserRepository
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) FindByUsername(username string) (*model.User, error) {
+	args := m.Called(username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Create(user *model.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) FindByID(id uint) (*model.User, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) DeleteByID(id uint) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func setupTestLogger() {
+	// 创建一个测试用的logger，输出到内存而不是控制台或文件
+	encoder := zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
+	core := zapcore.NewCore(encoder, zapcore.AddSync(io.Discard), zapcore.DebugLevel)
+	logger.Log = zap.New(core)
+}
+
+func TestWorkspaceService_CreateWorkspace(t *testing.T) {
+	setupTestLogger()
+	workspaceRepo := new(MockWorkspaceRepository)
+	service := NewWorkspaceService(workspaceRepo, new(MockUserRepository))
+
+	t.Run("创建工作区成功", func(t *testing.T) {
+		workspaceRepo.On("CreateWorkspace", mock.Anything, mock.AnythingOfType("*model.Workspace"), uint(1)).
+			Run(func(args mock.Arguments) {
+				args.Get(1).(*model.Workspace).ID = 10
+			}).Return(nil)
+
+		resp, err := service.CreateWorkspace(context.Background(), &userpb.CreateWorkspaceRequest{UserId: 1, Name: " 市场部 "})
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(10), resp.Workspace.Id)
+		assert.Equal(t, "市场部", resp.Workspace.Name)
+		assert.Equal(t, model.WorkspaceOwner, resp.Workspace.Role)
+		workspaceRepo.AssertExpectations(t)
+	})
+
+	t.Run("名称为空", func(t *testing.T) {
+		_, err := service.CreateWorkspace(context.Background(), &userpb.CreateWorkspaceRequest{UserId: 1, Name: "  "})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestWorkspaceService_AddWorkspaceMember(t *testing.T) {
+	setupTestLogger()
+	workspaceRepo := new(MockWorkspaceRepository)
+	userRepo := new(MockUserRepository)
+	service := NewWorkspaceService(workspaceRepo, userRepo)
+
+	t.Run("所有者添加成员成功", func(t *testing.T) {
+		workspaceRepo.On("GetMemberRole", mock.Anything, uint(10), uint(1)).Return(model.WorkspaceOwner, nil)
+		userRepo.On("FindByUsername", "alice").Return(&model.User{ID: 2, Username: "alice"}, nil)
+		workspaceRepo.On("GetMemberRole", mock.Anything, uint(10), uint(2)).Return("", gorm.ErrRecordNotFound).Once()
+		workspaceRepo.On("AddMember", mock.Anything, &model.WorkspaceMember{WorkspaceID: 10, UserID: 2, Role: model.WorkspaceEditor}).Return(nil)
+
+		resp, err := service.AddWorkspaceMember(context.Background(), &userpb.AddWorkspaceMemberRequest{
+			UserId:      1,
+			WorkspaceId: 10,
+			Username:    "alice",
+			Role:        model.WorkspaceEditor,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(2), resp.Member.UserId)
+		assert.Equal(t, model.WorkspaceEditor, resp.Member.Role)
+		workspaceRepo.AssertExpectations(t)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("编辑者不能添加成员", func(t *testing.T) {
+		workspaceRepo.On("GetMemberRole", mock.Anything, uint(10), uint(3)).Return(model.WorkspaceEditor, nil)
+
+		_, err := service.AddWorkspaceMember(context.Background(), &userpb.AddWorkspaceMemberRequest{
+			UserId:      3,
+			WorkspaceId: 10,
+			Username:    "bob",
+			Role:        model.WorkspaceViewer,
+		})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("无效的角色", func(t *testing.T) {
+		_, err := service.AddWorkspaceMember(context.Background(), &userpb.AddWorkspaceMemberRequest{
+			UserId:      1,
+			WorkspaceId: 10,
+			Username:    "bob",
+			Role:        "admin",
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestWorkspaceService_RemoveWorkspaceMember(t *testing.T) {
+	setupTestLogger()
+
+	t.Run("不能移除最后一个所有者", func(t *testing.T) {
+		workspaceRepo := new(MockWorkspaceRepository)
+		service := NewWorkspaceService(workspaceRepo, new(MockUserRepository))
+		workspaceRepo.On("GetMemberRole", mock.Anything, uint(10), uint(1)).Return(model.WorkspaceOwner, nil)
+		workspaceRepo.On("CountOwners", mock.Anything, uint(10)).Return(int64(1), nil)
+
+		_, err := service.RemoveWorkspaceMember(context.Background(), &userpb.RemoveWorkspaceMemberRequest{
+			UserId:      1,
+			WorkspaceId: 10,
+			MemberId:    1,
+		})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		workspaceRepo.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("成员可以自己退出", func(t *testing.T) {
+		workspaceRepo := new(MockWorkspaceRepository)
+		service := NewWorkspaceService(workspaceRepo, new(MockUserRepository))
+		workspaceRepo.On("GetMemberRole", mock.Anything, uint(10), uint(2)).Return(model.WorkspaceViewer, nil)
+		workspaceRepo.On("RemoveMember", mock.Anything, uint(10), uint(2)).Return(nil)
+
+		resp, err := service.RemoveWorkspaceMember(context.Background(), &userpb.RemoveWorkspaceMemberRequest{
+			UserId:      2,
+			WorkspaceId: 10,
+			MemberId:    2,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "移除成功", resp.Message)
+		workspaceRepo.AssertExpectations(t)
+	})
+
+	t.Run("查看者不能移除其他成员", func(t *testing.T) {
+		workspaceRepo := new(MockWorkspaceRepository)
+		service := NewWorkspaceService(workspaceRepo, new(MockUserRepository))
+		workspaceRepo.On("GetMemberRole", mock.Anything, uint(10), uint(2)).Return(model.WorkspaceViewer, nil)
+
+		_, err := service.RemoveWorkspaceMember(context.Background(), &userpb.RemoveWorkspaceMemberRequest{
+			UserId:      2,
+			WorkspaceId: 10,
+			MemberId:    3,
+		})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}